@@ -16,8 +16,17 @@
 package ast
 
 import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
 	"github.com/pingcap/tidb/context"
 	"github.com/pingcap/tidb/model"
+	"github.com/pingcap/tidb/mysql"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util/types"
 )
 
@@ -32,11 +41,36 @@ type Node interface {
 	// assign the returned node to its method receiver, if skipChildren returns true,
 	// children should be skipped. Otherwise, call its children in particular order that
 	// later elements depends on former elements. Finally, return visitor.Leave.
+	// Statements visit their children in the order they appear in the source text,
+	// except that the tables are visited before the names referring to them:
+	// SelectStmt visits From and Where before Fields, InsertStmt visits Select
+	// before Table, and DeleteStmt visits TableRefs before Tables. Visitors may rely on it.
+	// The nodes defined in misc.go skip nil children, so Accept never panics on a
+	// partially built node.
 	Accept(v Visitor) (node Node, ok bool)
 	// Text returns the original text of the element.
 	Text() string
 	// SetText sets original text to the Node.
 	SetText(text string)
+	// OriginTextPosition returns the byte offset in the original SQL where the node begins,
+	// or -1 if it is unknown. The parser sets it on statements, fields, column and table
+	// names, parenthesized expressions, parameter markers and subqueries.
+	OriginTextPosition() int
+	// SetOriginTextPosition sets the byte offset in the original SQL where the node begins.
+	SetOriginTextPosition(offset int)
+	// IsInternal checks whether the node is from SQL generated by TiDB itself, like the SQL
+	// to load statistics or to update the privilege tables, rather than from a user.
+	IsInternal() bool
+	// SetInternal marks the node as from SQL generated by TiDB itself.
+	SetInternal()
+}
+
+// RestoreNode is a Node that can be restored to SQL text.
+// Parsing the restored text should produce a node equal to the original one.
+type RestoreNode interface {
+	Node
+	// Restore writes the SQL text of the node to w.
+	Restore(w io.Writer) error
 }
 
 // Flags indicates whether an expression contains certain types of expression.
@@ -169,11 +203,16 @@ type Statement interface {
 }
 
 // Visitor visits a Node.
+// A Visitor which stops visiting should implement ErrorVisitor to tell the caller why,
+// for example with an UnsupportedNodeError for a node it can not handle, and the caller
+// should use AcceptWithError to get the reason.
 type Visitor interface {
 	// Enter is called before children nodes are visited.
 	// The returned node must be the same type as the input node n.
 	// skipChildren returns true means children nodes should be skipped,
 	// this is useful when work is done in Enter and there is no need to visit children.
+	// Leave is still called on the returned node when its children are skipped,
+	// so bookkeeping done in Enter can always be undone in Leave.
 	Enter(n Node) (node Node, skipChildren bool)
 	// Leave is called after children nodes have been visited.
 	// The returned node's type can be different from the input node if it is a ExprNode,
@@ -181,3 +220,412 @@ type Visitor interface {
 	// ok returns false to stop visiting.
 	Leave(n Node) (node Node, ok bool)
 }
+
+// ErrorVisitor is a Visitor which keeps the reason why it stops visiting.
+type ErrorVisitor interface {
+	Visitor
+	// Error returns the reason why the visitor stopped visiting, nil if it didn't stop.
+	Error() error
+}
+
+// ErrVisitStopped is returned by AcceptWithError when a visitor stops visiting without a reason.
+var ErrVisitStopped = errors.New("visiting is stopped")
+
+// UnsupportedNodeError is the error of a visitor which stops on a node it can not handle.
+type UnsupportedNodeError struct {
+	Node Node
+}
+
+// Error implements error interface.
+func (e *UnsupportedNodeError) Error() string {
+	t := reflect.TypeOf(e.Node)
+	if t == nil {
+		return "nil node is not supported"
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return fmt.Sprintf("%s is not supported", t.Name())
+}
+
+// AcceptWithError calls n.Accept(v) and returns the reason if v stops visiting.
+// The reason is v.Error() if v is an ErrorVisitor, otherwise it is ErrVisitStopped.
+func AcceptWithError(n Node, v Visitor) (Node, error) {
+	node, ok := n.Accept(v)
+	if ok {
+		return node, nil
+	}
+	if ev, ok := v.(ErrorVisitor); ok {
+		if err := ev.Error(); err != nil {
+			return node, errors.Trace(err)
+		}
+	}
+	return node, ErrVisitStopped
+}
+
+// Walk traverses the AST rooted at node in depth-first order. It calls fn(n) when
+// entering each node n, and skips the children of n if fn returns false.
+// Walk is read-only, fn can inspect or modify a node in place but can not replace it.
+func Walk(node Node, fn func(Node) bool) {
+	node.Accept(inspector(fn))
+}
+
+// inspector adapts a func to the Visitor interface, it always returns the visited node.
+type inspector func(Node) bool
+
+// Enter implements Visitor interface.
+func (f inspector) Enter(n Node) (Node, bool) {
+	return n, !f(n)
+}
+
+// Leave implements Visitor interface.
+func (f inspector) Leave(n Node) (Node, bool) {
+	return n, true
+}
+
+// ChainVisitors returns a Visitor that applies visitors in a single traversal.
+// On each node, Enter is called on visitors in order and Leave in reverse order,
+// each one receives the node returned by the previous one.
+// If any visitor's Enter skips children, the children are skipped for all visitors,
+// but the remaining visitors' Enter and all visitors' Leave are still called on the node.
+// If any visitor's Leave returns ok false, the remaining visitors' Leave are not called
+// and the traversal stops. The returned Visitor is an ErrorVisitor, its Error returns
+// the first error of the visitors which are ErrorVisitors.
+func ChainVisitors(visitors ...Visitor) Visitor {
+	return chainVisitor(visitors)
+}
+
+// chainVisitor is the Visitor returned by ChainVisitors.
+type chainVisitor []Visitor
+
+// Enter implements Visitor interface.
+func (c chainVisitor) Enter(n Node) (Node, bool) {
+	var skipChildren bool
+	for _, v := range c {
+		var skip bool
+		n, skip = v.Enter(n)
+		skipChildren = skipChildren || skip
+	}
+	return n, skipChildren
+}
+
+// Leave implements Visitor interface.
+func (c chainVisitor) Leave(n Node) (Node, bool) {
+	for i := len(c) - 1; i >= 0; i-- {
+		var ok bool
+		n, ok = c[i].Leave(n)
+		if !ok {
+			return n, false
+		}
+	}
+	return n, true
+}
+
+// Error implements ErrorVisitor interface.
+func (c chainVisitor) Error() error {
+	for _, v := range c {
+		if ev, ok := v.(ErrorVisitor); ok {
+			if err := ev.Error(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ExtractTableRefs returns the tables referenced in the AST rooted at n, in the order they appear.
+// Tables are de-duplicated by schema and name, so a self-join is returned once.
+// Unqualified names are not resolved against the current database.
+func ExtractTableRefs(n Node) []*TableName {
+	extractor := &tableRefsExtractor{seen: make(map[string]struct{})}
+	n.Accept(extractor)
+	return extractor.tables
+}
+
+// tableRefsExtractor is the visitor to collect the table names of a statement.
+type tableRefsExtractor struct {
+	tables []*TableName
+	seen   map[string]struct{}
+}
+
+// Enter implements Visitor interface.
+func (e *tableRefsExtractor) Enter(n Node) (Node, bool) {
+	if t, ok := n.(*TableName); ok {
+		key := t.Schema.L + "." + t.Name.L
+		if _, ok := e.seen[key]; !ok {
+			e.seen[key] = struct{}{}
+			e.tables = append(e.tables, t)
+		}
+		return n, true
+	}
+	return n, false
+}
+
+// Leave implements Visitor interface.
+func (e *tableRefsExtractor) Leave(n Node) (Node, bool) {
+	return n, true
+}
+
+// ExtractVariables returns the names of the user variables and system variables
+// read or assigned in the AST rooted at n, lower-cased, de-duplicated and in the order Accept visits them.
+// SET NAMES and SET CHARACTER SET are reported as the system variables they assign.
+func ExtractVariables(n Node) (user []string, system []string) {
+	extractor := &variablesExtractor{seen: make(map[string]struct{})}
+	n.Accept(extractor)
+	return extractor.user, extractor.system
+}
+
+// variablesExtractor is the visitor to collect the variable names of a statement.
+type variablesExtractor struct {
+	user   []string
+	system []string
+	seen   map[string]struct{}
+}
+
+// Enter implements Visitor interface.
+func (e *variablesExtractor) Enter(n Node) (Node, bool) {
+	switch x := n.(type) {
+	case *SetNamesStmt:
+		e.addSetNames()
+	case *VariableAssignment:
+		if x.Name == SetNames {
+			e.addSetNames()
+		} else {
+			e.add(x.Name, x.IsSystem)
+		}
+	case *VariableExpr:
+		e.add(x.Name, x.IsSystem)
+	}
+	return n, false
+}
+
+// Leave implements Visitor interface.
+func (e *variablesExtractor) Leave(n Node) (Node, bool) {
+	return n, true
+}
+
+func (e *variablesExtractor) addSetNames() {
+	for _, name := range variable.SetNamesVariables {
+		e.add(name, true)
+	}
+	e.add(variable.CollationConnection, true)
+}
+
+func (e *variablesExtractor) add(name string, isSystem bool) {
+	name = strings.ToLower(name)
+	key := "@" + name
+	if isSystem {
+		key = "@@" + name
+	}
+	if _, ok := e.seen[key]; ok {
+		return
+	}
+	e.seen[key] = struct{}{}
+	if isSystem {
+		e.system = append(e.system, name)
+	} else {
+		e.user = append(e.user, name)
+	}
+}
+
+// ExtractSubqueries returns the subqueries in the AST rooted at n, including the nested ones,
+// in the order they appear in the source text. Correlated is only set once the statement is resolved.
+// Derived tables in the FROM clause are not SubqueryExpr, they are not returned.
+func ExtractSubqueries(n Node) []*SubqueryExpr {
+	extractor := &subqueriesExtractor{}
+	n.Accept(extractor)
+	// Accept visits the WHERE clause before the fields of a SELECT, so sort by the position.
+	sort.Stable(subqueriesByPosition(extractor.subqueries))
+	return extractor.subqueries
+}
+
+// subqueriesByPosition sorts the subqueries by where they begin in the source text.
+type subqueriesByPosition []*SubqueryExpr
+
+func (s subqueriesByPosition) Len() int      { return len(s) }
+func (s subqueriesByPosition) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s subqueriesByPosition) Less(i, j int) bool {
+	return s[i].OriginTextPosition() < s[j].OriginTextPosition()
+}
+
+// subqueriesExtractor is the visitor to collect the subqueries of a statement.
+type subqueriesExtractor struct {
+	subqueries []*SubqueryExpr
+}
+
+// Enter implements Visitor interface.
+func (e *subqueriesExtractor) Enter(n Node) (Node, bool) {
+	if s, ok := n.(*SubqueryExpr); ok {
+		e.subqueries = append(e.subqueries, s)
+	}
+	return n, false
+}
+
+// Leave implements Visitor interface.
+func (e *subqueriesExtractor) Leave(n Node) (Node, bool) {
+	return n, true
+}
+
+// RewriteSchema renames the databases referenced in the AST rooted at n according to mapping,
+// and returns the rewritten node. The keys of mapping are matched case-insensitively,
+// names not in mapping are left untouched.
+// Unqualified references are not rewritten, they follow the database selected by USE,
+// which is rewritten as well.
+func RewriteSchema(n Node, mapping map[string]string) Node {
+	rewriter := &schemaRewriter{mapping: make(map[string]string, len(mapping))}
+	for from, to := range mapping {
+		rewriter.mapping[strings.ToLower(from)] = to
+	}
+	node, _ := n.Accept(rewriter)
+	return node
+}
+
+// schemaRewriter is the visitor to rename databases.
+type schemaRewriter struct {
+	mapping map[string]string
+}
+
+// Enter implements Visitor interface.
+func (r *schemaRewriter) Enter(n Node) (Node, bool) {
+	return n, false
+}
+
+// Leave implements Visitor interface.
+func (r *schemaRewriter) Leave(n Node) (Node, bool) {
+	switch x := n.(type) {
+	case *TableName:
+		x.Schema = r.rewriteCIStr(x.Schema)
+	case *ColumnName:
+		x.Schema = r.rewriteCIStr(x.Schema)
+	case *UseStmt:
+		x.DBName = r.rewrite(x.DBName)
+	case *ShowStmt:
+		x.DBName = r.rewrite(x.DBName)
+	case *CreateDatabaseStmt:
+		x.Name = r.rewrite(x.Name)
+	case *DropDatabaseStmt:
+		x.Name = r.rewrite(x.Name)
+	case *GrantStmt:
+		x.Level.DBName = r.rewrite(x.Level.DBName)
+	case *RevokeStmt:
+		x.Level.DBName = r.rewrite(x.Level.DBName)
+	}
+	return n, true
+}
+
+func (r *schemaRewriter) rewrite(name string) string {
+	if to, ok := r.mapping[strings.ToLower(name)]; ok && name != "" {
+		return to
+	}
+	return name
+}
+
+func (r *schemaRewriter) rewriteCIStr(name model.CIStr) model.CIStr {
+	if to, ok := r.mapping[name.L]; ok && name.L != "" {
+		return model.NewCIStr(to)
+	}
+	return name
+}
+
+// StmtCategory is the category of a statement, used to route statements.
+type StmtCategory int
+
+// Statement categories.
+const (
+	// StmtCategoryUnknown is returned for statements not known by StmtType.
+	StmtCategoryUnknown StmtCategory = iota
+	// StmtCategoryDDL is for statements that change the schema.
+	StmtCategoryDDL
+	// StmtCategoryDML is for statements that read or write data.
+	StmtCategoryDML
+	// StmtCategoryTransaction is for transaction control and table locking statements.
+	StmtCategoryTransaction
+	// StmtCategoryUtility is for SET, SHOW, account management and administration statements.
+	StmtCategoryUtility
+	// StmtCategoryPrepared is for prepared statement management.
+	StmtCategoryPrepared
+)
+
+// StmtType returns the category of the statement n.
+func StmtType(n StmtNode) StmtCategory {
+	switch n.(type) {
+	case DDLNode:
+		return StmtCategoryDDL
+	case *SelectStmt, *UnionStmt, *InsertStmt, *UpdateStmt, *DeleteStmt, *LoadDataStmt, *ImportIntoStmt, *NonTransactionalDMLStmt,
+		*DoStmt:
+		return StmtCategoryDML
+	case *BeginStmt, *CommitStmt, *RollbackStmt, *SavepointStmt, *ReleaseSavepointStmt,
+		*SetTransactionStmt, *LockTablesStmt, *UnlockTablesStmt:
+		return StmtCategoryTransaction
+	case *PrepareStmt, *ExecuteStmt, *DeallocateStmt:
+		return StmtCategoryPrepared
+	case *ShowStmt, *SetStmt, *SetNamesStmt, *SetPwdStmt, *SetRoleStmt, *SetDefaultRoleStmt, *SetConfigStmt, *UseStmt,
+		*ExplainStmt, *PlanReplayerStmt, *TraceStmt, *EmptyStmt,
+		*CreateUserStmt, *AlterUserStmt, *DropUserStmt, *GrantStmt, *RevokeStmt,
+		*CreateRoleStmt, *DropRoleStmt, *GrantRoleStmt, *RevokeRoleStmt,
+		*AdminStmt, *RepairTableStmt, *AnalyzeTableStmt, *BinlogStmt, *ChangeStmt, *FlushStmt, *OptimizeTableStmt, *CheckTableStmt, *KillStmt,
+		*ShutdownStmt, *RestartStmt, *AlterInstanceStmt, *HelpStmt, *LoadStatsStmt, *DropStatsStmt, *BRIEStmt, *SplitRegionStmt, *CreateBindingStmt, *DropBindingStmt, *CalibrateResourceStmt,
+		*AddQueryWatchStmt, *DropQueryWatchStmt:
+		return StmtCategoryUtility
+	}
+	return StmtCategoryUnknown
+}
+
+// IsReadOnly checks whether the statement n neither writes data nor changes the schema or global state,
+// so it can be dispatched to a read replica. SELECT ... FOR UPDATE and LOCK IN SHARE MODE are not read-only
+// as they take locks. EXPLAIN is read-only whatever the statement is as it doesn't execute it,
+// but EXPLAIN ANALYZE executes the statement and is read-only only if the statement is.
+// It is conservative and returns false for statements it doesn't know.
+func IsReadOnly(n StmtNode) bool {
+	switch x := n.(type) {
+	case *SelectStmt, *UnionStmt:
+		return !hasSelectLock(x)
+	case *ExplainStmt:
+		return !x.Analyze || IsReadOnly(x.Stmt)
+	case *TraceStmt:
+		return IsReadOnly(x.Stmt)
+	case *SetStmt:
+		for _, v := range x.Variables {
+			if v.IsGlobal {
+				return false
+			}
+		}
+		return true
+	case *SetTransactionStmt:
+		return x.Scope != TransactionScopeGlobal
+	case *ShowStmt, *UseStmt, *SetNamesStmt, *EmptyStmt,
+		*BeginStmt, *CommitStmt, *RollbackStmt, *SavepointStmt, *ReleaseSavepointStmt:
+		return true
+	}
+	return false
+}
+
+// IsWriteToSystemTable checks whether the statement n may write data and references a table of the
+// mysql system database, like a DML or DDL on mysql.user, so direct edits of the system tables can be
+// gated separately. It builds on IsReadOnly, so writes executed by EXPLAIN ANALYZE are caught too.
+// It is conservative and returns true if a system table is only read by a writing statement.
+// Statements writing the system tables implicitly, like GRANT and CREATE USER, are not caught.
+// Unqualified table names are only caught after the names are resolved against the current database.
+func IsWriteToSystemTable(n StmtNode) bool {
+	if IsReadOnly(n) {
+		return false
+	}
+	for _, t := range ExtractTableRefs(n) {
+		if t.Schema.L == mysql.SystemDB {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSelectLock checks whether any SELECT in the AST rooted at n locks the rows it reads.
+func hasSelectLock(n Node) bool {
+	var locked bool
+	Walk(n, func(n Node) bool {
+		if sel, ok := n.(*SelectStmt); ok && sel.LockTp != SelectLockNone {
+			locked = true
+		}
+		return !locked
+	})
+	return locked
+}
@@ -19,6 +19,10 @@ import "github.com/pingcap/tidb/util/types"
 // Node implementations should embed it in.
 type node struct {
 	text string
+	// offset is the origin text position, it is unknown unless hasOffset is true.
+	offset    int
+	hasOffset bool
+	internal  bool
 }
 
 // SetText implements Node interface.
@@ -31,6 +35,30 @@ func (n *node) Text() string {
 	return n.text
 }
 
+// SetOriginTextPosition implements Node interface.
+func (n *node) SetOriginTextPosition(offset int) {
+	n.offset = offset
+	n.hasOffset = true
+}
+
+// OriginTextPosition implements Node interface.
+func (n *node) OriginTextPosition() int {
+	if !n.hasOffset {
+		return -1
+	}
+	return n.offset
+}
+
+// SetInternal implements Node interface.
+func (n *node) SetInternal() {
+	n.internal = true
+}
+
+// IsInternal implements Node interface.
+func (n *node) IsInternal() bool {
+	return n.internal
+}
+
 // stmtNode implements StmtNode interface.
 // Statement implementations should embed it in.
 type stmtNode struct {
@@ -0,0 +1,126 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import "reflect"
+
+// CloneStmt returns a deep copy of n. The copy is structurally equal to n but
+// shares no nodes with it, so either one can be modified without affecting the other.
+// The nodes Accept doesn't visit, like the statement prepared by PrepareStmt, and the
+// values of other packages, like the table info of a resolved name, are shared.
+func CloneStmt(n StmtNode) StmtNode {
+	if n == nil {
+		return nil
+	}
+	newNode, _ := n.Accept(&cloner{copied: make(map[interface{}]reflect.Value)})
+	return newNode.(StmtNode)
+}
+
+var (
+	nodeInterface = reflect.TypeOf((*Node)(nil)).Elem()
+	astPkgPath    = nodeInterface.PkgPath()
+)
+
+// cloner is a Visitor that replaces every node it enters with a copy,
+// then Accept replaces the children of the copy with their copies.
+// The slices and the values of this package a node holds are copied too,
+// as Accept assigns the children to them.
+type cloner struct {
+	// copied maps the pointers to the values which are not nodes to their copies,
+	// so a value referred more than once, like a resolved result field, is copied once.
+	copied map[interface{}]reflect.Value
+}
+
+// Enter implements Visitor interface.
+func (c *cloner) Enter(n Node) (Node, bool) {
+	v := reflect.ValueOf(n)
+	if v.IsNil() {
+		// Some statements visit their optional children even if they are nil.
+		return n, true
+	}
+	nv := reflect.New(v.Type().Elem())
+	c.copyStruct(nv.Elem(), v.Elem())
+	return nv.Interface().(Node), false
+}
+
+// Leave implements Visitor interface.
+func (c *cloner) Leave(n Node) (Node, bool) {
+	return n, true
+}
+
+// copyStruct copies src into dst, the unexported fields are copied by value.
+func (c *cloner) copyStruct(dst, src reflect.Value) {
+	dst.Set(src)
+	for i := 0; i < dst.NumField(); i++ {
+		if f := dst.Field(i); f.CanSet() {
+			f.Set(c.copyValue(f))
+		}
+	}
+}
+
+func (c *cloner) copyValue(v reflect.Value) reflect.Value {
+	if v.Type().Implements(nodeInterface) {
+		// The node is copied when Accept visits it.
+		return v
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Type().Elem().PkgPath() != astPkgPath {
+			return v
+		}
+		if nv, ok := c.copied[v.Interface()]; ok {
+			return nv
+		}
+		nv := reflect.New(v.Type().Elem())
+		c.copied[v.Interface()] = nv
+		nv.Elem().Set(c.copyValue(v.Elem()))
+		return nv
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		nv := reflect.New(v.Type()).Elem()
+		nv.Set(c.copyValue(v.Elem()))
+		return nv
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		nv := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			nv.Index(i).Set(c.copyValue(v.Index(i)))
+		}
+		return nv
+	case reflect.Array:
+		nv := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			nv.Index(i).Set(c.copyValue(v.Index(i)))
+		}
+		return nv
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		nv := reflect.MakeMap(v.Type())
+		for _, key := range v.MapKeys() {
+			nv.SetMapIndex(key, c.copyValue(v.MapIndex(key)))
+		}
+		return nv
+	case reflect.Struct:
+		nv := reflect.New(v.Type()).Elem()
+		c.copyStruct(nv, v)
+		return nv
+	}
+	return v
+}
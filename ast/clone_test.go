@@ -0,0 +1,75 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	. "github.com/pingcap/check"
+	. "github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/parser"
+)
+
+var _ = Suite(&testCloneSuite{})
+
+type testCloneSuite struct {
+}
+
+func (ts *testCloneSuite) TestCloneStmt(c *C) {
+	c.Assert(CloneStmt(nil), IsNil)
+
+	sql := "set @a = 'x', @@global.autocommit = (select b from t where c > 1)"
+	stmt, err := parser.New().ParseOneStmt(sql, "", "")
+	c.Assert(err, IsNil)
+	set := stmt.(*SetStmt)
+	cloned := CloneStmt(set).(*SetStmt)
+	c.Assert(cloned, DeepEquals, set)
+	c.Assert(cloned.Text(), Equals, sql)
+
+	c.Assert(cloned, Not(Equals), set)
+	c.Assert(cloned.Variables[0], Not(Equals), set.Variables[0])
+	c.Assert(cloned.Variables[0].Value, Not(Equals), set.Variables[0].Value)
+	c.Assert(cloned.Variables[1].Value, Not(Equals), set.Variables[1].Value)
+
+	cloned.Variables[0].Name = "b"
+	cloned.Variables[0].Value.SetValue("y")
+	cloned.Variables = append(cloned.Variables[:1], &VariableAssignment{Name: "c"})
+	c.Assert(set.Variables, HasLen, 2)
+	c.Assert(set.Variables[0].Name, Equals, "a")
+	c.Assert(set.Variables[0].Value.GetValue(), Equals, "x")
+	c.Assert(set.Variables[1].Name, Equals, "autocommit")
+}
+
+func (ts *testCloneSuite) TestCloneUsingVars(c *C) {
+	stmt, err := parser.New().ParseOneStmt("execute s using @a, @b", "", "")
+	c.Assert(err, IsNil)
+	exec := stmt.(*ExecuteStmt)
+	cloned := CloneStmt(exec).(*ExecuteStmt)
+	c.Assert(cloned, DeepEquals, exec)
+	c.Assert(cloned.UsingVars[0], Not(Equals), exec.UsingVars[0])
+	cloned.UsingVars[0].(*VariableExpr).Name = "c"
+	c.Assert(exec.UsingVars[0].(*VariableExpr).Name, Equals, "a")
+}
+
+func (ts *testCloneSuite) TestCloneNestedSlices(c *C) {
+	stmt, err := parser.New().ParseOneStmt("insert into t values (1, 2), (3, 4)", "", "")
+	c.Assert(err, IsNil)
+	insert := stmt.(*InsertStmt)
+	cloned := CloneStmt(insert).(*InsertStmt)
+	c.Assert(NodesEqual(cloned, insert), IsTrue)
+	c.Assert(cloned.Lists[1][0], Not(Equals), insert.Lists[1][0])
+
+	cloned.Lists[1][0] = &ValueExpr{}
+	cloned.Lists[0][1].SetValue(5)
+	c.Assert(insert.Lists[1][0].GetValue(), Equals, int64(3))
+	c.Assert(insert.Lists[0][1].GetValue(), Equals, int64(2))
+}
@@ -26,6 +26,8 @@ var (
 	_ DDLNode = &DropDatabaseStmt{}
 	_ DDLNode = &DropIndexStmt{}
 	_ DDLNode = &DropTableStmt{}
+	_ DDLNode = &FlashBackTableStmt{}
+	_ DDLNode = &RecoverTableStmt{}
 	_ DDLNode = &RenameTableStmt{}
 	_ DDLNode = &TruncateTableStmt{}
 
@@ -36,6 +38,7 @@ var (
 	_ Node = &Constraint{}
 	_ Node = &IndexColName{}
 	_ Node = &ReferenceDef{}
+	_ Node = &TableToTable{}
 )
 
 // CharsetOpt is used for parsing charset option from SQL.
@@ -457,22 +460,49 @@ func (n *DropTableStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
-// RenameTableStmt is a statement to rename a table.
+// RenameTableStmt is a statement to rename tables.
+// All the renames are done in one statement, so they are kept in one node.
 // See http://dev.mysql.com/doc/refman/5.7/en/rename-table.html
 type RenameTableStmt struct {
 	ddlNode
 
-	OldTable *TableName
-	NewTable *TableName
+	TableToTables []*TableToTable
 }
 
 // Accept implements Node Accept interface.
+// It visits TableToTables in order.
 func (n *RenameTableStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
 	n = newNode.(*RenameTableStmt)
+	for i, t := range n.TableToTables {
+		node, ok := t.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.TableToTables[i] = node.(*TableToTable)
+	}
+	return v.Leave(n)
+}
+
+// TableToTable is a pair of the old and the new table name in RenameTableStmt.
+type TableToTable struct {
+	node
+
+	OldTable *TableName
+	NewTable *TableName
+}
+
+// Accept implements Node Accept interface.
+// It visits OldTable and NewTable in that order.
+func (n *TableToTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*TableToTable)
 	node, ok := n.OldTable.Accept(v)
 	if !ok {
 		return n, false
@@ -731,6 +761,58 @@ func (n *AlterTableStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// FlashBackTableStmt is a statement to restore a dropped table.
+// NewName is the name of the restored table if FLASHBACK TABLE t TO new_name is used,
+// it is empty if the table is restored with its original name.
+type FlashBackTableStmt struct {
+	ddlNode
+
+	Table   *TableName
+	NewName string
+}
+
+// Accept implements Node Accept interface.
+func (n *FlashBackTableStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*FlashBackTableStmt)
+	node, ok := n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	return v.Leave(n)
+}
+
+// RecoverTableStmt is a statement to recover a dropped table.
+// RECOVER TABLE t recovers the latest dropped table t, Table is set and JobID is 0.
+// RECOVER TABLE BY JOB job_id recovers the table dropped by the DDL job, Table is nil then.
+type RecoverTableStmt struct {
+	ddlNode
+
+	Table *TableName
+	JobID int64
+}
+
+// Accept implements Node Accept interface.
+func (n *RecoverTableStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RecoverTableStmt)
+	if n.Table != nil {
+		node, ok := n.Table.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Table = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
 // TruncateTableStmt is a statement to empty a table completely.
 // See https://dev.mysql.com/doc/refman/5.7/en/truncate-table.html
 type TruncateTableStmt struct {
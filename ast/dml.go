@@ -14,6 +14,9 @@
 package ast
 
 import (
+	"strings"
+
+	"github.com/juju/errors"
 	"github.com/pingcap/tidb/model"
 )
 
@@ -26,6 +29,9 @@ var (
 	_ DMLNode = &ShowStmt{}
 	_ DMLNode = &LoadDataStmt{}
 
+	_ StmtNode = &ImportIntoStmt{}
+	_ StmtNode = &NonTransactionalDMLStmt{}
+
 	_ Node = &Assignment{}
 	_ Node = &ByItem{}
 	_ Node = &FieldList{}
@@ -141,6 +147,25 @@ type IndexHint struct {
 	HintScope  IndexHintScope
 }
 
+// TableOptimizerHint is a table level optimizer hint, like `/*+ TIDB_SMJ(t1, t2) */`
+// after SELECT, UPDATE or DELETE.
+type TableOptimizerHint struct {
+	node
+
+	HintName model.CIStr
+	Tables   []model.CIStr
+}
+
+// Accept implements Node Accept interface.
+func (n *TableOptimizerHint) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*TableOptimizerHint)
+	return v.Leave(n)
+}
+
 // Accept implements Node Accept interface.
 func (n *TableName) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
@@ -461,6 +486,8 @@ type SelectStmt struct {
 	Limit *Limit
 	// Lock is the lock type
 	LockTp SelectLockType
+	// TableHints represents the table level optimizer hints, in source order.
+	TableHints []*TableOptimizerHint
 }
 
 // Accept implements Node Accept interface.
@@ -471,6 +498,14 @@ func (n *SelectStmt) Accept(v Visitor) (Node, bool) {
 	}
 
 	n = newNode.(*SelectStmt)
+	for i, hint := range n.TableHints {
+		node, ok := hint.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.TableHints[i] = node.(*TableOptimizerHint)
+	}
+
 	if n.From != nil {
 		node, ok := n.From.Accept(v)
 		if !ok {
@@ -643,6 +678,7 @@ type LoadDataStmt struct {
 	IsLocal    bool
 	Path       string
 	Table      *TableName
+	Columns    []*ColumnName
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
 }
@@ -661,6 +697,13 @@ func (n *LoadDataStmt) Accept(v Visitor) (Node, bool) {
 		}
 		n.Table = node.(*TableName)
 	}
+	for i, val := range n.Columns {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Columns[i] = node.(*ColumnName)
+	}
 	return v.Leave(n)
 }
 
@@ -677,6 +720,113 @@ type LinesClause struct {
 	Terminated string
 }
 
+// ImportIntoStmt is a statement to import data from files into an existing table.
+// Unlike LoadDataStmt, the import is done by TiDB in the background.
+type ImportIntoStmt struct {
+	stmtNode
+
+	Table              *TableName
+	ColumnsAndUserVars []*ColumnNameOrUserVar
+	Path               string
+	// Format is the file format, it is empty if not given, which means CSV.
+	Format  string
+	Options []*LoadDataOpt
+}
+
+// ColumnNameOrUserVar is a column to import into, or a user variable to hold a field of the file.
+// Exactly one of ColumnName and UserVar is set.
+type ColumnNameOrUserVar struct {
+	ColumnName *ColumnName
+	UserVar    *VariableExpr
+}
+
+// LoadDataOpt is an option in the WITH clause of IMPORT INTO.
+type LoadDataOpt struct {
+	// Name is lower-cased.
+	Name string
+	// Value is nil for the options without a value, like DETACHED.
+	Value ExprNode
+}
+
+// importIntoOptions is the options of IMPORT INTO, and whether they need a value.
+var importIntoOptions = map[string]bool{
+	"character_set":            true,
+	"fields_terminated_by":     true,
+	"fields_enclosed_by":       true,
+	"fields_escaped_by":        true,
+	"fields_defined_null_by":   true,
+	"lines_terminated_by":      true,
+	"skip_rows":                true,
+	"split_file":               false,
+	"disk_quota":               true,
+	"thread":                   true,
+	"max_write_speed":          true,
+	"checksum_table":           true,
+	"record_errors":            true,
+	"detached":                 false,
+	"disable_tikv_import_mode": false,
+}
+
+// Accept implements Node Accept interface.
+// It visits Table and ColumnsAndUserVars in that order, the option values are not visited.
+func (n *ImportIntoStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ImportIntoStmt)
+	node, ok := n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	for _, c := range n.ColumnsAndUserVars {
+		if c.ColumnName != nil {
+			node, ok = c.ColumnName.Accept(v)
+			if !ok {
+				return n, false
+			}
+			c.ColumnName = node.(*ColumnName)
+		}
+		if c.UserVar != nil {
+			node, ok = c.UserVar.Accept(v)
+			if !ok {
+				return n, false
+			}
+			c.UserVar = node.(*VariableExpr)
+		}
+	}
+	return v.Leave(n)
+}
+
+// Validate checks the format and the options, an option must be known, given once,
+// and have a value if and only if it needs one.
+func (n *ImportIntoStmt) Validate() error {
+	switch strings.ToLower(n.Format) {
+	case "", "csv", "sql", "parquet":
+	default:
+		return errors.Errorf("Unknown IMPORT INTO format '%s'", n.Format)
+	}
+	seen := make(map[string]struct{}, len(n.Options))
+	for _, opt := range n.Options {
+		needValue, ok := importIntoOptions[opt.Name]
+		if !ok {
+			return errors.Errorf("Unknown IMPORT INTO option '%s'", opt.Name)
+		}
+		if _, ok := seen[opt.Name]; ok {
+			return errors.Errorf("IMPORT INTO option '%s' is given more than once", opt.Name)
+		}
+		seen[opt.Name] = struct{}{}
+		if needValue != (opt.Value != nil) {
+			if needValue {
+				return errors.Errorf("IMPORT INTO option '%s' needs a value", opt.Name)
+			}
+			return errors.Errorf("IMPORT INTO option '%s' doesn't take a value", opt.Name)
+		}
+	}
+	return nil
+}
+
 // InsertStmt is a statement to insert new rows into an existing table.
 // See https://dev.mysql.com/doc/refman/5.7/en/insert.html
 type InsertStmt struct {
@@ -765,6 +915,8 @@ type DeleteStmt struct {
 	Quick        bool
 	IsMultiTable bool
 	BeforeFrom   bool
+	// TableHints represents the table level optimizer hints, in source order.
+	TableHints []*TableOptimizerHint
 }
 
 // Accept implements Node Accept interface.
@@ -775,6 +927,14 @@ func (n *DeleteStmt) Accept(v Visitor) (Node, bool) {
 	}
 
 	n = newNode.(*DeleteStmt)
+	for i, hint := range n.TableHints {
+		node, ok := hint.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.TableHints[i] = node.(*TableOptimizerHint)
+	}
+
 	node, ok := n.TableRefs.Accept(v)
 	if !ok {
 		return n, false
@@ -824,6 +984,8 @@ type UpdateStmt struct {
 	LowPriority   bool
 	Ignore        bool
 	MultipleTable bool
+	// TableHints represents the table level optimizer hints, in source order.
+	TableHints []*TableOptimizerHint
 }
 
 // Accept implements Node Accept interface.
@@ -833,6 +995,13 @@ func (n *UpdateStmt) Accept(v Visitor) (Node, bool) {
 		return v.Leave(newNode)
 	}
 	n = newNode.(*UpdateStmt)
+	for i, hint := range n.TableHints {
+		node, ok := hint.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.TableHints[i] = node.(*TableOptimizerHint)
+	}
 	node, ok := n.TableRefs.Accept(v)
 	if !ok {
 		return n, false
@@ -869,6 +1038,59 @@ func (n *UpdateStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// Dry run modes of NonTransactionalDMLStmt.
+const (
+	// NoDryRun executes the DML.
+	NoDryRun = iota
+	// DryRunQuery shows the query which splits the rows into batches.
+	DryRunQuery
+	// DryRunSplitDml shows the statements which would be executed for the batches.
+	DryRunSplitDml
+)
+
+// NonTransactionalDMLStmt is a statement to execute a DML in batches of Limit rows,
+// each batch in its own transaction, the rows are split by ShardColumn.
+// ShardColumn is nil if not given, which means the handle of the table.
+type NonTransactionalDMLStmt struct {
+	stmtNode
+
+	DMLStmt     DMLNode
+	ShardColumn *ColumnName
+	Limit       uint64
+	DryRun      int
+}
+
+// Accept implements Node Accept interface.
+// It visits ShardColumn and DMLStmt in that order.
+func (n *NonTransactionalDMLStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*NonTransactionalDMLStmt)
+	if n.ShardColumn != nil {
+		node, ok := n.ShardColumn.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.ShardColumn = node.(*ColumnName)
+	}
+	node, ok := n.DMLStmt.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.DMLStmt = node.(DMLNode)
+	return v.Leave(n)
+}
+
+// Validate checks the batch size is positive.
+func (n *NonTransactionalDMLStmt) Validate() error {
+	if n.Limit == 0 {
+		return errors.New("BATCH LIMIT must be greater than 0")
+	}
+	return nil
+}
+
 // Limit is the limit clause.
 type Limit struct {
 	node
@@ -926,6 +1148,71 @@ const (
 	ShowProcessList
 	ShowCreateDatabase
 	ShowEvents
+	ShowErrors
+	ShowPlugins
+	ShowOpenTables
+	ShowPrivileges
+	ShowBindings
+	ShowProfiles
+	ShowProfile
+	ShowStatsMeta
+	ShowStatsHistograms
+	ShowStatsBuckets
+	ShowStatsHealthy
+	ShowBuiltins
+)
+
+// ProfileType is the type of the information shown by SHOW PROFILE.
+type ProfileType int
+
+// Profile types.
+const (
+	ProfileTypeAll ProfileType = iota + 1
+	ProfileTypeBlockIO
+	ProfileTypeContextSwitches
+	ProfileTypeCPU
+	ProfileTypeIPC
+	ProfileTypeMemory
+	ProfileTypePageFaults
+	ProfileTypeSource
+	ProfileTypeSwaps
+)
+
+// String implements fmt.Stringer interface.
+func (t ProfileType) String() string {
+	switch t {
+	case ProfileTypeAll:
+		return "ALL"
+	case ProfileTypeBlockIO:
+		return "BLOCK IO"
+	case ProfileTypeContextSwitches:
+		return "CONTEXT SWITCHES"
+	case ProfileTypeCPU:
+		return "CPU"
+	case ProfileTypeIPC:
+		return "IPC"
+	case ProfileTypeMemory:
+		return "MEMORY"
+	case ProfileTypePageFaults:
+		return "PAGE FAULTS"
+	case ProfileTypeSource:
+		return "SOURCE"
+	case ProfileTypeSwaps:
+		return "SWAPS"
+	}
+	return ""
+}
+
+// ShowScope is the scope of SHOW VARIABLES, SHOW STATUS and SHOW BINDINGS.
+type ShowScope int
+
+// Show scopes.
+const (
+	// ShowScopeUnspecified is used when neither GLOBAL nor SESSION is given,
+	// it behaves like SESSION in most cases.
+	ShowScopeUnspecified ShowScope = iota
+	ShowScopeSession
+	ShowScopeGlobal
 )
 
 // ShowStmt is a statement to provide information about databases, tables, columns and so on.
@@ -939,16 +1226,44 @@ type ShowStmt struct {
 	Table  *TableName  // Used for showing columns.
 	Column *ColumnName // Used for `desc table column`.
 	Flag   int         // Some flag parsed from sql, such as FULL.
-	Full   bool
-	User   string // Used for show grants.
+	Full   bool        // Used for show tables, columns and processlist.
+	// Extended is used for show columns and index, it also shows the hidden columns and index parts.
+	Extended bool
+
+	// Used by show grants. User is nil for the current user.
+	User  *UserIdentity
+	Roles []*RoleIdentity
 
-	// Used by show variables
+	// Used by show variables, status and bindings.
+	Scope ShowScope
+	// GlobalScope is kept for compatibility, it is true if Scope is ShowScopeGlobal.
 	GlobalScope bool
 	Pattern     *PatternLikeExpr
 	Where       ExprNode
+	OrderBy     *OrderByClause
+	Limit       *Limit
+
+	// Used by show warnings/errors.
+	CountOnly bool
+
+	// Used by show profile. ProfileQueryID is nil if FOR QUERY is not given,
+	// then the most recent statement is shown.
+	ProfileTypes   []ProfileType
+	ProfileQueryID *uint64
+}
+
+// Filterable checks whether the SHOW target accepts the LIKE, WHERE and ORDER BY clauses.
+// ShowOpenTables is filterable but only accepts LIKE.
+func (n *ShowStmt) Filterable() bool {
+	switch n.Tp {
+	case ShowEngines, ShowPlugins, ShowPrivileges, ShowProfiles, ShowProfile, ShowBuiltins, ShowWarnings, ShowErrors, ShowCreateTable, ShowCreateDatabase, ShowGrants, ShowProcessList:
+		return false
+	}
+	return true
 }
 
 // Accept implements Node Accept interface.
+// It visits Table, Column, Pattern, Where, OrderBy and Limit in that order.
 func (n *ShowStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
@@ -976,14 +1291,6 @@ func (n *ShowStmt) Accept(v Visitor) (Node, bool) {
 		}
 		n.Pattern = node.(*PatternLikeExpr)
 	}
-
-	switch n.Tp {
-	case ShowTriggers, ShowProcedureStatus, ShowProcessList, ShowEvents:
-		// We don't have any data to return for those types,
-		// but visiting Where may cause resolving error, so return here to avoid error.
-		return v.Leave(n)
-	}
-
 	if n.Where != nil {
 		node, ok := n.Where.Accept(v)
 		if !ok {
@@ -991,5 +1298,19 @@ func (n *ShowStmt) Accept(v Visitor) (Node, bool) {
 		}
 		n.Where = node.(ExprNode)
 	}
+	if n.OrderBy != nil {
+		node, ok := n.OrderBy.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.OrderBy = node.(*OrderByClause)
+	}
+	if n.Limit != nil {
+		node, ok := n.Limit.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Limit = node.(*Limit)
+	}
 	return v.Leave(n)
 }
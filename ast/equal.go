@@ -0,0 +1,115 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import "reflect"
+
+var (
+	nodeType = reflect.TypeOf(node{})
+	// positionFields are the fields which record where a node is in the source text.
+	positionFields = map[reflect.Type]string{
+		reflect.TypeOf(SelectField{}):     "Offset",
+		reflect.TypeOf(ParamMarkerExpr{}): "Offset",
+	}
+)
+
+// NodesEqual reports whether a and b are structurally equal. The original text
+// and positions of the nodes are ignored, and nil slices or maps are equal to
+// empty ones.
+func NodesEqual(a, b Node) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	eq := &equalChecker{visited: make(map[[2]uintptr]bool)}
+	return eq.equal(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+type equalChecker struct {
+	// visited records the pointer pairs being compared, to stop at cycles.
+	visited map[[2]uintptr]bool
+}
+
+func (eq *equalChecker) equal(a, b reflect.Value) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() && b.IsNil()
+		}
+		key := [2]uintptr{a.Pointer(), b.Pointer()}
+		if a.Pointer() == b.Pointer() || eq.visited[key] {
+			return true
+		}
+		eq.visited[key] = true
+		return eq.equal(a.Elem(), b.Elem())
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() && b.IsNil()
+		}
+		return eq.equal(a.Elem(), b.Elem())
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !eq.equal(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			bv := b.MapIndex(key)
+			if !bv.IsValid() || !eq.equal(a.MapIndex(key), bv) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		if a.Type() == nodeType {
+			return true
+		}
+		skip := positionFields[a.Type()]
+		for i := 0; i < a.NumField(); i++ {
+			if skip != "" && a.Type().Field(i).Name == skip {
+				continue
+			}
+			if !eq.equal(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	// The values of unexported fields can't be compared through Interface.
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	}
+	return false
+}
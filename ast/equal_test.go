@@ -0,0 +1,86 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	. "github.com/pingcap/check"
+	. "github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/parser"
+)
+
+var _ = Suite(&testEqualSuite{})
+
+type testEqualSuite struct {
+}
+
+func (ts *testEqualSuite) TestNodesEqual(c *C) {
+	p := parser.New()
+	parse := func(sql string) StmtNode {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", sql))
+		return stmt
+	}
+	cases := []struct {
+		a     string
+		b     string
+		equal bool
+	}{
+		// Text and positions are ignored.
+		{"explain select a, ? from t", "EXPLAIN   SELECT a,  ? FROM t", true},
+		{"explain select 1", "explain format = 'row' select 1", true},
+		{"explain select 1", "explain format = 'dot' select 1", false},
+		{"explain select 1", "explain analyze select 1", false},
+		{"explain select a from t", "explain select b from t", false},
+		{"show full tables", "show full tables", true},
+		{"show full tables", "show tables", false},
+		{"show processlist", "show full processlist", false},
+		{"show grants for 'u'@'%'", "show grants for 'u'", true},
+		{"show grants for 'u'@'%'", "show grants for 'u'@'h'", false},
+		{"set @a = 1 + 2, @@global.b = c", "set @a = 1+2, @@global.b = c", true},
+		{"set @a = 1 + 2", "set @a = 1 + 3", false},
+		{"set @a = 1 + 2", "set @a = 1 - 2", false},
+		{"set @a = 1, @b = 2", "set @a = 1", false},
+		{"set @a = 1", "set @@a = 1", false},
+		{"set autocommit = default", "set autocommit = default", true},
+	}
+	for _, ca := range cases {
+		a, b := parse(ca.a), parse(ca.b)
+		c.Assert(NodesEqual(a, b), Equals, ca.equal, Commentf("%s and %s", ca.a, ca.b))
+		c.Assert(NodesEqual(b, a), Equals, ca.equal, Commentf("%s and %s", ca.b, ca.a))
+	}
+
+	c.Assert(NodesEqual(nil, nil), IsTrue)
+	c.Assert(NodesEqual(&SetStmt{}, nil), IsFalse)
+	c.Assert(NodesEqual(&SetStmt{}, &ShowStmt{}), IsFalse)
+	// Nil and empty slices are equal.
+	c.Assert(NodesEqual(&SetStmt{}, &SetStmt{Variables: []*VariableAssignment{}}), IsTrue)
+	c.Assert(NodesEqual(&ShowStmt{Roles: nil}, &ShowStmt{Roles: []*RoleIdentity{}}), IsTrue)
+}
+
+func (ts *testEqualSuite) TestRestoreStable(c *C) {
+	sqls := []string{
+		"explain select a, b + 1 from t where c in (1, 2) order by a desc limit 10",
+		"show full columns from t from db like 'a%'",
+		"set @a = 1, @@global.autocommit = default, names utf8",
+	}
+	for _, sql := range sqls {
+		stmt, err := parser.New().ParseOneStmt(sql, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", sql))
+		restored := restoreSQL(c, sql)
+		stmt2, err := parser.New().ParseOneStmt(restored, "", "")
+		c.Assert(err, IsNil, Commentf("restored %s", restored))
+		c.Assert(NodesEqual(stmt, stmt2), IsTrue, Commentf("source %s restored %s", sql, restored))
+		c.Assert(NodesEqual(stmt, CloneStmt(stmt)), IsTrue, Commentf("source %s", sql))
+	}
+}
@@ -544,6 +544,9 @@ func (n *PatternLikeExpr) Accept(v Visitor) (Node, bool) {
 type ParamMarkerExpr struct {
 	exprNode
 	Offset int
+	// Name is the name of a named parameter marker like `:name`,
+	// it is empty for the positional parameter marker `?`.
+	Name string
 }
 
 // Accept implements Node Accept interface.
@@ -0,0 +1,100 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/types"
+)
+
+// FoldConstants replaces the arithmetic on numeric literals in the tree rooted at n
+// with the computed literal, so `SET @@max_connections = 10*100` becomes `SET @@max_connections = 1000`.
+// It is conservative: functions, columns and variables are never folded, and an operation
+// that fails or returns NULL, like an overflow or a division by zero, is left as it is
+// to report the error at execution. The tree is modified in place, the new root is returned.
+func FoldConstants(n Node) Node {
+	folded, _ := n.Accept(constantFolder{})
+	return folded
+}
+
+// constantFolder is the visitor to fold constants, it works bottom-up in Leave.
+type constantFolder struct{}
+
+// Enter implements Visitor interface.
+func (f constantFolder) Enter(n Node) (Node, bool) {
+	return n, false
+}
+
+// Leave implements Visitor interface.
+func (f constantFolder) Leave(n Node) (Node, bool) {
+	switch x := n.(type) {
+	case *ParenthesesExpr:
+		if v, ok := x.Expr.(*ValueExpr); ok {
+			return v, true
+		}
+	case *UnaryOperationExpr:
+		if x.Op == opcode.Minus {
+			if v, ok := x.V.(*ValueExpr); ok && isNumericLiteral(v) {
+				return foldArithmetic(x, opcode.Minus, types.NewIntDatum(0), v.Datum), true
+			}
+		}
+	case *BinaryOperationExpr:
+		l, lok := x.L.(*ValueExpr)
+		r, rok := x.R.(*ValueExpr)
+		if lok && rok && isNumericLiteral(l) && isNumericLiteral(r) {
+			return foldArithmetic(x, x.Op, l.Datum, r.Datum), true
+		}
+	}
+	return n, true
+}
+
+// isNumericLiteral checks whether v is a number, other literals need conversions that may warn.
+func isNumericLiteral(v *ValueExpr) bool {
+	switch v.Kind() {
+	case types.KindInt64, types.KindUint64, types.KindFloat64, types.KindMysqlDecimal:
+		return true
+	}
+	return false
+}
+
+// foldArithmetic computes a op b, it returns expr if op is not arithmetic or the computation fails.
+func foldArithmetic(expr ExprNode, op opcode.Op, a, b types.Datum) ExprNode {
+	sc := new(variable.StatementContext)
+	a, b, err := types.CoerceDatum(sc, a, b)
+	if err != nil {
+		return expr
+	}
+	var d types.Datum
+	switch op {
+	case opcode.Plus:
+		d, err = types.ComputePlus(a, b)
+	case opcode.Minus:
+		d, err = types.ComputeMinus(a, b)
+	case opcode.Mul:
+		d, err = types.ComputeMul(a, b)
+	case opcode.Div:
+		d, err = types.ComputeDiv(sc, a, b)
+	case opcode.IntDiv:
+		d, err = types.ComputeIntDiv(sc, a, b)
+	case opcode.Mod:
+		d, err = types.ComputeMod(sc, a, b)
+	default:
+		return expr
+	}
+	if err != nil || d.IsNull() {
+		return expr
+	}
+	return NewValueExpr(d.GetValue())
+}
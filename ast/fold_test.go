@@ -0,0 +1,59 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	"bytes"
+
+	. "github.com/pingcap/check"
+	. "github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/opcode"
+)
+
+var _ = Suite(&testFoldSuite{})
+
+type testFoldSuite struct {
+}
+
+func (ts *testFoldSuite) TestFoldConstants(c *C) {
+	cases := []struct {
+		sql    string
+		folded string
+	}{
+		{"select 1+2", "SELECT 3"},
+		{"set @@max_connections = 10*100", "SET @@max_connections = 1000"},
+		{"select (1 + 2) * 3, -(4 - 1), 7 div 2, 7 % 4", "SELECT 9, -3, 3, 3"},
+		{"select 1.5 + 1, 1e1 + 1", "SELECT 2.5, 1.1e+01"},
+		{"select a + 1 * 2 from t", "SELECT `a` + 2 FROM `t`"},
+		{"select now() + 0, @a + 1", "SELECT now() + 0, @a + 1"},
+		{"select 1 + '1', 1 + null", "SELECT 1 + '1', 1 + NULL"},
+		// Errors are left to be reported at execution.
+		{"select 1 / 0, 18446744073709551615 + 1", "SELECT 1 / 0, 18446744073709551615 + 1"},
+	}
+	for _, ca := range cases {
+		stmt, err := parser.New().ParseOneStmt(ca.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", ca.sql))
+		node := FoldConstants(stmt)
+		var buf bytes.Buffer
+		c.Assert(Restore(&buf, node), IsNil, Commentf("sql: %s", ca.sql))
+		c.Assert(buf.String(), Equals, ca.folded, Commentf("sql: %s", ca.sql))
+	}
+
+	// The root is replaced if it is folded.
+	expr := &BinaryOperationExpr{Op: opcode.Plus, L: NewValueExpr(int64(1)), R: NewValueExpr(int64(2))}
+	folded, ok := FoldConstants(expr).(*ValueExpr)
+	c.Assert(ok, IsTrue)
+	c.Assert(folded.GetInt64(), Equals, int64(3))
+}
@@ -15,31 +15,70 @@ package ast
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/juju/errors"
 	"github.com/pingcap/tidb/context"
 	"github.com/pingcap/tidb/model"
 	"github.com/pingcap/tidb/mysql"
+	"github.com/pingcap/tidb/terror"
 )
 
 var (
 	_ StmtNode = &AdminStmt{}
+	_ StmtNode = &AlterInstanceStmt{}
 	_ StmtNode = &AlterUserStmt{}
 	_ StmtNode = &BeginStmt{}
 	_ StmtNode = &BinlogStmt{}
+	_ StmtNode = &BRIEStmt{}
+	_ StmtNode = &CalibrateResourceStmt{}
+	_ StmtNode = &AddQueryWatchStmt{}
+	_ StmtNode = &DropQueryWatchStmt{}
+	_ StmtNode = &ChangeStmt{}
 	_ StmtNode = &CommitStmt{}
+	_ StmtNode = &CreateBindingStmt{}
+	_ StmtNode = &CreateRoleStmt{}
 	_ StmtNode = &CreateUserStmt{}
 	_ StmtNode = &DeallocateStmt{}
 	_ StmtNode = &DoStmt{}
+	_ StmtNode = &DropBindingStmt{}
+	_ StmtNode = &EmptyStmt{}
+	_ StmtNode = &DropRoleStmt{}
 	_ StmtNode = &ExecuteStmt{}
 	_ StmtNode = &ExplainStmt{}
+	_ StmtNode = &PlanReplayerStmt{}
+	_ StmtNode = &GrantRoleStmt{}
 	_ StmtNode = &GrantStmt{}
+	_ StmtNode = &KillStmt{}
+	_ StmtNode = &LockTablesStmt{}
 	_ StmtNode = &PrepareStmt{}
+	_ StmtNode = &ReleaseSavepointStmt{}
+	_ StmtNode = &RepairTableStmt{}
+	_ StmtNode = &RevokeRoleStmt{}
+	_ StmtNode = &RevokeStmt{}
 	_ StmtNode = &RollbackStmt{}
+	_ StmtNode = &SavepointStmt{}
+	_ StmtNode = &SetNamesStmt{}
 	_ StmtNode = &SetPwdStmt{}
+	_ StmtNode = &SetConfigStmt{}
+	_ StmtNode = &SetRoleStmt{}
+	_ StmtNode = &SetDefaultRoleStmt{}
 	_ StmtNode = &SetStmt{}
+	_ StmtNode = &SetTransactionStmt{}
+	_ StmtNode = &ShutdownStmt{}
+	_ StmtNode = &RestartStmt{}
+	_ StmtNode = &HelpStmt{}
+	_ StmtNode = &LoadStatsStmt{}
+	_ StmtNode = &DropStatsStmt{}
+	_ StmtNode = &SplitRegionStmt{}
+	_ StmtNode = &TraceStmt{}
+	_ StmtNode = &UnlockTablesStmt{}
 	_ StmtNode = &UseStmt{}
 	_ StmtNode = &AnalyzeTableStmt{}
 	_ StmtNode = &FlushStmt{}
+	_ StmtNode = &OptimizeTableStmt{}
+	_ StmtNode = &CheckTableStmt{}
 
 	_ Node = &PrivElem{}
 	_ Node = &VariableAssignment{}
@@ -64,7 +103,64 @@ type AuthOption struct {
 	ByAuthString bool
 	AuthString   string
 	HashString   string
-	// TODO: support auth_plugin
+	// AuthPlugin is the authentication plugin specified by IDENTIFIED WITH,
+	// empty means the default one.
+	AuthPlugin string
+}
+
+// Explain formats.
+const (
+	ExplainFormatROW         = "row"
+	ExplainFormatJSON        = "json"
+	ExplainFormatDOT         = "dot"
+	ExplainFormatTraditional = "traditional"
+)
+
+// ExplainFormats stores the valid formats for explain statement, used by validator.
+var ExplainFormats = []string{
+	ExplainFormatROW,
+	ExplainFormatJSON,
+	ExplainFormatDOT,
+	ExplainFormatTraditional,
+}
+
+// Trace formats.
+const (
+	TraceFormatRow  = "row"
+	TraceFormatJSON = "json"
+)
+
+// TraceFormats stores the valid formats for trace statement, used by validator.
+var TraceFormats = []string{
+	TraceFormatRow,
+	TraceFormatJSON,
+}
+
+// TraceStmt is a statement to trace what sql actually does at background.
+type TraceStmt struct {
+	stmtNode
+
+	// Stmt can be any statement, not only the explainable ones.
+	Stmt StmtNode
+	// Format is the output format of the trace, it is one of TraceFormats.
+	Format string
+}
+
+// Accept implements Node Accept interface.
+func (n *TraceStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*TraceStmt)
+	if n.Stmt != nil {
+		node, ok := n.Stmt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Stmt = node.(StmtNode)
+	}
+	return v.Leave(n)
 }
 
 // ExplainStmt is a statement to provide information about how is SQL statement executed
@@ -74,6 +170,11 @@ type ExplainStmt struct {
 	stmtNode
 
 	Stmt StmtNode
+	// Analyze is true for EXPLAIN ANALYZE, which executes the statement
+	// and collects its runtime statistics.
+	Analyze bool
+	// Format is the output format of the plan, it is one of ExplainFormats.
+	Format string
 }
 
 // Accept implements Node Accept interface.
@@ -83,23 +184,83 @@ func (n *ExplainStmt) Accept(v Visitor) (Node, bool) {
 		return v.Leave(newNode)
 	}
 	n = newNode.(*ExplainStmt)
-	node, ok := n.Stmt.Accept(v)
-	if !ok {
-		return n, false
+	if n.Stmt != nil {
+		node, ok := n.Stmt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Stmt = node.(DMLNode)
+	}
+	return v.Leave(n)
+}
+
+// PlanReplayerStmt is a statement to dump the information to replay the plan of a statement,
+// or to load the dumped information. PLAN REPLAYER DUMP EXPLAIN [ANALYZE] stmt sets Stmt and
+// Analyze, PLAN REPLAYER LOAD 'file' sets Load and File.
+type PlanReplayerStmt struct {
+	stmtNode
+
+	Stmt    StmtNode
+	Analyze bool
+	Load    bool
+	File    string
+}
+
+// Accept implements Node Accept interface.
+func (n *PlanReplayerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*PlanReplayerStmt)
+	if n.Stmt != nil {
+		node, ok := n.Stmt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Stmt = node.(StmtNode)
 	}
-	n.Stmt = node.(DMLNode)
 	return v.Leave(n)
 }
 
+// Validate checks that the statement is either the DUMP form with a statement
+// or the LOAD form with a file.
+func (n *PlanReplayerStmt) Validate() error {
+	if n.Load {
+		if n.Stmt != nil || n.Analyze {
+			return errors.New("PLAN REPLAYER LOAD doesn't take a statement")
+		}
+		return nil
+	}
+	if n.Stmt == nil {
+		return errors.New("PLAN REPLAYER DUMP needs a statement")
+	}
+	if n.File != "" {
+		return errors.New("PLAN REPLAYER DUMP doesn't take a file")
+	}
+	return nil
+}
+
 // PrepareStmt is a statement to prepares a SQL statement which contains placeholders,
 // and it is executed with ExecuteStmt and released with DeallocateStmt.
 // See https://dev.mysql.com/doc/refman/5.7/en/prepare.html
 type PrepareStmt struct {
 	stmtNode
 
-	Name    string
+	Name string
+	// SQLText is the text of `PREPARE stmt FROM 'text'`.
 	SQLText string
-	SQLVar  *VariableExpr
+	// SQLVar is the user variable of `PREPARE stmt FROM @var`, the text is its value
+	// at execution. SQLText and SQLVar are mutually exclusive.
+	SQLVar *VariableExpr
+	// SQLStmt is the statement parsed from SQLText or the value of SQLVar.
+	// It is not set by the parser but when the statement is prepared,
+	// and is not visited by Accept.
+	SQLStmt StmtNode
+	// ParamNames is the distinct names of the named parameter markers in SQLStmt,
+	// in the order of their first appearance. It is empty if positional
+	// parameter markers are used. Like SQLStmt, it is set when the statement is prepared.
+	ParamNames []string
 }
 
 // Accept implements Node Accept interface.
@@ -119,325 +280,1629 @@ func (n *PrepareStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
-// DeallocateStmt is a statement to release PreparedStmt.
-// See https://dev.mysql.com/doc/refman/5.7/en/deallocate-prepare.html
-type DeallocateStmt struct {
+// ParamCount returns the number of parameter markers in SQLStmt,
+// including the ones in subqueries and function arguments.
+// It returns 0 if SQLStmt is not set.
+func (n *PrepareStmt) ParamCount() int {
+	if n.SQLStmt == nil {
+		return 0
+	}
+	var counter paramMarkerCounter
+	n.SQLStmt.Accept(&counter)
+	return counter.count
+}
+
+// ParamMarkerNames returns the distinct names of the named parameter markers,
+// in the order of markers. Execute arguments are paired with the names by position,
+// so markers sharing a name share the same argument.
+// names is nil if all the markers are positional, mixed is true if positional
+// and named markers are used together.
+func ParamMarkerNames(markers []*ParamMarkerExpr) (names []string, mixed bool) {
+	var positional bool
+	seen := make(map[string]struct{})
+	for _, m := range markers {
+		if m.Name == "" {
+			positional = true
+			continue
+		}
+		if _, ok := seen[m.Name]; !ok {
+			seen[m.Name] = struct{}{}
+			names = append(names, m.Name)
+		}
+	}
+	return names, positional && len(names) > 0
+}
+
+// paramMarkerCounter is a Visitor to count parameter markers.
+type paramMarkerCounter struct {
+	count int
+}
+
+func (c *paramMarkerCounter) Enter(in Node) (Node, bool) {
+	if _, ok := in.(*ParamMarkerExpr); ok {
+		c.count++
+	}
+	return in, false
+}
+
+func (c *paramMarkerCounter) Leave(in Node) (Node, bool) {
+	return in, true
+}
+
+// DeallocateStmt is a statement to release PreparedStmt.
+// The text protocol DEALLOCATE PREPARE identifies the statement by Name.
+// A statement prepared through the binary protocol has no name, so the
+// deallocation is built with UsingID set and identifies it by ID instead.
+// Name is ignored when UsingID is true, and ID is ignored otherwise.
+// See https://dev.mysql.com/doc/refman/5.7/en/deallocate-prepare.html
+type DeallocateStmt struct {
+	stmtNode
+
+	Name    string
+	ID      uint32
+	UsingID bool
+}
+
+// Accept implements Node Accept interface.
+func (n *DeallocateStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DeallocateStmt)
+	return v.Leave(n)
+}
+
+// ExecuteStmt is a statement to execute PreparedStmt.
+// See https://dev.mysql.com/doc/refman/5.7/en/execute.html
+type ExecuteStmt struct {
+	stmtNode
+
+	Name      string
+	UsingVars []ExprNode
+}
+
+// Accept implements Node Accept interface.
+// It visits UsingVars in the order they are bound to the parameter markers.
+func (n *ExecuteStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ExecuteStmt)
+	for i, val := range n.UsingVars {
+		if val == nil {
+			continue
+		}
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.UsingVars[i] = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// ArgsCount returns the number of arguments given by USING, which is 0 without USING.
+// It is to be checked against PrepareStmt.ParamCount of the prepared statement.
+func (n *ExecuteStmt) ArgsCount() int {
+	return len(n.UsingVars)
+}
+
+// Validate checks that every entry in UsingVars is a user variable,
+// as EXECUTE ... USING accepts neither literals nor system variables.
+func (n *ExecuteStmt) Validate() error {
+	for i, val := range n.UsingVars {
+		if v, ok := val.(*VariableExpr); !ok || v.IsSystem {
+			return errors.Errorf("EXECUTE ... USING only accepts user variables, argument %d is not", i+1)
+		}
+	}
+	return nil
+}
+
+// BeginStmt is a statement to start a new transaction.
+// See https://dev.mysql.com/doc/refman/5.7/en/commit.html
+type BeginStmt struct {
+	stmtNode
+
+	// ReadOnly is true for START TRANSACTION READ ONLY.
+	ReadOnly bool
+	// WithConsistentSnapshot is true for START TRANSACTION WITH CONSISTENT SNAPSHOT.
+	WithConsistentSnapshot bool
+}
+
+// Accept implements Node Accept interface.
+func (n *BeginStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*BeginStmt)
+	return v.Leave(n)
+}
+
+// BinlogStmt is an internal-use statement.
+// We just parse and ignore it.
+// See http://dev.mysql.com/doc/refman/5.7/en/binlog.html
+type BinlogStmt struct {
+	stmtNode
+	Str string
+}
+
+// Accept implements Node Accept interface.
+func (n *BinlogStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*BinlogStmt)
+	return v.Leave(n)
+}
+
+// Binlog node types for ChangeStmt.
+const (
+	PumpType    = "PUMP"
+	DrainerType = "DRAINER"
+)
+
+// ChangeStmt is a statement to change the state of a TiDB binlog node.
+// The syntax is CHANGE {PUMP | DRAINER} TO NODE_STATE = 'state' FOR NODE_ID 'id'.
+type ChangeStmt struct {
+	stmtNode
+
+	// NodeType is PumpType or DrainerType.
+	NodeType string
+	State    string
+	NodeID   string
+}
+
+// Accept implements Node Accept interface.
+func (n *ChangeStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ChangeStmt)
+	return v.Leave(n)
+}
+
+// CompletionType is the value of the AND [NO] CHAIN and [NO] RELEASE options of COMMIT and ROLLBACK.
+type CompletionType int
+
+// Completion types.
+const (
+	// CompletionTypeUnspecified is used when the option is not given,
+	// the completion_type system variable decides the behavior then.
+	CompletionTypeUnspecified CompletionType = iota
+	CompletionTypeYes
+	CompletionTypeNo
+)
+
+// CommitStmt is a statement to commit the current transaction.
+// See https://dev.mysql.com/doc/refman/5.7/en/commit.html
+type CommitStmt struct {
+	stmtNode
+
+	// Chain is CompletionTypeYes for AND CHAIN, which begins a new transaction right after the commit.
+	Chain CompletionType
+	// Release is CompletionTypeYes for RELEASE, which closes the connection after the commit.
+	Release CompletionType
+}
+
+// Accept implements Node Accept interface.
+func (n *CommitStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CommitStmt)
+	return v.Leave(n)
+}
+
+// RollbackStmt is a statement to roll back the current transaction.
+// See https://dev.mysql.com/doc/refman/5.7/en/commit.html
+type RollbackStmt struct {
+	stmtNode
+
+	// SavepointName is the savepoint to roll back to,
+	// empty means rolling back the whole transaction.
+	SavepointName string
+	// Chain and Release are the same as in CommitStmt.
+	Chain   CompletionType
+	Release CompletionType
+}
+
+// Accept implements Node Accept interface.
+func (n *RollbackStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RollbackStmt)
+	return v.Leave(n)
+}
+
+// SavepointStmt is a statement to set a named transaction savepoint.
+// See https://dev.mysql.com/doc/refman/5.7/en/savepoint.html
+type SavepointStmt struct {
+	stmtNode
+
+	Name string
+}
+
+// Accept implements Node Accept interface.
+func (n *SavepointStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*SavepointStmt)
+	return v.Leave(n)
+}
+
+// ReleaseSavepointStmt is a statement to remove a named savepoint
+// from the set of savepoints of the current transaction.
+// See https://dev.mysql.com/doc/refman/5.7/en/savepoint.html
+type ReleaseSavepointStmt struct {
+	stmtNode
+
+	Name string
+}
+
+// Accept implements Node Accept interface.
+func (n *ReleaseSavepointStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ReleaseSavepointStmt)
+	return v.Leave(n)
+}
+
+// UseStmt is a statement to use the DBName database as the current database.
+// See https://dev.mysql.com/doc/refman/5.7/en/use.html
+type UseStmt struct {
+	stmtNode
+
+	DBName string
+}
+
+// Accept implements Node Accept interface.
+func (n *UseStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*UseStmt)
+	return v.Leave(n)
+}
+
+// Validate checks that DBName is a legal database name.
+func (n *UseStmt) Validate() error {
+	return validateIdentifier(n.DBName, ErrWrongDBName)
+}
+
+// Error codes of the validation.
+const (
+	codeTooLongIdent terror.ErrCode = 1059
+	codeWrongDBName  terror.ErrCode = 1102
+)
+
+// Errors of the validation.
+var (
+	// ErrTooLongIdent returns for a too long name of database/table/column.
+	ErrTooLongIdent = terror.ClassAST.New(codeTooLongIdent, "Identifier name '%s' is too long")
+	// ErrWrongDBName returns for an illegal database name.
+	ErrWrongDBName = terror.ClassAST.New(codeWrongDBName, "Incorrect database name '%s'")
+)
+
+func init() {
+	astMySQLErrCodes := map[terror.ErrCode]uint16{
+		codeTooLongIdent: mysql.ErrTooLongIdent,
+		codeWrongDBName:  mysql.ErrWrongDBName,
+	}
+	terror.ErrClassToMySQLCodes[terror.ClassAST] = astMySQLErrCodes
+}
+
+// validateIdentifier checks that name is a legal identifier, errWrongName is returned if it isn't.
+// Like MySQL, a name must be 1 to 64 characters long, can't end with a space and can't contain NUL.
+func validateIdentifier(name string, errWrongName *terror.Error) error {
+	if utf8.RuneCountInString(name) > mysql.MaxDatabaseNameLength {
+		return ErrTooLongIdent.GenByArgs(name)
+	}
+	if name == "" || strings.HasSuffix(name, " ") || strings.ContainsRune(name, 0) {
+		return errWrongName.GenByArgs(name)
+	}
+	return nil
+}
+
+const (
+	// SetNames is the const for set names/charset stmt.
+	// If VariableAssignment.Name == Names, it should be set names/charset stmt.
+	SetNames = "SetNAMES"
+)
+
+// SysVarSyntax is how a system variable is written in a SET statement,
+// the syntaxes are equal in semantics but are kept for restoring the text.
+type SysVarSyntax int
+
+// System variable syntaxes.
+const (
+	// SysVarSyntaxPrefix is `@@GLOBAL.x`, `@@SESSION.x` or `@@x`.
+	SysVarSyntaxPrefix SysVarSyntax = iota
+	// SysVarSyntaxKeyword is `GLOBAL x`, `SESSION x` or `x`.
+	SysVarSyntaxKeyword
+)
+
+// VariableAssignment is a variable assignment struct.
+type VariableAssignment struct {
+	node
+	Name     string
+	Value    ExprNode
+	IsGlobal bool
+	IsSystem bool
+	// IsDefault is true if the value is the DEFAULT keyword, Value is nil then.
+	IsDefault bool
+	// Syntax is how the system variable is written, it is unused for user variables.
+	Syntax SysVarSyntax
+	// ImplicitScope is true if neither GLOBAL nor SESSION is written, the scope is SESSION then.
+	ImplicitScope bool
+
+	// VariableAssignment should be able to store information for SetCharset/SetPWD Stmt.
+	// For SET NAMES, Value is charset, ExtendValue is collation.
+	// TODO: Use SetStmt to implement set password statement.
+	ExtendValue *ValueExpr
+}
+
+// Accept implements Node interface.
+// It visits Value only, ExtendValue is a collation name rather than an expression.
+func (n *VariableAssignment) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*VariableAssignment)
+	if n.Value != nil {
+		node, ok := n.Value.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Value = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// FlushStmtType is the type for FLUSH statement.
+type FlushStmtType int
+
+// Flush statement types.
+const (
+	FlushNone FlushStmtType = iota
+	FlushTables
+	FlushPrivileges
+	FlushStatus
+	FlushLogs
+)
+
+// FlushStmt is a statement to flush tables/privileges/optimizer costs and so on.
+type FlushStmt struct {
+	stmtNode
+
+	Tp              FlushStmtType // Privileges/Tables/...
+	NoWriteToBinLog bool
+	// For FlushTableStmt, if Tables is empty, it means flush all tables.
+	Tables   []*TableName
+	ReadLock bool
+}
+
+// Accept implements Node Accept interface.
+func (n *FlushStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*FlushStmt)
+	for i, t := range n.Tables {
+		if t == nil {
+			continue
+		}
+		node, ok := t.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Tables[i] = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+// OptimizeTableStmt is a statement to reorganize the storage of tables.
+// See https://dev.mysql.com/doc/refman/5.7/en/optimize-table.html
+type OptimizeTableStmt struct {
+	stmtNode
+
+	NoWriteToBinLog bool
+	Tables          []*TableName
+}
+
+// Accept implements Node Accept interface.
+func (n *OptimizeTableStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*OptimizeTableStmt)
+	for i, t := range n.Tables {
+		if t == nil {
+			continue
+		}
+		node, ok := t.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Tables[i] = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+// CheckTableStmt is a statement to check tables for errors, it is different from ADMIN CHECK TABLE.
+// CheckOptions holds the options in upper case as they are written, like "FOR UPGRADE" or "QUICK".
+// See https://dev.mysql.com/doc/refman/5.7/en/check-table.html
+type CheckTableStmt struct {
+	stmtNode
+
+	Tables       []*TableName
+	CheckOptions []string
+}
+
+// Accept implements Node Accept interface.
+func (n *CheckTableStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CheckTableStmt)
+	for i, t := range n.Tables {
+		if t == nil {
+			continue
+		}
+		node, ok := t.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Tables[i] = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+// KillStmt is a statement to kill a query or connection.
+// See https://dev.mysql.com/doc/refman/5.7/en/kill.html
+type KillStmt struct {
+	stmtNode
+
+	// Query is true for KILL QUERY, which terminates the statement the connection
+	// is currently executing but leaves the connection itself intact.
+	Query bool
+	// ConnectionID is the id of the connection to kill.
+	ConnectionID uint64
+	// TiDBExtension is true for KILL TIDB. The plain KILL may be routed to another
+	// tidb-server by a proxy, the TiDB form requires a direct connection.
+	TiDBExtension bool
+}
+
+// Accept implements Node Accept interface.
+func (n *KillStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*KillStmt)
+	return v.Leave(n)
+}
+
+// ShutdownStmt is a statement to stop the server.
+// See https://dev.mysql.com/doc/refman/5.7/en/shutdown.html
+type ShutdownStmt struct {
+	stmtNode
+}
+
+// Accept implements Node Accept interface.
+func (n *ShutdownStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ShutdownStmt)
+	return v.Leave(n)
+}
+
+// RestartStmt is a statement to restart the server.
+// See https://dev.mysql.com/doc/refman/8.0/en/restart.html
+type RestartStmt struct {
+	stmtNode
+}
+
+// Accept implements Node Accept interface.
+func (n *RestartStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RestartStmt)
+	return v.Leave(n)
+}
+
+// AlterInstanceStmt is a statement to change the state of the server instance,
+// only ALTER INSTANCE RELOAD TLS is supported now.
+// See https://dev.mysql.com/doc/refman/8.0/en/alter-instance.html
+type AlterInstanceStmt struct {
+	stmtNode
+
+	// ReloadTLS is set for RELOAD TLS, which reloads the certificate, key and CA files.
+	ReloadTLS bool
+	// NoRollbackOnError disables TLS for new connections if the files fail to reload,
+	// instead of keeping the current TLS context.
+	NoRollbackOnError bool
+}
+
+// Accept implements Node Accept interface.
+func (n *AlterInstanceStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AlterInstanceStmt)
+	return v.Leave(n)
+}
+
+// HelpStmt is a statement to look up a topic in the help tables.
+// See https://dev.mysql.com/doc/refman/5.7/en/help.html
+type HelpStmt struct {
+	stmtNode
+
+	Topic string
+}
+
+// Accept implements Node Accept interface.
+func (n *HelpStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*HelpStmt)
+	return v.Leave(n)
+}
+
+// LoadStatsStmt is a statement to load the statistics dumped to a file.
+type LoadStatsStmt struct {
+	stmtNode
+
+	Path string
+}
+
+// Accept implements Node Accept interface.
+func (n *LoadStatsStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*LoadStatsStmt)
+	return v.Leave(n)
+}
+
+// BRIEKind is the kind of BRIEStmt, BRIE is short for backup and restore integrated with BR.
+type BRIEKind uint8
+
+// BRIE kinds.
+const (
+	BRIEKindBackup BRIEKind = iota
+	BRIEKindRestore
+)
+
+// String implements fmt.Stringer interface.
+func (kind BRIEKind) String() string {
+	switch kind {
+	case BRIEKindBackup:
+		return "BACKUP"
+	case BRIEKindRestore:
+		return "RESTORE"
+	}
+	return ""
+}
+
+// BRIEOption is an option in the WITH clause of BACKUP and RESTORE.
+type BRIEOption struct {
+	// Name is lower-cased.
+	Name  string
+	Value ExprNode
+}
+
+// brieOptions is the options of BACKUP and RESTORE, and the kinds of statement they apply to.
+var brieOptions = map[string][]BRIEKind{
+	"rate_limit":               {BRIEKindBackup, BRIEKindRestore},
+	"concurrency":              {BRIEKindBackup, BRIEKindRestore},
+	"checksum":                 {BRIEKindBackup, BRIEKindRestore},
+	"send_credentials_to_tikv": {BRIEKindBackup, BRIEKindRestore},
+	"last_backup":              {BRIEKindBackup},
+	"snapshot":                 {BRIEKindBackup},
+	"online":                   {BRIEKindRestore},
+}
+
+// BRIEStmt is a statement to back up the cluster to a storage, or restore it from a storage by BR.
+// It is for all the databases if both Schemas and Tables are empty.
+type BRIEStmt struct {
+	stmtNode
+
+	Kind    BRIEKind
+	Schemas []string
+	Tables  []*TableName
+	// Storage is the URL of the storage, like 's3://bucket/path'.
+	Storage string
+	Options []*BRIEOption
+}
+
+// Accept implements Node Accept interface.
+// It visits Tables in order, the option values are not visited.
+func (n *BRIEStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*BRIEStmt)
+	for i, val := range n.Tables {
+		if val == nil {
+			continue
+		}
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Tables[i] = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+// Validate checks that the storage is given, and each option is known for the kind of statement
+// and given at most once.
+func (n *BRIEStmt) Validate() error {
+	if n.Storage == "" {
+		return errors.Errorf("%s needs a storage", n.Kind)
+	}
+	seen := make(map[string]struct{}, len(n.Options))
+	for _, opt := range n.Options {
+		kinds, ok := brieOptions[opt.Name]
+		if !ok {
+			return errors.Errorf("Unknown %s option '%s'", n.Kind, opt.Name)
+		}
+		if !containsBRIEKind(kinds, n.Kind) {
+			return errors.Errorf("%s doesn't take option '%s'", n.Kind, opt.Name)
+		}
+		if _, ok := seen[opt.Name]; ok {
+			return errors.Errorf("%s option '%s' is given more than once", n.Kind, opt.Name)
+		}
+		seen[opt.Name] = struct{}{}
+	}
+	return nil
+}
+
+func containsBRIEKind(kinds []BRIEKind, kind BRIEKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// DropStatsStmt is a statement to drop the statistics of tables, so they are analyzed again.
+// PartitionNames and IsGlobalStats are only set for a single table, PartitionNames is for
+// the statistics of the partitions, and IsGlobalStats for the global statistics of a partitioned table.
+type DropStatsStmt struct {
+	stmtNode
+
+	Tables         []*TableName
+	PartitionNames []string
+	IsGlobalStats  bool
+}
+
+// Accept implements Node Accept interface.
+func (n *DropStatsStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropStatsStmt)
+	for i, val := range n.Tables {
+		if val == nil {
+			continue
+		}
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Tables[i] = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+// CalibrateResourceOptionType is the type of DynamicCalibrateResourceOption.
+type CalibrateResourceOptionType int
+
+// Calibrate resource option types.
+const (
+	CalibrateStartTime CalibrateResourceOptionType = iota
+	CalibrateEndTime
+	CalibrateDuration
+)
+
+// String implements fmt.Stringer interface.
+func (t CalibrateResourceOptionType) String() string {
+	switch t {
+	case CalibrateStartTime:
+		return "START_TIME"
+	case CalibrateEndTime:
+		return "END_TIME"
+	case CalibrateDuration:
+		return "DURATION"
+	}
+	return ""
+}
+
+// calibrateWorkloads is the workloads CALIBRATE RESOURCE can estimate the capacity for.
+var calibrateWorkloads = map[string]struct{}{
+	"tpcc":            {},
+	"oltp_read_write": {},
+	"oltp_read_only":  {},
+	"oltp_write_only": {},
+	"tpch_10":         {},
+}
+
+// CalibrateResourceStmt is a statement to estimate the resource capacity of the cluster,
+// either for a predefined workload, or from the actual load in a time window.
+// WorkloadType and DynamicCalibrateResourceOptionList are mutually exclusive.
+type CalibrateResourceStmt struct {
+	stmtNode
+
+	// WorkloadType is lower-cased.
+	WorkloadType                       string
+	DynamicCalibrateResourceOptionList []*DynamicCalibrateResourceOption
+}
+
+// Accept implements Node Accept interface.
+// It visits DynamicCalibrateResourceOptionList in order.
+func (n *CalibrateResourceStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CalibrateResourceStmt)
+	for i, val := range n.DynamicCalibrateResourceOptionList {
+		if val == nil {
+			continue
+		}
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.DynamicCalibrateResourceOptionList[i] = node.(*DynamicCalibrateResourceOption)
+	}
+	return v.Leave(n)
+}
+
+// Validate checks that the workload is known, and the time window is given by START_TIME
+// and at most one of END_TIME and DURATION, each option at most once.
+func (n *CalibrateResourceStmt) Validate() error {
+	if n.WorkloadType != "" {
+		if _, ok := calibrateWorkloads[n.WorkloadType]; !ok {
+			return errors.Errorf("Unknown CALIBRATE RESOURCE workload '%s'", n.WorkloadType)
+		}
+	}
+	if len(n.DynamicCalibrateResourceOptionList) == 0 {
+		return nil
+	}
+	seen := make(map[CalibrateResourceOptionType]struct{}, len(n.DynamicCalibrateResourceOptionList))
+	for _, opt := range n.DynamicCalibrateResourceOptionList {
+		if _, ok := seen[opt.Tp]; ok {
+			return errors.Errorf("CALIBRATE RESOURCE option %s is given more than once", opt.Tp)
+		}
+		seen[opt.Tp] = struct{}{}
+	}
+	if _, ok := seen[CalibrateStartTime]; !ok {
+		return errors.New("CALIBRATE RESOURCE needs START_TIME for the time window")
+	}
+	_, hasEnd := seen[CalibrateEndTime]
+	_, hasDuration := seen[CalibrateDuration]
+	if hasEnd && hasDuration {
+		return errors.New("CALIBRATE RESOURCE accepts only one of END_TIME and DURATION")
+	}
+	return nil
+}
+
+// DynamicCalibrateResourceOption is an option for the time window of CalibrateResourceStmt.
+// Ts is the time of START_TIME and END_TIME, StrValue is the duration of DURATION, like '20m'.
+type DynamicCalibrateResourceOption struct {
+	node
+
+	Tp       CalibrateResourceOptionType
+	Ts       ExprNode
+	StrValue string
+}
+
+// Accept implements Node Accept interface.
+func (n *DynamicCalibrateResourceOption) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DynamicCalibrateResourceOption)
+	if n.Ts != nil {
+		node, ok := n.Ts.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Ts = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// QueryWatchOptionType is the type of QueryWatchOption.
+type QueryWatchOptionType int
+
+// Query watch option types.
+const (
+	QueryWatchResourceGroup QueryWatchOptionType = iota
+	QueryWatchAction
+	QueryWatchType
+)
+
+// String implements fmt.Stringer interface.
+func (t QueryWatchOptionType) String() string {
+	switch t {
+	case QueryWatchResourceGroup:
+		return "RESOURCE GROUP"
+	case QueryWatchAction:
+		return "ACTION"
+	case QueryWatchType:
+		return "SQL TEXT/SQL DIGEST/PLAN DIGEST"
+	}
+	return ""
+}
+
+// RunawayActionType is the action taken on the queries matched by QUERY WATCH.
+type RunawayActionType int
+
+// Runaway action types.
+const (
+	RunawayActionDryRun RunawayActionType = iota + 1
+	RunawayActionCooldown
+	RunawayActionKill
+)
+
+// String implements fmt.Stringer interface.
+func (t RunawayActionType) String() string {
+	switch t {
+	case RunawayActionDryRun:
+		return "DRYRUN"
+	case RunawayActionCooldown:
+		return "COOLDOWN"
+	case RunawayActionKill:
+		return "KILL"
+	}
+	return ""
+}
+
+// RunawayWatchType is how QUERY WATCH matches the queries.
+type RunawayWatchType int
+
+// Runaway watch types, WatchExact matches the SQL text, WatchSimilar the SQL digest
+// and WatchPlan the plan digest.
+const (
+	WatchExact RunawayWatchType = iota + 1
+	WatchSimilar
+	WatchPlan
+)
+
+// String implements fmt.Stringer interface.
+func (t RunawayWatchType) String() string {
+	switch t {
+	case WatchExact:
+		return "EXACT"
+	case WatchSimilar:
+		return "SIMILAR"
+	case WatchPlan:
+		return "PLAN"
+	}
+	return ""
+}
+
+// QueryWatchOption is an option of AddQueryWatchStmt.
+// ResourceGroupName is set for QueryWatchResourceGroup, and Action for QueryWatchAction.
+// For QueryWatchType, Pattern is the SQL text if TypeSpecified is true, which is
+// SQL TEXT {EXACT | SIMILAR | PLAN} TO 'text', otherwise it is the digest given by
+// SQL DIGEST or PLAN DIGEST, whose WatchType is WatchSimilar or WatchPlan.
+type QueryWatchOption struct {
+	node
+
+	Tp                QueryWatchOptionType
+	ResourceGroupName model.CIStr
+	Action            RunawayActionType
+	WatchType         RunawayWatchType
+	Pattern           string
+	TypeSpecified     bool
+}
+
+// Accept implements Node Accept interface.
+func (n *QueryWatchOption) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*QueryWatchOption)
+	return v.Leave(n)
+}
+
+// AddQueryWatchStmt is a statement to watch the runaway queries matched by the options,
+// and take the action on them.
+type AddQueryWatchStmt struct {
+	stmtNode
+
+	QueryWatchOptionList []*QueryWatchOption
+}
+
+// Accept implements Node Accept interface.
+// It visits QueryWatchOptionList in order.
+func (n *AddQueryWatchStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AddQueryWatchStmt)
+	for i, val := range n.QueryWatchOptionList {
+		if val == nil {
+			continue
+		}
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.QueryWatchOptionList[i] = node.(*QueryWatchOption)
+	}
+	return v.Leave(n)
+}
+
+// Validate checks that each option is given at most once, and the queries to watch are given.
+func (n *AddQueryWatchStmt) Validate() error {
+	seen := make(map[QueryWatchOptionType]struct{}, len(n.QueryWatchOptionList))
+	for _, opt := range n.QueryWatchOptionList {
+		if _, ok := seen[opt.Tp]; ok {
+			return errors.Errorf("QUERY WATCH option %s is given more than once", opt.Tp)
+		}
+		seen[opt.Tp] = struct{}{}
+	}
+	if _, ok := seen[QueryWatchType]; !ok {
+		return errors.New("QUERY WATCH needs one of SQL TEXT, SQL DIGEST and PLAN DIGEST")
+	}
+	return nil
+}
+
+// DropQueryWatchStmt is a statement to remove the query watch item of IntValue.
+type DropQueryWatchStmt struct {
+	stmtNode
+
+	IntValue int64
+}
+
+// Accept implements Node Accept interface.
+func (n *DropQueryWatchStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropQueryWatchStmt)
+	return v.Leave(n)
+}
+
+// CreateBindingStmt creates a SQL binding, the plan of OriginSel is generated from HintedSel,
+// which is the same query with hints.
+type CreateBindingStmt struct {
+	stmtNode
+
+	GlobalScope bool
+	OriginSel   StmtNode
+	HintedSel   StmtNode
+}
+
+// Accept implements Node Accept interface.
+// It visits OriginSel and HintedSel in that order.
+func (n *CreateBindingStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateBindingStmt)
+	if n.OriginSel != nil {
+		node, ok := n.OriginSel.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.OriginSel = node.(StmtNode)
+	}
+	if n.HintedSel != nil {
+		node, ok := n.HintedSel.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.HintedSel = node.(StmtNode)
+	}
+	return v.Leave(n)
+}
+
+// DropBindingStmt drops the SQL binding of OriginSel.
+type DropBindingStmt struct {
+	stmtNode
+
+	GlobalScope bool
+	OriginSel   StmtNode
+}
+
+// Accept implements Node Accept interface.
+func (n *DropBindingStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropBindingStmt)
+	if n.OriginSel != nil {
+		node, ok := n.OriginSel.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.OriginSel = node.(StmtNode)
+	}
+	return v.Leave(n)
+}
+
+// TableLockType is the lock type of a table in LockTablesStmt.
+type TableLockType int
+
+// Table lock types.
+const (
+	TableLockRead TableLockType = iota
+	TableLockReadLocal
+	TableLockWrite
+	TableLockLowPriorityWrite
+)
+
+// String implements fmt.Stringer interface.
+func (t TableLockType) String() string {
+	switch t {
+	case TableLockRead:
+		return "READ"
+	case TableLockReadLocal:
+		return "READ LOCAL"
+	case TableLockWrite:
+		return "WRITE"
+	case TableLockLowPriorityWrite:
+		return "LOW_PRIORITY WRITE"
+	}
+	return ""
+}
+
+// TableLock is a table and the lock type to acquire on it.
+type TableLock struct {
+	Table *TableName
+	Type  TableLockType
+}
+
+// LockTablesStmt is a statement to lock tables.
+// See https://dev.mysql.com/doc/refman/5.7/en/lock-tables.html
+type LockTablesStmt struct {
+	stmtNode
+
+	TableLocks []TableLock
+}
+
+// Accept implements Node Accept interface.
+func (n *LockTablesStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*LockTablesStmt)
+	for i := range n.TableLocks {
+		if n.TableLocks[i].Table == nil {
+			continue
+		}
+		node, ok := n.TableLocks[i].Table.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.TableLocks[i].Table = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+// UnlockTablesStmt is a statement to release the table locks held by the current session.
+// See https://dev.mysql.com/doc/refman/5.7/en/lock-tables.html
+type UnlockTablesStmt struct {
+	stmtNode
+}
+
+// Accept implements Node Accept interface.
+func (n *UnlockTablesStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*UnlockTablesStmt)
+	return v.Leave(n)
+}
+
+// SplitOption is the split points of SplitRegionStmt.
+// Either Lower, Upper and Num are set for the BETWEEN ... AND ... REGIONS form,
+// or ValueLists is set for the BY form.
+type SplitOption struct {
+	Lower      []ExprNode
+	Upper      []ExprNode
+	Num        int64
+	ValueLists [][]ExprNode
+}
+
+// SplitRegionStmt is a statement to pre-split the regions of a table or an index.
+type SplitRegionStmt struct {
+	stmtNode
+
+	Table     *TableName
+	IndexName string
+	SplitOpt  *SplitOption
+}
+
+// Accept implements Node Accept interface.
+func (n *SplitRegionStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*SplitRegionStmt)
+	if n.Table != nil {
+		node, ok := n.Table.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Table = node.(*TableName)
+	}
+	if n.SplitOpt == nil {
+		return v.Leave(n)
+	}
+	for i, val := range n.SplitOpt.Lower {
+		if val == nil {
+			continue
+		}
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.SplitOpt.Lower[i] = node.(ExprNode)
+	}
+	for i, val := range n.SplitOpt.Upper {
+		if val == nil {
+			continue
+		}
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.SplitOpt.Upper[i] = node.(ExprNode)
+	}
+	for i, list := range n.SplitOpt.ValueLists {
+		for j, val := range list {
+			if val == nil {
+				continue
+			}
+			node, ok := val.Accept(v)
+			if !ok {
+				return n, false
+			}
+			n.SplitOpt.ValueLists[i][j] = node.(ExprNode)
+		}
+	}
+	return v.Leave(n)
+}
+
+// SetStmt is the statement to set variables.
+type SetStmt struct {
+	stmtNode
+	// Variables is the list of variable assignment.
+	Variables []*VariableAssignment
+}
+
+// Accept implements Node Accept interface.
+// It visits Variables in the order they are assigned.
+func (n *SetStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*SetStmt)
+	for i, val := range n.Variables {
+		if val == nil {
+			continue
+		}
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Variables[i] = node.(*VariableAssignment)
+	}
+	return v.Leave(n)
+}
+
+// TransactionScope is the scope of the SET TRANSACTION statement.
+type TransactionScope int
+
+// Transaction scopes.
+const (
+	// TransactionScopeNext applies to the next transaction of the current session only.
+	TransactionScopeNext TransactionScope = iota
+	TransactionScopeSession
+	TransactionScopeGlobal
+)
+
+// Transaction isolation levels, the values are the same as the tx_isolation variable.
+const (
+	ReadUncommitted = "READ-UNCOMMITTED"
+	ReadCommitted   = "READ-COMMITTED"
+	RepeatableRead  = "REPEATABLE-READ"
+	Serializable    = "SERIALIZABLE"
+)
+
+// Transaction access modes.
+const (
+	TransactionReadWrite = "READ WRITE"
+	TransactionReadOnly  = "READ ONLY"
+)
+
+// SetTransactionStmt is the statement to set the characteristics of transactions.
+// See https://dev.mysql.com/doc/refman/5.7/en/set-transaction.html
+type SetTransactionStmt struct {
 	stmtNode
 
-	Name string
+	Scope TransactionScope
+	// IsolationLevel is empty if the isolation level is not specified.
+	IsolationLevel string
+	// AccessMode is empty if the access mode is not specified.
+	AccessMode string
 }
 
 // Accept implements Node Accept interface.
-func (n *DeallocateStmt) Accept(v Visitor) (Node, bool) {
+func (n *SetTransactionStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*DeallocateStmt)
+	n = newNode.(*SetTransactionStmt)
 	return v.Leave(n)
 }
 
-// ExecuteStmt is a statement to execute PreparedStmt.
-// See https://dev.mysql.com/doc/refman/5.7/en/execute.html
-type ExecuteStmt struct {
+// SetNamesStmt is a statement to assign values to character and collation variables.
+// It is built for a SET statement that only contains SET NAMES or SET CHARACTER SET,
+// the assignment in a list mixed with other variables is still a VariableAssignment.
+// Collate is empty if the COLLATE clause is omitted. For SET NAMES DEFAULT,
+// Charset is empty and IsDefault is true.
+// See https://dev.mysql.com/doc/refman/5.7/en/set-names.html
+type SetNamesStmt struct {
 	stmtNode
 
-	Name      string
-	UsingVars []ExprNode
+	Charset   string
+	Collate   string
+	IsDefault bool
 }
 
 // Accept implements Node Accept interface.
-func (n *ExecuteStmt) Accept(v Visitor) (Node, bool) {
+func (n *SetNamesStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*ExecuteStmt)
-	for i, val := range n.UsingVars {
-		node, ok := val.Accept(v)
-		if !ok {
-			return n, false
-		}
-		n.UsingVars[i] = node.(ExprNode)
-	}
+	n = newNode.(*SetNamesStmt)
 	return v.Leave(n)
 }
 
-// BeginStmt is a statement to start a new transaction.
-// See https://dev.mysql.com/doc/refman/5.7/en/commit.html
-type BeginStmt struct {
+// SetPwdStmt is a statement to assign a password to user account.
+// A nil User means the current user. Password is the plain text password,
+// with any surrounding PASSWORD(...) already stripped by the parser.
+// See https://dev.mysql.com/doc/refman/5.7/en/set-password.html
+type SetPwdStmt struct {
 	stmtNode
+
+	User     *UserIdentity
+	Password string
 }
 
 // Accept implements Node Accept interface.
-func (n *BeginStmt) Accept(v Visitor) (Node, bool) {
+func (n *SetPwdStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*BeginStmt)
+	n = newNode.(*SetPwdStmt)
 	return v.Leave(n)
 }
 
-// BinlogStmt is an internal-use statement.
-// We just parse and ignore it.
-// See http://dev.mysql.com/doc/refman/5.7/en/binlog.html
-type BinlogStmt struct {
-	stmtNode
-	Str string
+// UserIdentity represents the 'user'@'host' of an account.
+// Hostname is "%" if the host part is omitted, and Username is empty for anonymous users.
+// CurrentUser is true for CURRENT_USER or CURRENT_USER(), Username and Hostname are unused then.
+type UserIdentity struct {
+	Username    string
+	Hostname    string
+	CurrentUser bool
 }
 
-// Accept implements Node Accept interface.
-func (n *BinlogStmt) Accept(v Visitor) (Node, bool) {
-	newNode, skipChildren := v.Enter(n)
-	if skipChildren {
-		return v.Leave(newNode)
+// String returns the canonical 'user'@'host' form of the user.
+func (user *UserIdentity) String() string {
+	if user.CurrentUser {
+		return "CURRENT_USER()"
 	}
-	n = newNode.(*BinlogStmt)
-	return v.Leave(n)
+	return quoteString(user.Username) + "@" + quoteString(user.Hostname)
 }
 
-// CommitStmt is a statement to commit the current transaction.
-// See https://dev.mysql.com/doc/refman/5.7/en/commit.html
-type CommitStmt struct {
+// RoleIdentity represents the 'role'@'host' of a MySQL 8 role.
+// Hostname is "%" if the host part is omitted.
+type RoleIdentity struct {
+	Username string
+	Hostname string
+}
+
+// String returns the canonical 'role'@'host' form of the role.
+func (role *RoleIdentity) String() string {
+	return quoteString(role.Username) + "@" + quoteString(role.Hostname)
+}
+
+// SetRoleType is the type of the SET ROLE statement.
+type SetRoleType int
+
+// SET ROLE types.
+const (
+	SetRoleDefault SetRoleType = iota
+	SetRoleNone
+	SetRoleAll
+	SetRoleAllExcept
+	SetRoleRegular
+)
+
+// SetRoleStmt is the statement to set the active roles of the current session.
+// See https://dev.mysql.com/doc/refman/8.0/en/set-role.html
+type SetRoleStmt struct {
 	stmtNode
+
+	SetRoleOpt SetRoleType
+	// RoleList is the roles to activate for SetRoleRegular,
+	// or the roles to exclude for SetRoleAllExcept.
+	RoleList []*RoleIdentity
 }
 
 // Accept implements Node Accept interface.
-func (n *CommitStmt) Accept(v Visitor) (Node, bool) {
+func (n *SetRoleStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*CommitStmt)
+	n = newNode.(*SetRoleStmt)
 	return v.Leave(n)
 }
 
-// RollbackStmt is a statement to roll back the current transaction.
-// See https://dev.mysql.com/doc/refman/5.7/en/commit.html
-type RollbackStmt struct {
+// SetDefaultRoleStmt is the statement to set the roles activated when the users log in.
+// SetRoleOpt is one of SetRoleNone, SetRoleAll and SetRoleRegular.
+// See https://dev.mysql.com/doc/refman/8.0/en/set-default-role.html
+type SetDefaultRoleStmt struct {
 	stmtNode
+
+	SetRoleOpt SetRoleType
+	// RoleList is the roles to activate for SetRoleRegular.
+	RoleList []*RoleIdentity
+	UserList []*UserIdentity
 }
 
 // Accept implements Node Accept interface.
-func (n *RollbackStmt) Accept(v Visitor) (Node, bool) {
+func (n *SetDefaultRoleStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*RollbackStmt)
+	n = newNode.(*SetDefaultRoleStmt)
 	return v.Leave(n)
 }
 
-// UseStmt is a statement to use the DBName database as the current database.
-// See https://dev.mysql.com/doc/refman/5.7/en/use.html
-type UseStmt struct {
+// SetConfigStmt is the statement to change the config of a cluster component,
+// like SET CONFIG tikv split.qps-threshold = 1000.
+type SetConfigStmt struct {
 	stmtNode
 
-	DBName string
+	// Type is the lower-cased component type, like tikv or pd.
+	Type string
+	// Instance is the address of a single instance, like '127.0.0.1:20160'.
+	Instance string
+	// Name is the config item name, like split.qps-threshold.
+	Name  string
+	Value ExprNode
 }
 
 // Accept implements Node Accept interface.
-func (n *UseStmt) Accept(v Visitor) (Node, bool) {
+func (n *SetConfigStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*UseStmt)
+	n = newNode.(*SetConfigStmt)
+	if n.Value != nil {
+		node, ok := n.Value.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Value = node.(ExprNode)
+	}
 	return v.Leave(n)
 }
 
-const (
-	// SetNames is the const for set names/charset stmt.
-	// If VariableAssignment.Name == Names, it should be set names/charset stmt.
-	SetNames = "SetNAMES"
-)
+// Validate checks that exactly one of Type and Instance is set.
+func (n *SetConfigStmt) Validate() error {
+	if (n.Type == "") == (n.Instance == "") {
+		return errors.Errorf("SET CONFIG requires exactly one of a component type or an instance address")
+	}
+	return nil
+}
 
-// VariableAssignment is a variable assignment struct.
-type VariableAssignment struct {
-	node
-	Name     string
-	Value    ExprNode
-	IsGlobal bool
-	IsSystem bool
+// UserSpec is used for parsing create user statement.
+type UserSpec struct {
+	User    *UserIdentity
+	AuthOpt *AuthOption
+}
 
-	// VariableAssignment should be able to store information for SetCharset/SetPWD Stmt.
-	// For SetCharsetStmt, Value is charset, ExtendValue is collation.
-	// TODO: Use SetStmt to implement set password statement.
-	ExtendValue *ValueExpr
+// CreateUserStmt creates user account.
+// See https://dev.mysql.com/doc/refman/5.7/en/create-user.html
+type CreateUserStmt struct {
+	stmtNode
+
+	IfNotExists bool
+	Specs       []*UserSpec
 }
 
-// Accept implements Node interface.
-func (n *VariableAssignment) Accept(v Visitor) (Node, bool) {
+// Accept implements Node Accept interface.
+func (n *CreateUserStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*VariableAssignment)
-	node, ok := n.Value.Accept(v)
-	if !ok {
-		return n, false
-	}
-	n.Value = node.(ExprNode)
+	n = newNode.(*CreateUserStmt)
 	return v.Leave(n)
 }
 
-// FlushStmtType is the type for FLUSH statement.
-type FlushStmtType int
-
-// Flush statement types.
-const (
-	FlushNone FlushStmtType = iota
-	FlushTables
-	FlushPrivileges
-)
-
-// FlushStmt is a statement to flush tables/privileges/optimizer costs and so on.
-type FlushStmt struct {
+// AlterUserStmt modifies user account.
+// See https://dev.mysql.com/doc/refman/5.7/en/alter-user.html
+type AlterUserStmt struct {
 	stmtNode
 
-	Tp              FlushStmtType // Privileges/Tables/...
-	NoWriteToBinLog bool
-	// For FlushTableStmt, if Tables is empty, it means flush all tables.
-	Tables   []*TableName
-	ReadLock bool
+	IfExists    bool
+	CurrentAuth *AuthOption
+	Specs       []*UserSpec
 }
 
 // Accept implements Node Accept interface.
-func (n *FlushStmt) Accept(v Visitor) (Node, bool) {
+func (n *AlterUserStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*FlushStmt)
+	n = newNode.(*AlterUserStmt)
 	return v.Leave(n)
 }
 
-// SetStmt is the statement to set variables.
-type SetStmt struct {
+// DropUserStmt creates user account.
+// See http://dev.mysql.com/doc/refman/5.7/en/drop-user.html
+type DropUserStmt struct {
 	stmtNode
-	// Variables is the list of variable assignment.
-	Variables []*VariableAssignment
+
+	IfExists bool
+	UserList []*UserIdentity
 }
 
 // Accept implements Node Accept interface.
-func (n *SetStmt) Accept(v Visitor) (Node, bool) {
+func (n *DropUserStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*SetStmt)
-	for i, val := range n.Variables {
-		node, ok := val.Accept(v)
-		if !ok {
-			return n, false
-		}
-		n.Variables[i] = node.(*VariableAssignment)
-	}
+	n = newNode.(*DropUserStmt)
 	return v.Leave(n)
 }
 
-/*
-// SetCharsetStmt is a statement to assign values to character and collation variables.
-// See https://dev.mysql.com/doc/refman/5.7/en/set-statement.html
-type SetCharsetStmt struct {
+// CreateRoleStmt creates roles.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-role.html
+type CreateRoleStmt struct {
 	stmtNode
 
-	Charset string
-	Collate string
+	IfNotExists bool
+	RoleList    []*RoleIdentity
 }
 
 // Accept implements Node Accept interface.
-func (n *SetCharsetStmt) Accept(v Visitor) (Node, bool) {
+func (n *CreateRoleStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*SetCharsetStmt)
+	n = newNode.(*CreateRoleStmt)
 	return v.Leave(n)
 }
-*/
 
-// SetPwdStmt is a statement to assign a password to user account.
-// See https://dev.mysql.com/doc/refman/5.7/en/set-password.html
-type SetPwdStmt struct {
+// DropRoleStmt drops roles.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-role.html
+type DropRoleStmt struct {
 	stmtNode
 
-	User     string
-	Password string
+	IfExists bool
+	RoleList []*RoleIdentity
 }
 
 // Accept implements Node Accept interface.
-func (n *SetPwdStmt) Accept(v Visitor) (Node, bool) {
+func (n *DropRoleStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*SetPwdStmt)
+	n = newNode.(*DropRoleStmt)
 	return v.Leave(n)
 }
 
-// UserSpec is used for parsing create user statement.
-type UserSpec struct {
-	User    string
-	AuthOpt *AuthOption
-}
-
-// CreateUserStmt creates user account.
-// See https://dev.mysql.com/doc/refman/5.7/en/create-user.html
-type CreateUserStmt struct {
+// GrantRoleStmt grants each of the Roles to each of the Users.
+// See https://dev.mysql.com/doc/refman/8.0/en/grant.html
+type GrantRoleStmt struct {
 	stmtNode
 
-	IfNotExists bool
-	Specs       []*UserSpec
+	Roles []*RoleIdentity
+	Users []*UserIdentity
 }
 
 // Accept implements Node Accept interface.
-func (n *CreateUserStmt) Accept(v Visitor) (Node, bool) {
+func (n *GrantRoleStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*CreateUserStmt)
+	n = newNode.(*GrantRoleStmt)
 	return v.Leave(n)
 }
 
-// AlterUserStmt modifies user account.
-// See https://dev.mysql.com/doc/refman/5.7/en/alter-user.html
-type AlterUserStmt struct {
+// RevokeRoleStmt revokes each of the Roles from each of the Users.
+// See https://dev.mysql.com/doc/refman/8.0/en/revoke.html
+type RevokeRoleStmt struct {
 	stmtNode
 
-	IfExists    bool
-	CurrentAuth *AuthOption
-	Specs       []*UserSpec
+	Roles []*RoleIdentity
+	Users []*UserIdentity
 }
 
 // Accept implements Node Accept interface.
-func (n *AlterUserStmt) Accept(v Visitor) (Node, bool) {
+func (n *RevokeRoleStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*AlterUserStmt)
+	n = newNode.(*RevokeRoleStmt)
 	return v.Leave(n)
 }
 
-// DropUserStmt creates user account.
-// See http://dev.mysql.com/doc/refman/5.7/en/drop-user.html
-type DropUserStmt struct {
+// EmptyStmt is an empty statement between two semicolons, like the second statement in "SELECT 1;;SELECT 2".
+type EmptyStmt struct {
 	stmtNode
-
-	IfExists bool
-	UserList []string
 }
 
 // Accept implements Node Accept interface.
-func (n *DropUserStmt) Accept(v Visitor) (Node, bool) {
+func (n *EmptyStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
 	if skipChildren {
 		return v.Leave(newNode)
 	}
-	n = newNode.(*DropUserStmt)
+	n = newNode.(*EmptyStmt)
 	return v.Leave(n)
 }
 
@@ -456,6 +1921,9 @@ func (n *DoStmt) Accept(v Visitor) (Node, bool) {
 	}
 	n = newNode.(*DoStmt)
 	for i, val := range n.Exprs {
+		if val == nil {
+			continue
+		}
 		node, ok := val.Accept(v)
 		if !ok {
 			return n, false
@@ -472,14 +1940,21 @@ type AdminStmtType int
 const (
 	AdminShowDDL = iota + 1
 	AdminCheckTable
+	AdminShowDDLJobs
+	AdminCancelDDLJobs
+	AdminCheckIndex
 )
 
 // AdminStmt is the struct for Admin statement.
+// Tables holds the target of ADMIN CHECK TABLE and ADMIN CHECK INDEX,
+// Index names the index to check, and JobIDs lists the jobs to cancel.
 type AdminStmt struct {
 	stmtNode
 
 	Tp     AdminStmtType
+	Index  string
 	Tables []*TableName
+	JobIDs []int64
 }
 
 // Accept implements Node Accpet interface.
@@ -491,6 +1966,9 @@ func (n *AdminStmt) Accept(v Visitor) (Node, bool) {
 
 	n = newNode.(*AdminStmt)
 	for i, val := range n.Tables {
+		if val == nil {
+			continue
+		}
 		node, ok := val.Accept(v)
 		if !ok {
 			return n, false
@@ -501,6 +1979,40 @@ func (n *AdminStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// RepairTableStmt is a statement to rebuild the metadata of Table from the definition in CreateStmt,
+// it is used when the metadata is corrupted.
+type RepairTableStmt struct {
+	stmtNode
+
+	Table      *TableName
+	CreateStmt *CreateTableStmt
+}
+
+// Accept implements Node Accept interface.
+// It visits Table and CreateStmt in that order.
+func (n *RepairTableStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RepairTableStmt)
+	if n.Table != nil {
+		node, ok := n.Table.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Table = node.(*TableName)
+	}
+	if n.CreateStmt != nil {
+		node, ok := n.CreateStmt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.CreateStmt = node.(*CreateTableStmt)
+	}
+	return v.Leave(n)
+}
+
 // PrivElem is the privilege type and optional column list.
 type PrivElem struct {
 	node
@@ -517,6 +2029,9 @@ func (n *PrivElem) Accept(v Visitor) (Node, bool) {
 	}
 	n = newNode.(*PrivElem)
 	for i, val := range n.Cols {
+		if val == nil {
+			continue
+		}
 		node, ok := val.Accept(v)
 		if !ok {
 			return n, false
@@ -576,6 +2091,44 @@ func (n *GrantStmt) Accept(v Visitor) (Node, bool) {
 	}
 	n = newNode.(*GrantStmt)
 	for i, val := range n.Privs {
+		if val == nil {
+			continue
+		}
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Privs[i] = node.(*PrivElem)
+	}
+	return v.Leave(n)
+}
+
+// RevokeStmt is the struct for REVOKE statement.
+// REVOKE ..., GRANT OPTION is represented by a PrivElem of mysql.GrantPriv in Privs.
+// See https://dev.mysql.com/doc/refman/5.7/en/revoke.html
+type RevokeStmt struct {
+	stmtNode
+
+	Privs      []*PrivElem
+	ObjectType ObjectTypeType
+	Level      *GrantLevel
+	Users      []*UserSpec
+	// AllLevels is set by REVOKE ALL PRIVILEGES, GRANT OPTION FROM user, which has no ON
+	// clause and revokes the privileges at every level. Level is global for it.
+	AllLevels bool
+}
+
+// Accept implements Node Accept interface.
+func (n *RevokeStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RevokeStmt)
+	for i, val := range n.Privs {
+		if val == nil {
+			continue
+		}
 		node, ok := val.Accept(v)
 		if !ok {
 			return n, false
@@ -615,6 +2168,22 @@ type AnalyzeTableStmt struct {
 	stmtNode
 
 	TableNames []*TableName
+	// IndexFlag is set by the INDEX form, IndexNames is empty if all the indices are analyzed.
+	IndexNames []model.CIStr
+	IndexFlag  bool
+	// MaxNumBuckets is the WITH n BUCKETS option, 0 means the server default.
+	MaxNumBuckets uint64
+}
+
+// MaxAnalyzeNumBuckets is the largest number of buckets WITH n BUCKETS accepts.
+const MaxAnalyzeNumBuckets = 1024
+
+// Validate checks that the number of buckets is not larger than MaxAnalyzeNumBuckets.
+func (n *AnalyzeTableStmt) Validate() error {
+	if n.MaxNumBuckets > MaxAnalyzeNumBuckets {
+		return errors.Errorf("The number of buckets %d is larger than the maximum %d", n.MaxNumBuckets, MaxAnalyzeNumBuckets)
+	}
+	return nil
 }
 
 // Accept implements Node Accept interface.
@@ -625,6 +2194,9 @@ func (n *AnalyzeTableStmt) Accept(v Visitor) (Node, bool) {
 	}
 	n = newNode.(*AnalyzeTableStmt)
 	for i, val := range n.TableNames {
+		if val == nil {
+			continue
+		}
 		node, ok := val.Accept(v)
 		if !ok {
 			return n, false
@@ -14,8 +14,19 @@
 package ast_test
 
 import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/juju/errors"
 	. "github.com/pingcap/check"
 	. "github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/mysql"
 	"github.com/pingcap/tidb/parser"
 )
 
@@ -42,22 +53,68 @@ func (visitor1) Enter(in Node) (Node, bool) {
 	return in, true
 }
 
+// skipRecorder skips all children and records the nodes it enters and leaves.
+type skipRecorder struct {
+	entered []Node
+	left    []Node
+}
+
+func (v *skipRecorder) Enter(in Node) (Node, bool) {
+	v.entered = append(v.entered, in)
+	return in, true
+}
+
+func (v *skipRecorder) Leave(in Node) (Node, bool) {
+	v.left = append(v.left, in)
+	return in, true
+}
+
 func (ts *testMiscSuite) TestMiscVisitorCover(c *C) {
 	stmts := []Node{
 		(&AdminStmt{}),
 		(&AlterUserStmt{}),
 		(&BeginStmt{}),
 		(&BinlogStmt{}),
+		(&ChangeStmt{}),
 		(&CommitStmt{}),
 		(&CreateUserStmt{}),
 		(&DeallocateStmt{}),
 		(&DoStmt{}),
+		(&EmptyStmt{}),
 		(&ExecuteStmt{UsingVars: []ExprNode{&ValueExpr{}}}),
 		(&ExplainStmt{Stmt: &ShowStmt{}}),
+		(&PlanReplayerStmt{Stmt: &SelectStmt{}}),
+		(&PlanReplayerStmt{Load: true}),
+		(&TraceStmt{Stmt: &ShowStmt{}}),
 		(&GrantStmt{}),
+		(&RevokeStmt{Privs: []*PrivElem{{Cols: []*ColumnName{{}}}}}),
 		(&PrepareStmt{SQLVar: &VariableExpr{Value: &ValueExpr{}}}),
 		(&RollbackStmt{}),
 		(&SetPwdStmt{}),
+		(&SetConfigStmt{Value: &ValueExpr{}}),
+		(&SetRoleStmt{}),
+		(&SetDefaultRoleStmt{}),
+		(&AlterInstanceStmt{}),
+		(&CreateRoleStmt{}),
+		(&DropRoleStmt{}),
+		(&GrantRoleStmt{}),
+		(&RevokeRoleStmt{}),
+		(&ShutdownStmt{}),
+		(&RestartStmt{}),
+		(&HelpStmt{}),
+		(&LoadStatsStmt{}),
+		(&DropStatsStmt{Tables: []*TableName{{}}}),
+		(&BRIEStmt{Tables: []*TableName{{}}}),
+		(&AddQueryWatchStmt{QueryWatchOptionList: []*QueryWatchOption{{Tp: QueryWatchAction}}}),
+		(&DropQueryWatchStmt{}),
+		(&NonTransactionalDMLStmt{DMLStmt: &DeleteStmt{TableRefs: &TableRefsClause{TableRefs: &Join{Left: &TableName{}}}}, ShardColumn: &ColumnName{}}),
+		(&CreateBindingStmt{OriginSel: &SelectStmt{}, HintedSel: &SelectStmt{}}),
+		(&DropBindingStmt{OriginSel: &SelectStmt{}}),
+		(&CalibrateResourceStmt{DynamicCalibrateResourceOptionList: []*DynamicCalibrateResourceOption{{Ts: &ValueExpr{}}, {Tp: CalibrateDuration}}}),
+		(&RepairTableStmt{Table: &TableName{}, CreateStmt: &CreateTableStmt{Table: &TableName{}}}),
+		(&LockTablesStmt{TableLocks: []TableLock{{Table: &TableName{}}}}),
+		(&SplitRegionStmt{Table: &TableName{}, SplitOpt: &SplitOption{Lower: []ExprNode{&ValueExpr{}}, Upper: []ExprNode{&ValueExpr{}}, ValueLists: [][]ExprNode{{&ValueExpr{}}}}}),
+		(&UnlockTablesStmt{}),
 		(&SetStmt{Variables: []*VariableAssignment{
 			{
 				Value: &ValueExpr{},
@@ -69,7 +126,10 @@ func (ts *testMiscSuite) TestMiscVisitorCover(c *C) {
 				{},
 			},
 		}),
-		(&FlushStmt{}),
+		(&FlushStmt{Tables: []*TableName{{}}}),
+		(&OptimizeTableStmt{Tables: []*TableName{{}}}),
+		(&CheckTableStmt{Tables: []*TableName{{}}}),
+		(&TableOptimizerHint{}),
 		(&PrivElem{}),
 		(&VariableAssignment{Value: &ValueExpr{}}),
 	}
@@ -77,7 +137,72 @@ func (ts *testMiscSuite) TestMiscVisitorCover(c *C) {
 	for _, v := range stmts {
 		v.Accept(visitor{})
 		v.Accept(visitor1{})
+
+		// Leave is still called when Enter skips the children.
+		r := &skipRecorder{}
+		node, ok := v.Accept(r)
+		c.Assert(ok, IsTrue)
+		c.Assert(node, Equals, v)
+		c.Assert(r.entered, HasLen, 1)
+		c.Assert(r.entered[0], Equals, v)
+		c.Assert(r.left, HasLen, 1)
+		c.Assert(r.left[0], Equals, v, Commentf("%T", v))
+	}
+}
+
+// enterRecorder records the nodes it enters and visits all children.
+type enterRecorder struct {
+	visitor
+	entered []Node
+}
+
+func (v *enterRecorder) Enter(in Node) (Node, bool) {
+	v.entered = append(v.entered, in)
+	return in, false
+}
+
+func (ts *testMiscSuite) TestMiscVisitorNilChildren(c *C) {
+	stmts := []Node{
+		(&TraceStmt{}),
+		(&ExplainStmt{}),
+		(&ExecuteStmt{UsingVars: []ExprNode{nil}}),
+		(&FlushStmt{Tables: []*TableName{nil}}),
+		(&OptimizeTableStmt{Tables: []*TableName{nil}}),
+		(&CheckTableStmt{Tables: []*TableName{nil}}),
+		(&BRIEStmt{Tables: []*TableName{nil}}),
+		(&DropStatsStmt{Tables: []*TableName{nil}}),
+		(&CalibrateResourceStmt{DynamicCalibrateResourceOptionList: []*DynamicCalibrateResourceOption{nil, {}}}),
+		(&AddQueryWatchStmt{QueryWatchOptionList: []*QueryWatchOption{nil}}),
+		(&CreateBindingStmt{}),
+		(&CreateBindingStmt{OriginSel: &SelectStmt{}}),
+		(&DropBindingStmt{}),
+		(&LockTablesStmt{TableLocks: []TableLock{{}}}),
+		(&SplitRegionStmt{}),
+		(&SplitRegionStmt{SplitOpt: &SplitOption{Lower: []ExprNode{nil}, Upper: []ExprNode{nil}, ValueLists: [][]ExprNode{{nil}}}}),
+		(&SetStmt{Variables: []*VariableAssignment{nil, {}}}),
+		(&SetConfigStmt{}),
+		(&DoStmt{Exprs: []ExprNode{nil}}),
+		(&AdminStmt{Tables: []*TableName{nil}}),
+		(&RepairTableStmt{}),
+		(&PrivElem{Cols: []*ColumnName{nil}}),
+		(&GrantStmt{Privs: []*PrivElem{nil}}),
+		(&RevokeStmt{Privs: []*PrivElem{nil}}),
+		(&AnalyzeTableStmt{TableNames: []*TableName{nil}}),
+	}
+	for _, v := range stmts {
+		node, ok := v.Accept(visitor{})
+		c.Assert(ok, IsTrue, Commentf("%T", v))
+		c.Assert(node, Equals, v)
 	}
+
+	// The children which are not nil are still visited.
+	value := &ValueExpr{}
+	stmt := &SetStmt{Variables: []*VariableAssignment{nil, {Value: value}}}
+	r := &enterRecorder{}
+	_, ok := stmt.Accept(r)
+	c.Assert(ok, IsTrue)
+	c.Assert(r.entered, HasLen, 3)
+	c.Assert(r.entered[2], Equals, value)
 }
 
 func (ts *testMiscSuite) TestDDLVisitorCover(c *C) {
@@ -90,6 +215,11 @@ drop database test;
 drop index t_i on t;
 drop table t;
 truncate t;
+recover table t;
+recover table by job 10;
+flashback table t;
+flashback table t to t1;
+rename table t to t1, t2 to t3;
 create table t (
 jobAbbr char(4) not null,
 constraint foreign key (jobabbr) references ffxi_jobtype (jobabbr) on delete cascade on update cascade
@@ -112,7 +242,9 @@ insert into t_copy select * from t where t.x > 5;
 (select a from t1 where a=10 and b=1) union (select a from t2 where a=11 and b=2) order by a limit 10;
 update t1 set col1 = col1 + 1, col2 = col1;
 show create table t;
-load data infile '/tmp/t.csv' into table t fields terminated by 'ab' enclosed by 'b';`
+load data infile '/tmp/t.csv' into table t fields terminated by 'ab' enclosed by 'b';
+load data local infile '/tmp/t.csv' into table t lines starting by 'xy' (a, b);
+import into t (a, @b) from '/tmp/t.csv' with thread = 8;`
 
 	parser := parser.New()
 	stmts, err := parser.Parse(sql, "", "")
@@ -122,3 +254,804 @@ load data infile '/tmp/t.csv' into table t fields terminated by 'ab' enclosed by
 		stmt.Accept(visitor1{})
 	}
 }
+
+type nodeRecorder struct {
+	visitor
+	nodes []Node
+}
+
+func (v *nodeRecorder) Enter(in Node) (Node, bool) {
+	v.nodes = append(v.nodes, in)
+	return in, false
+}
+
+func (ts *testMiscSuite) TestShowStmtVisitOrder(c *C) {
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("show columns from t like 'c%' order by field limit 50, 50", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ShowStmt)
+	c.Assert(show.Table, NotNil)
+	c.Assert(show.Pattern, NotNil)
+	c.Assert(show.OrderBy, NotNil)
+	c.Assert(show.Limit, NotNil)
+	show.Column = &ColumnName{}
+	show.Where = &ValueExpr{}
+
+	v := &nodeRecorder{}
+	show.Accept(v)
+	var order []Node
+	for _, n := range v.nodes {
+		switch n {
+		case show.Table, show.Column, show.Pattern, show.Where, show.OrderBy, show.Limit:
+			order = append(order, n)
+		}
+	}
+	c.Assert(order, DeepEquals, []Node{show.Table, show.Column, show.Pattern, show.Where, show.OrderBy, show.Limit})
+}
+
+func (ts *testMiscSuite) TestSelectStmtVisitOrder(c *C) {
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("select a from t where b > 1 group by a having a > 1 order by a", "", "")
+	c.Assert(err, IsNil)
+	sel := stmt.(*SelectStmt)
+
+	v := &nodeRecorder{}
+	sel.Accept(v)
+	var order []Node
+	for _, n := range v.nodes {
+		switch n {
+		case sel.From, sel.Where, sel.Fields, sel.GroupBy, sel.Having, sel.OrderBy:
+			order = append(order, n)
+		}
+	}
+	// From and Where are visited before Fields.
+	c.Assert(order, DeepEquals, []Node{sel.From, sel.Where, sel.Fields, sel.GroupBy, sel.Having, sel.OrderBy})
+}
+
+func (ts *testMiscSuite) TestSetStmtVisitOrder(c *C) {
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("set @a = 1, @@session.b = 2, c = 3", "", "")
+	c.Assert(err, IsNil)
+	set := stmt.(*SetStmt)
+	c.Assert(set.Variables, HasLen, 3)
+
+	v := &nodeRecorder{}
+	set.Accept(v)
+	var order []Node
+	for _, n := range v.nodes {
+		for _, va := range set.Variables {
+			if n == va || n == va.Value {
+				order = append(order, n)
+			}
+		}
+	}
+	var expected []Node
+	for _, va := range set.Variables {
+		expected = append(expected, va, va.Value)
+	}
+	c.Assert(order, DeepEquals, expected)
+}
+
+func (ts *testMiscSuite) TestExecuteStmtVisitOrder(c *C) {
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("execute s using @c, @a, @b", "", "")
+	c.Assert(err, IsNil)
+	execute := stmt.(*ExecuteStmt)
+
+	v := &nodeRecorder{}
+	execute.Accept(v)
+	var names []string
+	for _, n := range v.nodes {
+		if va, ok := n.(*VariableExpr); ok {
+			names = append(names, va.Name)
+		}
+	}
+	c.Assert(names, DeepEquals, []string{"c", "a", "b"})
+}
+
+type valueRewriter struct {
+	visitor
+}
+
+func (valueRewriter) Leave(in Node) (Node, bool) {
+	if _, ok := in.(*ValueExpr); ok {
+		return &ParamMarkerExpr{}, true
+	}
+	return in, true
+}
+
+func (ts *testMiscSuite) TestGrantStmtVisitColumns(c *C) {
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("grant select (c1, c2) on db.t to 'u'@'%'", "", "")
+	c.Assert(err, IsNil)
+	grant := stmt.(*GrantStmt)
+
+	v := &nodeRecorder{}
+	grant.Accept(v)
+	var cols []Node
+	for _, n := range v.nodes {
+		if _, ok := n.(*ColumnName); ok {
+			cols = append(cols, n)
+		}
+	}
+	c.Assert(cols, DeepEquals, []Node{grant.Privs[0].Cols[0], grant.Privs[0].Cols[1]})
+}
+
+func (ts *testMiscSuite) TestVariableAssignmentDefault(c *C) {
+	set := &SetStmt{Variables: []*VariableAssignment{
+		{Name: "autocommit", IsSystem: true, IsDefault: true},
+	}}
+	v := &nodeRecorder{}
+	set.Accept(v)
+	c.Assert(v.nodes, HasLen, 2)
+	c.Assert(set.Variables[0].Value, IsNil)
+}
+
+func (ts *testMiscSuite) TestDoStmtRewrite(c *C) {
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("do 1, sleep(2)", "", "")
+	c.Assert(err, IsNil)
+	node, ok := stmt.Accept(valueRewriter{})
+	c.Assert(ok, IsTrue)
+	do := node.(*DoStmt)
+	c.Assert(do.Exprs, HasLen, 2)
+	c.Assert(do.Exprs[0], FitsTypeOf, &ParamMarkerExpr{})
+	c.Assert(do.Exprs[1].(*FuncCallExpr).Args[0], FitsTypeOf, &ParamMarkerExpr{})
+}
+
+func (ts *testMiscSuite) TestPrepareStmtParamCount(c *C) {
+	c.Assert((&PrepareStmt{}).ParamCount(), Equals, 0)
+
+	parser := parser.New()
+	cases := []struct {
+		sql   string
+		count int
+	}{
+		{"select 1", 0},
+		{"select ?, ?", 2},
+		{"select abs(?) from t where a in (select b from t1 where c > ?) limit ?", 3},
+		{"insert into t values (?, concat(?, 'a'))", 2},
+		{"update t set a = ? where b = (select max(c) from t1 where d = ?)", 2},
+	}
+	for _, ca := range cases {
+		stmt, err := parser.ParseOneStmt(ca.sql, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", ca.sql))
+		prepare := &PrepareStmt{SQLText: ca.sql, SQLStmt: stmt}
+		c.Assert(prepare.ParamCount(), Equals, ca.count, Commentf("source %s", ca.sql))
+	}
+}
+
+func (ts *testMiscSuite) TestParamMarkerNames(c *C) {
+	names, mixed := ParamMarkerNames([]*ParamMarkerExpr{{}, {}})
+	c.Assert(names, IsNil)
+	c.Assert(mixed, IsFalse)
+	names, mixed = ParamMarkerNames([]*ParamMarkerExpr{{Name: "b"}, {Name: "a"}, {Name: "b"}})
+	c.Assert(names, DeepEquals, []string{"b", "a"})
+	c.Assert(mixed, IsFalse)
+	_, mixed = ParamMarkerNames([]*ParamMarkerExpr{{Name: "a"}, {}})
+	c.Assert(mixed, IsTrue)
+}
+
+func (ts *testMiscSuite) TestUserIdentityString(c *C) {
+	c.Assert((&UserIdentity{Username: "u", Hostname: "%"}).String(), Equals, "'u'@'%'")
+	c.Assert((&UserIdentity{Username: "", Hostname: "localhost"}).String(), Equals, "''@'localhost'")
+	c.Assert((&UserIdentity{Username: "it's", Hostname: "h"}).String(), Equals, "'it''s'@'h'")
+	c.Assert((&UserIdentity{CurrentUser: true}).String(), Equals, "CURRENT_USER()")
+}
+
+func (ts *testMiscSuite) TestWalk(c *C) {
+	stmt, err := parser.New().ParseOneStmt("set @@global.autocommit = 0, @a = (select b from t where c = 1), names utf8", "", "")
+	c.Assert(err, IsNil)
+	var names []string
+	var columns int
+	Walk(stmt, func(n Node) bool {
+		switch x := n.(type) {
+		case *VariableAssignment:
+			names = append(names, x.Name)
+		case *ColumnNameExpr:
+			columns++
+		case *SubqueryExpr:
+			// Skip the children of subqueries.
+			return false
+		}
+		return true
+	})
+	c.Assert(names, DeepEquals, []string{"autocommit", "a", SetNames})
+	c.Assert(columns, Equals, 0)
+
+	columns = 0
+	Walk(stmt, func(n Node) bool {
+		if _, ok := n.(*ColumnNameExpr); ok {
+			columns++
+		}
+		return true
+	})
+	c.Assert(columns, Equals, 2)
+}
+
+// tracer logs the nodes it enters and leaves, it can skip children or stop visiting.
+type tracer struct {
+	name string
+	log  *[]string
+	skip bool
+	stop bool
+}
+
+func (t *tracer) Enter(in Node) (Node, bool) {
+	*t.log = append(*t.log, fmt.Sprintf("%s>%T", t.name, in))
+	return in, t.skip
+}
+
+func (t *tracer) Leave(in Node) (Node, bool) {
+	*t.log = append(*t.log, fmt.Sprintf("%s<%T", t.name, in))
+	return in, !t.stop
+}
+
+func (ts *testMiscSuite) TestChainVisitors(c *C) {
+	stmt, err := parser.New().ParseOneStmt("do 1", "", "")
+	c.Assert(err, IsNil)
+
+	var log []string
+	a := &tracer{name: "a", log: &log}
+	b := &tracer{name: "b", log: &log}
+	_, ok := stmt.Accept(ChainVisitors(a, b))
+	c.Assert(ok, IsTrue)
+	c.Assert(log, DeepEquals, []string{
+		"a>*ast.DoStmt", "b>*ast.DoStmt",
+		"a>*ast.ValueExpr", "b>*ast.ValueExpr", "b<*ast.ValueExpr", "a<*ast.ValueExpr",
+		"b<*ast.DoStmt", "a<*ast.DoStmt",
+	})
+
+	// Skipping children in one visitor skips them for all, but Enter and Leave are still called.
+	log = nil
+	a.skip = true
+	_, ok = stmt.Accept(ChainVisitors(a, b))
+	c.Assert(ok, IsTrue)
+	c.Assert(log, DeepEquals, []string{"a>*ast.DoStmt", "b>*ast.DoStmt", "b<*ast.DoStmt", "a<*ast.DoStmt"})
+
+	// Stopping in one visitor's Leave stops the traversal.
+	log = nil
+	a.skip = false
+	b.stop = true
+	_, ok = stmt.Accept(ChainVisitors(a, b))
+	c.Assert(ok, IsFalse)
+	c.Assert(log, DeepEquals, []string{"a>*ast.DoStmt", "b>*ast.DoStmt", "a>*ast.ValueExpr", "b>*ast.ValueExpr", "b<*ast.ValueExpr"})
+
+	// Each visitor receives the node returned by the previous one.
+	log = nil
+	b.stop = false
+	_, ok = stmt.Accept(ChainVisitors(a, valueRewriter{}))
+	c.Assert(ok, IsTrue)
+	c.Assert(log, DeepEquals, []string{
+		"a>*ast.DoStmt", "a>*ast.ValueExpr", "a<*ast.ParamMarkerExpr", "a<*ast.DoStmt",
+	})
+}
+
+// showRejecter stops visiting on ShowStmt.
+type showRejecter struct {
+	err error
+}
+
+func (r *showRejecter) Enter(n Node) (Node, bool) {
+	return n, false
+}
+
+func (r *showRejecter) Leave(n Node) (Node, bool) {
+	if _, ok := n.(*ShowStmt); ok {
+		r.err = &UnsupportedNodeError{Node: n}
+		return n, false
+	}
+	return n, true
+}
+
+func (r *showRejecter) Error() error {
+	return r.err
+}
+
+func (ts *testMiscSuite) TestAcceptWithError(c *C) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("do 1", "", "")
+	c.Assert(err, IsNil)
+	_, err = AcceptWithError(stmt, &showRejecter{})
+	c.Assert(err, IsNil)
+
+	stmt, err = p.ParseOneStmt("show tables", "", "")
+	c.Assert(err, IsNil)
+	_, err = AcceptWithError(stmt, &showRejecter{})
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "ShowStmt is not supported")
+	unsupported, ok := errors.Cause(err).(*UnsupportedNodeError)
+	c.Assert(ok, IsTrue)
+	c.Assert(unsupported.Node, Equals, stmt)
+
+	// The reason is kept through ChainVisitors.
+	_, err = AcceptWithError(stmt, ChainVisitors(&tracer{log: new([]string)}, &showRejecter{}))
+	c.Assert(err, ErrorMatches, "ShowStmt is not supported")
+
+	// A visitor which is not an ErrorVisitor stops without a reason.
+	_, err = AcceptWithError(stmt, &tracer{log: new([]string), stop: true})
+	c.Assert(err, Equals, ErrVisitStopped)
+
+	// The error can be printed without a node.
+	c.Assert((&UnsupportedNodeError{}).Error(), Equals, "nil node is not supported")
+	c.Assert((&UnsupportedNodeError{Node: (*ShowStmt)(nil)}).Error(), Equals, "ShowStmt is not supported")
+}
+
+func (ts *testMiscSuite) TestExtractTableRefs(c *C) {
+	table := []struct {
+		sql    string
+		tables []string
+	}{
+		{"explain select * from t1 join db.t2 on t1.a = t2.a left join t1 as t3 on t3.b = t2.b", []string{"t1", "db.t2"}},
+		{"select * from t1 where a in (select a from t2 where b = (select max(b) from T1))", []string{"t1", "t2"}},
+		{"show columns from db.t", []string{"db.t"}},
+		{"insert into t1 select * from t1 join t2", []string{"t1", "t2"}},
+		{"set @a = 1", nil},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		var names []string
+		for _, tn := range ExtractTableRefs(stmt) {
+			name := tn.Name.O
+			if tn.Schema.O != "" {
+				name = tn.Schema.O + "." + name
+			}
+			names = append(names, name)
+		}
+		c.Assert(names, DeepEquals, t.tables, Commentf("sql: %s", t.sql))
+	}
+}
+
+func (ts *testMiscSuite) TestInternal(c *C) {
+	stmt, err := parser.New().ParseOneStmt("select a + 1 from t where b = 1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.IsInternal(), IsFalse)
+	stmt.SetInternal()
+
+	node, ok := stmt.Accept(visitor{})
+	c.Assert(ok, IsTrue)
+	c.Assert(node.IsInternal(), IsTrue)
+	c.Assert(FoldConstants(node).IsInternal(), IsTrue)
+	c.Assert(CloneStmt(stmt).IsInternal(), IsTrue)
+	// Only the marked node is internal.
+	c.Assert(stmt.(*SelectStmt).Where.IsInternal(), IsFalse)
+}
+
+func (ts *testMiscSuite) TestExtractVariables(c *C) {
+	table := []struct {
+		sql    string
+		user   []string
+		system []string
+	}{
+		{"set @a = 1, @@global.autocommit = 0, sql_mode = @@GLOBAL.sql_mode, @A = @b", []string{"a", "b"}, []string{"autocommit", "sql_mode"}},
+		{"select @a, @@max_connections from t where b = (select @c := 1)", []string{"c", "a"}, []string{"max_connections"}},
+		{"set names utf8", nil, []string{"character_set_client", "character_set_connection", "character_set_results", "collation_connection"}},
+		{"set @a = 1, character set utf8", []string{"a"}, []string{"character_set_client", "character_set_connection", "character_set_results", "collation_connection"}},
+		{"execute s using @a, @b, @a", []string{"a", "b"}, nil},
+		{"prepare s from @sql", []string{"sql"}, nil},
+		{"select 1", nil, nil},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		user, system := ExtractVariables(stmt)
+		c.Assert(user, DeepEquals, t.user, Commentf("sql: %s", t.sql))
+		c.Assert(system, DeepEquals, t.system, Commentf("sql: %s", t.sql))
+	}
+}
+
+func (ts *testMiscSuite) TestExtractSubqueries(c *C) {
+	// prefixes are the source text where each subquery begins.
+	table := []struct {
+		sql      string
+		prefixes []string
+	}{
+		{"select a, (select max(b) from t2 where t2.a = t1.a) from t1 where exists (select 1 from t3 where t3.a = t1.a and t3.b in (select b from t4))",
+			[]string{"(select max(b)", "(select 1 from t3", "(select b from t4"}},
+		{"explain select * from (select a from t1 union select a from t2) as t where a > any (select a from t3)",
+			[]string{"(select a from t3"}},
+		{"delete from t1 where a in (select a from t2)", []string{"(select a from t2"}},
+		{"select 1", nil},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		subqueries := ExtractSubqueries(stmt)
+		c.Assert(subqueries, HasLen, len(t.prefixes), Commentf("sql: %s", t.sql))
+		for i, s := range subqueries {
+			c.Assert(strings.HasPrefix(t.sql[s.OriginTextPosition():], t.prefixes[i]), IsTrue, Commentf("sql: %s, subquery %d", t.sql, i))
+		}
+	}
+}
+
+func (ts *testMiscSuite) TestRewriteSchema(c *C) {
+	mapping := map[string]string{"Logic": "phy_1", "other": "phy_2"}
+	table := []struct {
+		sql    string
+		expect string
+	}{
+		{"use logic", "USE `phy_1`"},
+		{"use unknown", "USE `unknown`"},
+		{"show tables from LOGIC", "SHOW TABLES FROM `phy_1`"},
+		{"show tables", "SHOW TABLES"},
+		{"select logic.t.a, b from logic.t join other.u on logic.t.id = u.id join t", "SELECT `phy_1`.`t`.`a`, `b` FROM `phy_1`.`t` JOIN `phy_2`.`u` ON `phy_1`.`t`.`id` = `u`.`id` JOIN `t`"},
+		{"explain select * from other.t where a in (select a from logic.t)", "EXPLAIN SELECT * FROM `phy_2`.`t` WHERE `a` IN (SELECT `a` FROM `phy_1`.`t`)"},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		node := RewriteSchema(stmt, mapping)
+		c.Assert(node, Equals, stmt)
+		var sb bytes.Buffer
+		c.Assert(Restore(&sb, node), IsNil, Commentf("sql: %s", t.sql))
+		c.Assert(sb.String(), Equals, t.expect, Commentf("sql: %s", t.sql))
+	}
+
+	stmt, err := parser.New().ParseOneStmt("grant select on logic.* to 'u'", "", "")
+	c.Assert(err, IsNil)
+	RewriteSchema(stmt, mapping)
+	c.Assert(stmt.(*GrantStmt).Level.DBName, Equals, "phy_1")
+}
+
+func (ts *testMiscSuite) TestExecuteStmtValidate(c *C) {
+	stmt := &ExecuteStmt{Name: "s", UsingVars: []ExprNode{&VariableExpr{Name: "a"}}}
+	c.Assert(stmt.Validate(), IsNil)
+
+	stmt.UsingVars = append(stmt.UsingVars, &ValueExpr{})
+	c.Assert(stmt.Validate(), ErrorMatches, ".*argument 2 is not")
+
+	stmt.UsingVars = []ExprNode{&VariableExpr{Name: "autocommit", IsSystem: true}}
+	c.Assert(stmt.Validate(), NotNil)
+}
+
+func (ts *testMiscSuite) TestExecuteStmtArgsCount(c *C) {
+	table := []struct {
+		sql   string
+		count int
+	}{
+		{"execute s", 0},
+		{"execute s using @a", 1},
+		{"execute s using @a, @b, @a", 3},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		c.Assert(stmt.(*ExecuteStmt).ArgsCount(), Equals, t.count, Commentf("sql: %s", t.sql))
+	}
+	c.Assert((&ExecuteStmt{}).ArgsCount(), Equals, 0)
+	c.Assert((&ExecuteStmt{UsingVars: []ExprNode{}}).ArgsCount(), Equals, 0)
+}
+
+func (ts *testMiscSuite) TestBRIEStmtValidate(c *C) {
+	table := []struct {
+		sql string
+		err string
+	}{
+		{"backup database * to 's3://bucket/path' with rate_limit = 120, snapshot = 0", ""},
+		{"restore table t from 'local:///tmp/backup' with online = 1", ""},
+		{"backup database test to ''", "BACKUP needs a storage"},
+		{"backup database * to 'local:///tmp/backup' with rate = 120", "Unknown BACKUP option 'rate'"},
+		{"backup database * to 'local:///tmp/backup' with online = 1", "BACKUP doesn't take option 'online'"},
+		{"restore database * from 'local:///tmp/backup' with last_backup = 1", "RESTORE doesn't take option 'last_backup'"},
+		{"restore database * from 'local:///tmp/backup' with CHECKSUM = 1, checksum = 0", "RESTORE option 'checksum' is given more than once"},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		err = stmt.(*BRIEStmt).Validate()
+		if t.err == "" {
+			c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		} else {
+			c.Assert(err, ErrorMatches, t.err, Commentf("sql: %s", t.sql))
+		}
+	}
+}
+
+func (ts *testMiscSuite) TestImportIntoStmtValidate(c *C) {
+	table := []struct {
+		sql string
+		err string
+	}{
+		{"import into t from '/tmp/t.csv'", ""},
+		{"import into t from '/tmp/t.sql' format 'SQL' with thread = 8, detached, skip_rows = 1", ""},
+		{"import into t from '/tmp/t.csv' format 'xml'", "Unknown IMPORT INTO format 'xml'"},
+		{"import into t from '/tmp/t.csv' with threads = 8", "Unknown IMPORT INTO option 'threads'"},
+		{"import into t from '/tmp/t.csv' with THREAD = 8, thread = 4", "IMPORT INTO option 'thread' is given more than once"},
+		{"import into t from '/tmp/t.csv' with thread", "IMPORT INTO option 'thread' needs a value"},
+		{"import into t from '/tmp/t.csv' with detached = 1", "IMPORT INTO option 'detached' doesn't take a value"},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		err = stmt.(*ImportIntoStmt).Validate()
+		if t.err == "" {
+			c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		} else {
+			c.Assert(err, ErrorMatches, t.err, Commentf("sql: %s", t.sql))
+		}
+	}
+}
+
+func (ts *testMiscSuite) TestCalibrateResourceStmtValidate(c *C) {
+	table := []struct {
+		sql string
+		err string
+	}{
+		{"calibrate resource", ""},
+		{"calibrate resource workload TPCC", ""},
+		{"calibrate resource start_time '2023-04-18 08:00:00' end_time '2023-04-18 08:20:00'", ""},
+		{"calibrate resource start_time date_sub(now(), interval 20 minute) duration '20m'", ""},
+		{"calibrate resource start_time '2023-04-18 08:00:00'", ""},
+		{"calibrate resource workload tpcd", "Unknown CALIBRATE RESOURCE workload 'tpcd'"},
+		{"calibrate resource end_time '2023-04-18 08:20:00'", "CALIBRATE RESOURCE needs START_TIME for the time window"},
+		{"calibrate resource start_time '2023-04-18 08:00:00', start_time '2023-04-18 08:10:00'", "CALIBRATE RESOURCE option START_TIME is given more than once"},
+		{"calibrate resource start_time '2023-04-18 08:00:00' end_time '2023-04-18 08:20:00' duration '20m'", "CALIBRATE RESOURCE accepts only one of END_TIME and DURATION"},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		err = stmt.(*CalibrateResourceStmt).Validate()
+		if t.err == "" {
+			c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		} else {
+			c.Assert(err, ErrorMatches, t.err, Commentf("sql: %s", t.sql))
+		}
+	}
+}
+
+func (ts *testMiscSuite) TestPlanReplayerStmtValidate(c *C) {
+	p := parser.New()
+	for _, sql := range []string{"plan replayer dump explain select 1", "plan replayer load 'a.zip'"} {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		c.Assert(err, IsNil)
+		c.Assert(stmt.(*PlanReplayerStmt).Validate(), IsNil, Commentf("sql: %s", sql))
+	}
+
+	cases := []struct {
+		stmt *PlanReplayerStmt
+		err  string
+	}{
+		{&PlanReplayerStmt{Load: true, File: "a.zip", Stmt: &SelectStmt{}}, "PLAN REPLAYER LOAD doesn't take a statement"},
+		{&PlanReplayerStmt{Load: true, File: "a.zip", Analyze: true}, "PLAN REPLAYER LOAD doesn't take a statement"},
+		{&PlanReplayerStmt{}, "PLAN REPLAYER DUMP needs a statement"},
+		{&PlanReplayerStmt{Stmt: &SelectStmt{}, File: "a.zip"}, "PLAN REPLAYER DUMP doesn't take a file"},
+	}
+	for _, ca := range cases {
+		c.Assert(ca.stmt.Validate(), ErrorMatches, ca.err)
+	}
+}
+
+func (ts *testMiscSuite) TestSetConfigStmtValidate(c *C) {
+	stmt := &SetConfigStmt{Type: "tikv", Name: "split.qps-threshold", Value: &ValueExpr{}}
+	c.Assert(stmt.Validate(), IsNil)
+	stmt.Instance = "127.0.0.1:20160"
+	c.Assert(stmt.Validate(), NotNil)
+	stmt.Type = ""
+	c.Assert(stmt.Validate(), IsNil)
+	stmt.Instance = ""
+	c.Assert(stmt.Validate(), NotNil)
+}
+
+// stmtTypeNames returns the names of the statement types declared in package ast,
+// which are the structs embedding stmtNode, ddlNode or dmlNode.
+func stmtTypeNames(c *C) []string {
+	fset := token.NewFileSet()
+	pkgs, err := goparser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	c.Assert(err, IsNil)
+	var names []string
+	for _, file := range pkgs["ast"].Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*goast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*goast.TypeSpec)
+				st, ok := typeSpec.Type.(*goast.StructType)
+				if !ok || !typeSpec.Name.IsExported() {
+					continue
+				}
+				for _, field := range st.Fields.List {
+					ident, ok := field.Type.(*goast.Ident)
+					if !ok || len(field.Names) != 0 {
+						continue
+					}
+					switch ident.Name {
+					case "stmtNode", "ddlNode", "dmlNode":
+						names = append(names, typeSpec.Name.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+func (ts *testMiscSuite) TestStmtType(c *C) {
+	table := []struct {
+		stmt     StmtNode
+		category StmtCategory
+	}{
+		{&CreateDatabaseStmt{}, StmtCategoryDDL},
+		{&DropDatabaseStmt{}, StmtCategoryDDL},
+		{&CreateTableStmt{}, StmtCategoryDDL},
+		{&DropTableStmt{}, StmtCategoryDDL},
+		{&RenameTableStmt{}, StmtCategoryDDL},
+		{&CreateIndexStmt{}, StmtCategoryDDL},
+		{&DropIndexStmt{}, StmtCategoryDDL},
+		{&AlterTableStmt{}, StmtCategoryDDL},
+		{&FlashBackTableStmt{}, StmtCategoryDDL},
+		{&RecoverTableStmt{}, StmtCategoryDDL},
+		{&TruncateTableStmt{}, StmtCategoryDDL},
+		{&SelectStmt{}, StmtCategoryDML},
+		{&UnionStmt{}, StmtCategoryDML},
+		{&InsertStmt{}, StmtCategoryDML},
+		{&UpdateStmt{}, StmtCategoryDML},
+		{&DeleteStmt{}, StmtCategoryDML},
+		{&LoadDataStmt{}, StmtCategoryDML},
+		{&ImportIntoStmt{}, StmtCategoryDML},
+		{&NonTransactionalDMLStmt{}, StmtCategoryDML},
+		{&DoStmt{}, StmtCategoryDML},
+		{&BeginStmt{}, StmtCategoryTransaction},
+		{&CommitStmt{}, StmtCategoryTransaction},
+		{&RollbackStmt{}, StmtCategoryTransaction},
+		{&SavepointStmt{}, StmtCategoryTransaction},
+		{&ReleaseSavepointStmt{}, StmtCategoryTransaction},
+		{&SetTransactionStmt{}, StmtCategoryTransaction},
+		{&LockTablesStmt{}, StmtCategoryTransaction},
+		{&UnlockTablesStmt{}, StmtCategoryTransaction},
+		{&PrepareStmt{}, StmtCategoryPrepared},
+		{&ExecuteStmt{}, StmtCategoryPrepared},
+		{&DeallocateStmt{}, StmtCategoryPrepared},
+		{&ShowStmt{}, StmtCategoryUtility},
+		{&SetStmt{}, StmtCategoryUtility},
+		{&SetNamesStmt{}, StmtCategoryUtility},
+		{&SetPwdStmt{}, StmtCategoryUtility},
+		{&SetRoleStmt{}, StmtCategoryUtility},
+		{&SetDefaultRoleStmt{}, StmtCategoryUtility},
+		{&AlterInstanceStmt{}, StmtCategoryUtility},
+		{&SetConfigStmt{}, StmtCategoryUtility},
+		{&UseStmt{}, StmtCategoryUtility},
+		{&ExplainStmt{}, StmtCategoryUtility},
+		{&PlanReplayerStmt{}, StmtCategoryUtility},
+		{&TraceStmt{}, StmtCategoryUtility},
+		{&EmptyStmt{}, StmtCategoryUtility},
+		{&CreateUserStmt{}, StmtCategoryUtility},
+		{&AlterUserStmt{}, StmtCategoryUtility},
+		{&DropUserStmt{}, StmtCategoryUtility},
+		{&GrantStmt{}, StmtCategoryUtility},
+		{&RevokeStmt{}, StmtCategoryUtility},
+		{&CreateRoleStmt{}, StmtCategoryUtility},
+		{&DropRoleStmt{}, StmtCategoryUtility},
+		{&GrantRoleStmt{}, StmtCategoryUtility},
+		{&RevokeRoleStmt{}, StmtCategoryUtility},
+		{&AdminStmt{}, StmtCategoryUtility},
+		{&RepairTableStmt{}, StmtCategoryUtility},
+		{&AnalyzeTableStmt{}, StmtCategoryUtility},
+		{&BinlogStmt{}, StmtCategoryUtility},
+		{&ChangeStmt{}, StmtCategoryUtility},
+		{&FlushStmt{}, StmtCategoryUtility},
+		{&OptimizeTableStmt{}, StmtCategoryUtility},
+		{&CheckTableStmt{}, StmtCategoryUtility},
+		{&KillStmt{}, StmtCategoryUtility},
+		{&ShutdownStmt{}, StmtCategoryUtility},
+		{&RestartStmt{}, StmtCategoryUtility},
+		{&HelpStmt{}, StmtCategoryUtility},
+		{&LoadStatsStmt{}, StmtCategoryUtility},
+		{&DropStatsStmt{}, StmtCategoryUtility},
+		{&AddQueryWatchStmt{}, StmtCategoryUtility},
+		{&DropQueryWatchStmt{}, StmtCategoryUtility},
+		{&BRIEStmt{}, StmtCategoryUtility},
+		{&SplitRegionStmt{}, StmtCategoryUtility},
+		{&CreateBindingStmt{}, StmtCategoryUtility},
+		{&DropBindingStmt{}, StmtCategoryUtility},
+		{&CalibrateResourceStmt{}, StmtCategoryUtility},
+	}
+	listed := make(map[string]struct{}, len(table))
+	for _, t := range table {
+		c.Assert(StmtType(t.stmt), Equals, t.category, Commentf("%T", t.stmt))
+		listed[reflect.TypeOf(t.stmt).Elem().Name()] = struct{}{}
+	}
+
+	// Every statement type must be listed above, so a new one can't be left without a category.
+	names := stmtTypeNames(c)
+	c.Assert(names, HasLen, len(table))
+	for _, name := range names {
+		_, ok := listed[name]
+		c.Assert(ok, IsTrue, Commentf("%s has no category", name))
+	}
+}
+
+func (ts *testMiscSuite) TestIsReadOnly(c *C) {
+	table := []struct {
+		sql      string
+		readOnly bool
+	}{
+		{"select * from t", true},
+		{"select * from t for update", false},
+		{"select * from t lock in share mode", false},
+		{"select a from t union select a from u", true},
+		{"select a from t union (select a from u for update)", false},
+		{"select * from t where a in (select a from u)", true},
+		{"show tables", true},
+		{"explain select * from t", true},
+		{"explain insert into t values (1)", true},
+		{"explain format = 'dot' delete from t", true},
+		{"explain t", true},
+		{"explain analyze select * from t", true},
+		{"explain analyze select * from t for update", false},
+		{"explain analyze insert into t values (1)", false},
+		{"explain analyze update t set a = 1", false},
+		{"trace select * from t", true},
+		{"trace delete from t", false},
+		{"use test", true},
+		{"begin", true},
+		{"commit", true},
+		{"rollback", true},
+		{"set @a = 1, autocommit = 0", true},
+		{"set names utf8", true},
+		{"set global autocommit = 0", false},
+		{"set @@global.autocommit = 0", false},
+		{"set transaction isolation level read committed", true},
+		{"set global transaction isolation level read committed", false},
+		{"insert into t values (1)", false},
+		{"update t set a = 1", false},
+		{"delete from t", false},
+		{"create table t (a int)", false},
+		{"drop table t", false},
+		{"analyze table t", false},
+		{"execute s", false},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		c.Assert(IsReadOnly(stmt), Equals, t.readOnly, Commentf("sql: %s", t.sql))
+	}
+}
+
+func (ts *testMiscSuite) TestIsWriteToSystemTable(c *C) {
+	table := []struct {
+		sql   string
+		write bool
+	}{
+		{"select * from mysql.user", false},
+		{"explain update mysql.user set password = ''", false},
+		{"update mysql.user set password = ''", true},
+		{"UPDATE MySQL.user SET password = ''", true},
+		{"insert into mysql.db (host) values ('%')", true},
+		{"delete from mysql.tables_priv", true},
+		{"delete t from t join mysql.user on t.a = user.user", true},
+		{"insert into t select user from mysql.user", true},
+		{"explain analyze delete from mysql.user", true},
+		{"drop table mysql.user", true},
+		{"update t set a = 1", false},
+		{"update mysqldb.user set a = 1", false},
+		{"select * from mysql.user for update", true},
+		{"grant select on *.* to 'u'", false},
+		// The unqualified names are not resolved.
+		{"update user set password = ''", false},
+	}
+	for _, t := range table {
+		stmt, err := parser.New().ParseOneStmt(t.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", t.sql))
+		c.Assert(IsWriteToSystemTable(stmt), Equals, t.write, Commentf("sql: %s", t.sql))
+	}
+}
+
+func (ts *testMiscSuite) TestUseStmtValidate(c *C) {
+	c.Assert((&UseStmt{DBName: "test"}).Validate(), IsNil)
+	c.Assert((&UseStmt{DBName: strings.Repeat("a", 64)}).Validate(), IsNil)
+	c.Assert((&UseStmt{DBName: strings.Repeat("数", 64)}).Validate(), IsNil)
+	err := (&UseStmt{DBName: strings.Repeat("a", 65)}).Validate()
+	c.Assert(ErrTooLongIdent.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*Identifier name '.*' is too long")
+	for _, name := range []string{"", "test ", "te\x00st"} {
+		err = (&UseStmt{DBName: name}).Validate()
+		c.Assert(ErrWrongDBName.Equal(err), IsTrue, Commentf("name %q", name))
+		c.Assert(err, ErrorMatches, ".*Incorrect database name .*", Commentf("name %q", name))
+	}
+	c.Assert(ErrWrongDBName.ToSQLError().Code, Equals, uint16(mysql.ErrWrongDBName))
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/juju/errors"
+)
+
+// Normalize returns the fingerprint of stmt, which is the restored SQL text with
+// every literal replaced by '?' and every IN list of literals collapsed to '(...)'.
+// Statements of the same shape have the same fingerprint regardless of their
+// literals and whitespaces. stmt is not modified. Normalize returns an empty
+// string if stmt can not be restored, like DDL statements.
+func Normalize(stmt StmtNode) string {
+	normalized, _ := CloneStmt(stmt).Accept(normalizer{})
+	var buf bytes.Buffer
+	if err := Restore(&buf, normalized); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// normalizer replaces the literals of the visited tree with parameter markers.
+type normalizer struct{}
+
+// Enter implements Visitor interface.
+func (nz normalizer) Enter(n Node) (Node, bool) {
+	if x, ok := n.(*FuncCallExpr); ok {
+		acceptExprArgs(x, nz)
+		return n, true
+	}
+	return n, false
+}
+
+// Leave implements Visitor interface.
+func (nz normalizer) Leave(n Node) (Node, bool) {
+	switch x := n.(type) {
+	case *ValueExpr:
+		return &ParamMarkerExpr{}, true
+	case *PatternInExpr:
+		if x.Sel != nil || len(x.List) == 0 {
+			break
+		}
+		for _, item := range x.List {
+			if _, ok := item.(*ParamMarkerExpr); !ok {
+				return n, true
+			}
+		}
+		x.List = []ExprNode{&normalizedList{}}
+	}
+	return n, true
+}
+
+// acceptExprArgs visits the arguments of n except the keywords of the function syntax,
+// like the time unit of DATE_ADD, which must be restored as they are.
+func acceptExprArgs(n *FuncCallExpr, v Visitor) {
+	for i, arg := range n.Args {
+		if n.isKeywordArg(i) {
+			continue
+		}
+		node, _ := arg.Accept(v)
+		n.Args[i] = node.(ExprNode)
+	}
+}
+
+// normalizedList is the placeholder of a collapsed IN list.
+type normalizedList struct {
+	exprNode
+}
+
+// Accept implements Node Accept interface.
+func (n *normalizedList) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// Restore implements RestoreNode interface.
+func (n *normalizedList) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "...")
+	return errors.Trace(err)
+}
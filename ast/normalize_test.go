@@ -0,0 +1,72 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	. "github.com/pingcap/check"
+	. "github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/parser"
+)
+
+var _ = Suite(&testNormalizeSuite{})
+
+type testNormalizeSuite struct {
+}
+
+func (ts *testNormalizeSuite) TestNormalize(c *C) {
+	cases := []struct {
+		sql        string
+		normalized string
+	}{
+		{"SELECT * FROM t WHERE id = 42 AND name = 'x'", "SELECT * FROM `t` WHERE `id` = ? AND `name` = ?"},
+		{"select *   from t\n where id=43 and name =  \"y\"", "SELECT * FROM `t` WHERE `id` = ? AND `name` = ?"},
+		{"select a from t where a in (1, 2, 3)", "SELECT `a` FROM `t` WHERE `a` IN (...)"},
+		{"select a from t where a not in (4)", "SELECT `a` FROM `t` WHERE `a` NOT IN (...)"},
+		{"select a from t where a in (b, 1)", "SELECT `a` FROM `t` WHERE `a` IN (`b`, ?)"},
+		{"select a from t where a in (select 1)", "SELECT `a` FROM `t` WHERE `a` IN (SELECT ?)"},
+		{"select a + 1 from t where b is null limit 10", "SELECT `a` + ? FROM `t` WHERE `b` IS NULL LIMIT ?"},
+		{"set @a = 'z', @@session.autocommit = ?", "SET @a = ?, @@SESSION.autocommit = ?"},
+		{"select cast(a as char(10)), date_add(a, interval 1 day), trim(leading 'x' from a) from t",
+			"SELECT CAST(`a` AS CHAR(10)), date_add(`a`, INTERVAL ? DAY), trim(LEADING ? FROM `a`) FROM `t`"},
+		{"select extract(year from a), trim(trailing from a), convert(a using utf8)", "SELECT extract(YEAR FROM `a`), trim(TRAILING FROM `a`), convert(`a` USING utf8)"},
+		{"insert into t (a, b) values (1, 'x'), (2, 'y') on duplicate key update b = 'z'",
+			"INSERT INTO `t` (`a`, `b`) VALUES (?, ?), (?, ?) ON DUPLICATE KEY UPDATE `b` = ?"},
+		{"replace into t set a = 1", "REPLACE INTO `t` SET `a` = ?"},
+		{"insert into t select a from s where b = 1", "INSERT INTO `t` SELECT `a` FROM `s` WHERE `b` = ?"},
+		{"update t set a = 'z' where b = ?", "UPDATE `t` SET `a` = ? WHERE `b` = ?"},
+		{"delete from t where a in (1, 2) limit 10", "DELETE FROM `t` WHERE `a` IN (...) LIMIT ?"},
+		{"delete t1 from t1 join t2 on t1.a = t2.a where t2.b = 1", "DELETE `t1` FROM `t1` JOIN `t2` ON `t1`.`a` = `t2`.`a` WHERE `t2`.`b` = ?"},
+		{"load data local infile '/tmp/t.csv' into table t (a, b)",
+			"LOAD DATA LOCAL INFILE '/tmp/t.csv' INTO TABLE `t` FIELDS TERMINATED BY '\t' ESCAPED BY '\\\\' LINES TERMINATED BY '\n' (`a`, `b`)"},
+		{"import into t from '/tmp/t.csv' with thread = 8", "IMPORT INTO `t` FROM '/tmp/t.csv' WITH thread = 8"},
+		// Statements which can not be restored have no fingerprint.
+		{"create table t (a int)", ""},
+	}
+	p := parser.New()
+	for _, ca := range cases {
+		stmt, err := p.ParseOneStmt(ca.sql, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", ca.sql))
+		c.Assert(Normalize(stmt), Equals, ca.normalized, Commentf("source %s", ca.sql))
+	}
+
+	// The statement is not modified.
+	sql := "select a from t where a in (1, 2)"
+	stmt, err := p.ParseOneStmt(sql, "", "")
+	c.Assert(err, IsNil)
+	c.Assert(Normalize(stmt), Equals, "SELECT `a` FROM `t` WHERE `a` IN (...)")
+	c.Assert(restoreSQL(c, sql), Equals, "SELECT `a` FROM `t` WHERE `a` IN (1, 2)")
+	list := stmt.(*SelectStmt).Where.(*PatternInExpr).List
+	c.Assert(list, HasLen, 2)
+	c.Assert(list[0].GetValue(), Equals, int64(1))
+}
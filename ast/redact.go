@@ -0,0 +1,75 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/juju/errors"
+)
+
+// RedactMarker is the text written in place of a redacted literal.
+const RedactMarker = "***"
+
+// Redact returns the restored SQL text of stmt with every literal except NULL and every
+// password replaced by RedactMarker, so it can be logged without leaking user data. Unlike Normalize,
+// the structure is kept as it is, `a IN (1, 2)` becomes `a IN (***, ***)`.
+// stmt is not modified. Redact returns an empty string if stmt can not be restored,
+// like DDL statements.
+func Redact(stmt StmtNode) string {
+	redacted, _ := CloneStmt(stmt).Accept(redactor{})
+	var buf bytes.Buffer
+	if err := Restore(&restoreWriter{w: &buf, redact: true}, redacted); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// redactor replaces the literals of the visited tree with redactedExpr.
+type redactor struct{}
+
+// Enter implements Visitor interface.
+func (r redactor) Enter(n Node) (Node, bool) {
+	if x, ok := n.(*FuncCallExpr); ok {
+		acceptExprArgs(x, r)
+		return n, true
+	}
+	return n, false
+}
+
+// Leave implements Visitor interface.
+func (r redactor) Leave(n Node) (Node, bool) {
+	if v, ok := n.(*ValueExpr); ok && !v.IsNull() {
+		return &redactedExpr{}, true
+	}
+	return n, true
+}
+
+// redactedExpr is the placeholder of a redacted literal.
+type redactedExpr struct {
+	exprNode
+}
+
+// Accept implements Node Accept interface.
+func (n *redactedExpr) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// Restore implements RestoreNode interface.
+func (n *redactedExpr) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, RedactMarker)
+	return errors.Trace(err)
+}
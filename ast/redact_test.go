@@ -0,0 +1,70 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	. "github.com/pingcap/check"
+	. "github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/parser"
+)
+
+var _ = Suite(&testRedactSuite{})
+
+type testRedactSuite struct {
+}
+
+func (ts *testRedactSuite) TestRedact(c *C) {
+	cases := []struct {
+		sql      string
+		redacted string
+	}{
+		{"SELECT * FROM t WHERE id = 42 AND name = 'secret'", "SELECT * FROM `t` WHERE `id` = *** AND `name` = ***"},
+		{"select a from t where a in (1, 'x', b)", "SELECT `a` FROM `t` WHERE `a` IN (***, ***, `b`)"},
+		{"select a + 1.5, now() from t where b is null and c = null", "SELECT `a` + ***, now() FROM `t` WHERE `b` IS NULL AND `c` = NULL"},
+		{"set @a = 'z', @@session.autocommit = ?", "SET @a = ***, @@SESSION.autocommit = ?"},
+		{"select date_add(a, interval 1 day), cast(a as char(4)), trim(both 'x' from a) from t",
+			"SELECT date_add(`a`, INTERVAL *** DAY), CAST(`a` AS CHAR(4)), trim(BOTH *** FROM `a`) FROM `t`"},
+		{"insert into t values ('secret')", "INSERT INTO `t` VALUES (***)"},
+		{"insert into t (a, b) values (1, null) on duplicate key update b = 'secret'",
+			"INSERT INTO `t` (`a`, `b`) VALUES (***, NULL) ON DUPLICATE KEY UPDATE `b` = ***"},
+		{"replace into t set a = 'secret'", "REPLACE INTO `t` SET `a` = ***"},
+		{"update t set a = 'z' where b = ?", "UPDATE `t` SET `a` = *** WHERE `b` = ?"},
+		{"delete from t where name = 'secret' limit 1", "DELETE FROM `t` WHERE `name` = *** LIMIT ***"},
+		{"set password = 'hunter2'", "SET PASSWORD = ***"},
+		{"set password for 'u'@'%' = password('hunter2')", "SET PASSWORD FOR 'u'@'%' = ***"},
+		{"create user 'u'@'%' identified by 'hunter2', 'v'@'%' identified by password 'hash'",
+			"CREATE USER 'u'@'%' IDENTIFIED BY ***, 'v'@'%' IDENTIFIED BY PASSWORD ***"},
+		{"alter user 'u'@'%' identified with 'mysql_native_password' by 'hunter2'",
+			"ALTER USER 'u'@'%' IDENTIFIED WITH 'mysql_native_password' BY ***"},
+		{"grant select (a, b), insert on test.t to 'u'@'%' identified by 'hunter2' with grant option",
+			"GRANT SELECT (`a`, `b`), INSERT ON `test`.`t` TO 'u'@'%' IDENTIFIED BY *** WITH GRANT OPTION"},
+		// Statements which can not be restored are not logged.
+		{"create table t (a int)", ""},
+	}
+	p := parser.New()
+	for _, ca := range cases {
+		stmt, err := p.ParseOneStmt(ca.sql, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", ca.sql))
+		c.Assert(Redact(stmt), Equals, ca.redacted, Commentf("source %s", ca.sql))
+	}
+
+	// The statement is not modified.
+	sql := "select a from t where a in (1, 2)"
+	stmt, err := p.ParseOneStmt(sql, "", "")
+	c.Assert(err, IsNil)
+	c.Assert(Redact(stmt), Equals, "SELECT `a` FROM `t` WHERE `a` IN (***, ***)")
+	list := stmt.(*SelectStmt).Where.(*PatternInExpr).List
+	c.Assert(list, HasLen, 2)
+	c.Assert(list[0].GetValue(), Equals, int64(1))
+}
@@ -0,0 +1,2217 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/tidb/model"
+	"github.com/pingcap/tidb/mysql"
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/util/charset"
+	"github.com/pingcap/tidb/util/types"
+)
+
+var (
+	_ RestoreNode = &AlterUserStmt{}
+	_ RestoreNode = &AnalyzeTableStmt{}
+	_ RestoreNode = &BeginStmt{}
+	_ RestoreNode = &BinlogStmt{}
+	_ RestoreNode = &BRIEStmt{}
+	_ RestoreNode = &ChangeStmt{}
+	_ RestoreNode = &CommitStmt{}
+	_ RestoreNode = &CreateRoleStmt{}
+	_ RestoreNode = &CreateUserStmt{}
+	_ RestoreNode = &DeleteStmt{}
+	_ RestoreNode = &DoStmt{}
+	_ RestoreNode = &EmptyStmt{}
+	_ RestoreNode = &DropRoleStmt{}
+	_ RestoreNode = &DropUserStmt{}
+	_ RestoreNode = &ExplainStmt{}
+	_ RestoreNode = &PlanReplayerStmt{}
+	_ RestoreNode = &GrantStmt{}
+	_ RestoreNode = &GrantRoleStmt{}
+	_ RestoreNode = &KillStmt{}
+	_ RestoreNode = &LockTablesStmt{}
+	_ RestoreNode = &ReleaseSavepointStmt{}
+	_ RestoreNode = &RevokeRoleStmt{}
+	_ RestoreNode = &RollbackStmt{}
+	_ RestoreNode = &SavepointStmt{}
+	_ RestoreNode = &SelectStmt{}
+	_ RestoreNode = &SetNamesStmt{}
+	_ RestoreNode = &SetPwdStmt{}
+	_ RestoreNode = &SetConfigStmt{}
+	_ RestoreNode = &SetRoleStmt{}
+	_ RestoreNode = &SetDefaultRoleStmt{}
+	_ RestoreNode = &SetStmt{}
+	_ RestoreNode = &SetTransactionStmt{}
+	_ RestoreNode = &TraceStmt{}
+	_ RestoreNode = &ShowStmt{}
+	_ RestoreNode = &ShutdownStmt{}
+	_ RestoreNode = &RestartStmt{}
+	_ RestoreNode = &AlterInstanceStmt{}
+	_ RestoreNode = &HelpStmt{}
+	_ RestoreNode = &ImportIntoStmt{}
+	_ RestoreNode = &InsertStmt{}
+	_ RestoreNode = &LoadDataStmt{}
+	_ RestoreNode = &LoadStatsStmt{}
+	_ RestoreNode = &DropStatsStmt{}
+	_ RestoreNode = &CreateBindingStmt{}
+	_ RestoreNode = &CalibrateResourceStmt{}
+	_ RestoreNode = &AddQueryWatchStmt{}
+	_ RestoreNode = &DropQueryWatchStmt{}
+	_ RestoreNode = &DropBindingStmt{}
+	_ RestoreNode = &SplitRegionStmt{}
+	_ RestoreNode = &UnionStmt{}
+	_ RestoreNode = &UnlockTablesStmt{}
+	_ RestoreNode = &UpdateStmt{}
+	_ RestoreNode = &UseStmt{}
+	_ RestoreNode = &VariableAssignment{}
+)
+
+// Restore writes the SQL text of n to w.
+// It returns an error if n doesn't implement RestoreNode.
+func Restore(w io.Writer, n Node) error {
+	rn, ok := n.(RestoreNode)
+	if !ok {
+		return errors.Errorf("restore %T is not supported", n)
+	}
+	return errors.Trace(rn.Restore(w))
+}
+
+// restoreWriter wraps an io.Writer and keeps the first error,
+// so the restore code doesn't need to check the result of every write.
+type restoreWriter struct {
+	w   io.Writer
+	err error
+	// redact writes RedactMarker in place of the passwords, see Redact.
+	redact bool
+}
+
+func newRestoreWriter(w io.Writer) *restoreWriter {
+	if rw, ok := w.(*restoreWriter); ok {
+		return rw
+	}
+	return &restoreWriter{w: w}
+}
+
+// Write implements io.Writer interface.
+func (rw *restoreWriter) Write(p []byte) (int, error) {
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	var n int
+	n, rw.err = rw.w.Write(p)
+	return n, rw.err
+}
+
+func (rw *restoreWriter) writeString(s string) {
+	io.WriteString(rw, s)
+}
+
+// writeName writes an identifier quoted by backticks.
+func (rw *restoreWriter) writeName(name string) {
+	rw.writeString(quoteName(name))
+}
+
+// writeQuoted writes a string literal quoted by single quotes.
+func (rw *restoreWriter) writeQuoted(s string) {
+	rw.writeString(quoteString(s))
+}
+
+// writeNode restores n to the underlying writer.
+func (rw *restoreWriter) writeNode(n Node) {
+	if rw.err != nil {
+		return
+	}
+	if err := Restore(rw, n); err != nil && rw.err == nil {
+		rw.err = err
+	}
+}
+
+// writeExprs restores exprs separated by comma.
+func (rw *restoreWriter) writeExprs(exprs []ExprNode) {
+	for i, expr := range exprs {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(expr)
+	}
+}
+
+// writePassword writes a password or a password hash quoted by single quotes.
+func (rw *restoreWriter) writePassword(s string) {
+	if rw.redact {
+		rw.writeString(RedactMarker)
+		return
+	}
+	rw.writeQuoted(s)
+}
+
+func quoteName(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `''`)
+
+func quoteString(s string) string {
+	return "'" + stringEscaper.Replace(s) + "'"
+}
+
+// writeUsers writes users separated by comma.
+func (rw *restoreWriter) writeUsers(users []*UserIdentity) {
+	for i, user := range users {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeString(user.String())
+	}
+}
+
+// writeRoles writes roles separated by comma.
+func (rw *restoreWriter) writeRoles(roles []*RoleIdentity) {
+	for i, role := range roles {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeString(role.String())
+	}
+}
+
+// writeUserSpecs writes the user specifications of CREATE USER and ALTER USER.
+func (rw *restoreWriter) writeUserSpecs(specs []*UserSpec) {
+	for i, spec := range specs {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeString(spec.User.String())
+		rw.writeAuthOption(spec.AuthOpt)
+	}
+}
+
+// writeAuthOption writes the IDENTIFIED clause, nothing is written if opt is nil.
+func (rw *restoreWriter) writeAuthOption(opt *AuthOption) {
+	if opt == nil {
+		return
+	}
+	rw.writeString(" IDENTIFIED")
+	if opt.AuthPlugin != "" {
+		rw.writeString(" WITH " + quoteString(opt.AuthPlugin))
+		if opt.ByAuthString {
+			rw.writeString(" BY ")
+			rw.writePassword(opt.AuthString)
+		} else if opt.HashString != "" {
+			rw.writeString(" AS ")
+			rw.writePassword(opt.HashString)
+		}
+		return
+	}
+	if opt.ByAuthString {
+		rw.writeString(" BY ")
+		rw.writePassword(opt.AuthString)
+	} else {
+		rw.writeString(" BY PASSWORD ")
+		rw.writePassword(opt.HashString)
+	}
+}
+
+func writeTableName(rw *restoreWriter, schema, name model.CIStr) {
+	if schema.O != "" {
+		rw.writeName(schema.O)
+		rw.writeString(".")
+	}
+	rw.writeName(name.O)
+}
+
+// Restore implements RestoreNode interface.
+func (n *BeginStmt) Restore(w io.Writer) error {
+	text := "START TRANSACTION"
+	if n.ReadOnly {
+		text += " READ ONLY"
+		if n.WithConsistentSnapshot {
+			text += ","
+		}
+	}
+	if n.WithConsistentSnapshot {
+		text += " WITH CONSISTENT SNAPSHOT"
+	}
+	_, err := io.WriteString(w, text)
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *CommitStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "COMMIT"+completionText(n.Chain, n.Release))
+	return errors.Trace(err)
+}
+
+// completionText returns the AND [NO] CHAIN and [NO] RELEASE options of COMMIT and ROLLBACK.
+func completionText(chain, release CompletionType) string {
+	var text string
+	switch chain {
+	case CompletionTypeYes:
+		text += " AND CHAIN"
+	case CompletionTypeNo:
+		text += " AND NO CHAIN"
+	}
+	switch release {
+	case CompletionTypeYes:
+		text += " RELEASE"
+	case CompletionTypeNo:
+		text += " NO RELEASE"
+	}
+	return text
+}
+
+// Restore implements RestoreNode interface.
+func (n *EmptyStmt) Restore(w io.Writer) error {
+	return nil
+}
+
+// Restore implements RestoreNode interface.
+func (n *DoStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("DO ")
+	rw.writeExprs(n.Exprs)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *RollbackStmt) Restore(w io.Writer) error {
+	text := "ROLLBACK"
+	if n.SavepointName != "" {
+		text += " TO SAVEPOINT " + quoteName(n.SavepointName)
+	} else {
+		text += completionText(n.Chain, n.Release)
+	}
+	_, err := io.WriteString(w, text)
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *KillStmt) Restore(w io.Writer) error {
+	text := "KILL "
+	if n.TiDBExtension {
+		text += "TIDB "
+	}
+	if n.Query {
+		text += "QUERY "
+	}
+	_, err := io.WriteString(w, text+strconv.FormatUint(n.ConnectionID, 10))
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ChangeStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("CHANGE " + n.NodeType + " TO NODE_STATE = ")
+	rw.writeQuoted(n.State)
+	rw.writeString(" FOR NODE_ID ")
+	rw.writeQuoted(n.NodeID)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *LockTablesStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("LOCK TABLES ")
+	for i, lock := range n.TableLocks {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(lock.Table)
+		rw.writeString(" " + lock.Type.String())
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *UnlockTablesStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "UNLOCK TABLES")
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *AnalyzeTableStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("ANALYZE TABLE ")
+	for i, table := range n.TableNames {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(table)
+	}
+	if n.IndexFlag {
+		rw.writeString(" INDEX")
+		for i, name := range n.IndexNames {
+			if i > 0 {
+				rw.writeString(",")
+			}
+			rw.writeString(" ")
+			rw.writeName(name.O)
+		}
+	}
+	if n.MaxNumBuckets != 0 {
+		rw.writeString(" WITH " + strconv.FormatUint(n.MaxNumBuckets, 10) + " BUCKETS")
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *BinlogStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "BINLOG "+quoteString(n.Str))
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ShutdownStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "SHUTDOWN")
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *AlterInstanceStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("ALTER INSTANCE")
+	if n.ReloadTLS {
+		rw.writeString(" RELOAD TLS")
+		if n.NoRollbackOnError {
+			rw.writeString(" NO ROLLBACK ON ERROR")
+		}
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *RestartStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "RESTART")
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *HelpStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "HELP "+quoteString(n.Topic))
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *LoadStatsStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "LOAD STATS "+quoteString(n.Path))
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *DropStatsStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("DROP STATS ")
+	for i, tn := range n.Tables {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(tn)
+	}
+	for i, name := range n.PartitionNames {
+		if i == 0 {
+			rw.writeString(" PARTITION ")
+		} else {
+			rw.writeString(", ")
+		}
+		rw.writeName(name)
+	}
+	if n.IsGlobalStats {
+		rw.writeString(" GLOBAL")
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *BRIEStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString(n.Kind.String())
+	switch {
+	case len(n.Tables) > 0:
+		rw.writeString(" TABLE ")
+		for i, tn := range n.Tables {
+			if i > 0 {
+				rw.writeString(", ")
+			}
+			rw.writeNode(tn)
+		}
+	case len(n.Schemas) > 0:
+		rw.writeString(" DATABASE ")
+		for i, schema := range n.Schemas {
+			if i > 0 {
+				rw.writeString(", ")
+			}
+			rw.writeName(schema)
+		}
+	default:
+		rw.writeString(" DATABASE *")
+	}
+	if n.Kind == BRIEKindBackup {
+		rw.writeString(" TO ")
+	} else {
+		rw.writeString(" FROM ")
+	}
+	rw.writeQuoted(n.Storage)
+	for i, opt := range n.Options {
+		if i == 0 {
+			rw.writeString(" WITH ")
+		} else {
+			rw.writeString(", ")
+		}
+		rw.writeString(opt.Name)
+		rw.writeString(" = ")
+		rw.writeNode(opt.Value)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *CalibrateResourceStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("CALIBRATE RESOURCE")
+	if n.WorkloadType != "" {
+		rw.writeString(" WORKLOAD " + n.WorkloadType)
+	}
+	for _, opt := range n.DynamicCalibrateResourceOptionList {
+		rw.writeString(" " + opt.Tp.String() + " ")
+		if opt.Tp == CalibrateDuration {
+			rw.writeQuoted(opt.StrValue)
+		} else {
+			rw.writeNode(opt.Ts)
+		}
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *AddQueryWatchStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("QUERY WATCH ADD")
+	for _, opt := range n.QueryWatchOptionList {
+		switch opt.Tp {
+		case QueryWatchResourceGroup:
+			rw.writeString(" RESOURCE GROUP ")
+			rw.writeName(opt.ResourceGroupName.O)
+		case QueryWatchAction:
+			rw.writeString(" ACTION " + opt.Action.String())
+		case QueryWatchType:
+			if opt.TypeSpecified {
+				rw.writeString(" SQL TEXT " + opt.WatchType.String() + " TO ")
+			} else if opt.WatchType == WatchPlan {
+				rw.writeString(" PLAN DIGEST ")
+			} else {
+				rw.writeString(" SQL DIGEST ")
+			}
+			rw.writeQuoted(opt.Pattern)
+		}
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *DropQueryWatchStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "QUERY WATCH REMOVE "+strconv.FormatInt(n.IntValue, 10))
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *CreateBindingStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("CREATE " + bindingScopeText(n.GlobalScope) + " BINDING FOR ")
+	rw.writeNode(n.OriginSel)
+	rw.writeString(" USING ")
+	rw.writeNode(n.HintedSel)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *DropBindingStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("DROP " + bindingScopeText(n.GlobalScope) + " BINDING FOR ")
+	rw.writeNode(n.OriginSel)
+	return errors.Trace(rw.err)
+}
+
+func bindingScopeText(global bool) string {
+	if global {
+		return "GLOBAL"
+	}
+	return "SESSION"
+}
+
+// Restore implements RestoreNode interface.
+func (n *SplitRegionStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("SPLIT TABLE ")
+	rw.writeNode(n.Table)
+	if n.IndexName != "" {
+		rw.writeString(" INDEX ")
+		rw.writeName(n.IndexName)
+	}
+	if len(n.SplitOpt.ValueLists) > 0 {
+		rw.writeString(" BY ")
+		for i, list := range n.SplitOpt.ValueLists {
+			if i > 0 {
+				rw.writeString(", ")
+			}
+			rw.writeString("(")
+			rw.writeExprs(list)
+			rw.writeString(")")
+		}
+	} else {
+		rw.writeString(" BETWEEN (")
+		rw.writeExprs(n.SplitOpt.Lower)
+		rw.writeString(") AND (")
+		rw.writeExprs(n.SplitOpt.Upper)
+		rw.writeString(") REGIONS " + strconv.FormatInt(n.SplitOpt.Num, 10))
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *CreateUserStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("CREATE USER ")
+	if n.IfNotExists {
+		rw.writeString("IF NOT EXISTS ")
+	}
+	rw.writeUserSpecs(n.Specs)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *AlterUserStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("ALTER USER ")
+	if n.IfExists {
+		rw.writeString("IF EXISTS ")
+	}
+	if n.CurrentAuth != nil {
+		rw.writeString("USER()")
+		rw.writeAuthOption(n.CurrentAuth)
+	} else {
+		rw.writeUserSpecs(n.Specs)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *DropUserStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("DROP USER ")
+	if n.IfExists {
+		rw.writeString("IF EXISTS ")
+	}
+	rw.writeUsers(n.UserList)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *CreateRoleStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("CREATE ROLE ")
+	if n.IfNotExists {
+		rw.writeString("IF NOT EXISTS ")
+	}
+	rw.writeRoles(n.RoleList)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *DropRoleStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("DROP ROLE ")
+	if n.IfExists {
+		rw.writeString("IF EXISTS ")
+	}
+	rw.writeRoles(n.RoleList)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *GrantStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("GRANT ")
+	for i, priv := range n.Privs {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(priv)
+	}
+	rw.writeString(" ON ")
+	if n.ObjectType == ObjectTypeTable {
+		rw.writeString("TABLE ")
+	}
+	restoreGrantLevel(rw, n.Level)
+	rw.writeString(" TO ")
+	rw.writeUserSpecs(n.Users)
+	if n.WithGrant {
+		rw.writeString(" WITH GRANT OPTION")
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *PrivElem) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	if n.Priv == mysql.AllPriv {
+		rw.writeString("ALL PRIVILEGES")
+	} else if name, ok := mysql.Priv2Str[n.Priv]; ok {
+		rw.writeString(strings.ToUpper(name))
+	} else {
+		return errors.Errorf("restore privilege %d is not supported", n.Priv)
+	}
+	if len(n.Cols) > 0 {
+		rw.writeString(" (")
+		restoreColumnNames(rw, n.Cols)
+		rw.writeString(")")
+	}
+	return errors.Trace(rw.err)
+}
+
+func restoreGrantLevel(rw *restoreWriter, level *GrantLevel) {
+	switch level.Level {
+	case GrantLevelGlobal:
+		rw.writeString("*.*")
+	case GrantLevelDB:
+		if level.DBName == "" {
+			rw.writeString("*")
+		} else {
+			rw.writeName(level.DBName)
+			rw.writeString(".*")
+		}
+	default:
+		writeTableName(rw, model.NewCIStr(level.DBName), model.NewCIStr(level.TableName))
+	}
+}
+
+// Restore implements RestoreNode interface.
+func (n *GrantRoleStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("GRANT ")
+	rw.writeRoles(n.Roles)
+	rw.writeString(" TO ")
+	rw.writeUsers(n.Users)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *RevokeRoleStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("REVOKE ")
+	rw.writeRoles(n.Roles)
+	rw.writeString(" FROM ")
+	rw.writeUsers(n.Users)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SetNamesStmt) Restore(w io.Writer) error {
+	if n.IsDefault {
+		_, err := io.WriteString(w, "SET NAMES DEFAULT")
+		return errors.Trace(err)
+	}
+	text := "SET NAMES " + quoteString(n.Charset)
+	if n.Collate != "" {
+		text += " COLLATE " + quoteString(n.Collate)
+	}
+	_, err := io.WriteString(w, text)
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SetPwdStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("SET PASSWORD ")
+	if n.User != nil {
+		rw.writeString("FOR " + n.User.String() + " ")
+	}
+	rw.writeString("= ")
+	rw.writePassword(n.Password)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SetConfigStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("SET CONFIG ")
+	if n.Type != "" {
+		rw.writeString(n.Type)
+	} else {
+		rw.writeString(quoteString(n.Instance))
+	}
+	rw.writeString(" " + n.Name + " = ")
+	rw.writeNode(n.Value)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SetRoleStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("SET ROLE ")
+	switch n.SetRoleOpt {
+	case SetRoleDefault:
+		rw.writeString("DEFAULT")
+	case SetRoleNone:
+		rw.writeString("NONE")
+	case SetRoleAll:
+		rw.writeString("ALL")
+	case SetRoleAllExcept:
+		rw.writeString("ALL EXCEPT ")
+	}
+	if n.SetRoleOpt == SetRoleAllExcept || n.SetRoleOpt == SetRoleRegular {
+		rw.writeRoles(n.RoleList)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SetDefaultRoleStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("SET DEFAULT ROLE ")
+	switch n.SetRoleOpt {
+	case SetRoleNone:
+		rw.writeString("NONE")
+	case SetRoleAll:
+		rw.writeString("ALL")
+	default:
+		rw.writeRoles(n.RoleList)
+	}
+	rw.writeString(" TO ")
+	rw.writeUsers(n.UserList)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SavepointStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "SAVEPOINT "+quoteName(n.Name))
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ReleaseSavepointStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "RELEASE SAVEPOINT "+quoteName(n.Name))
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *UseStmt) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, "USE "+quoteName(n.DBName))
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *PlanReplayerStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	if n.Load {
+		rw.writeString("PLAN REPLAYER LOAD ")
+		rw.writeQuoted(n.File)
+		return errors.Trace(rw.err)
+	}
+	rw.writeString("PLAN REPLAYER DUMP EXPLAIN ")
+	if n.Analyze {
+		rw.writeString("ANALYZE ")
+	}
+	rw.writeNode(n.Stmt)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ExplainStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("EXPLAIN ")
+	if n.Analyze {
+		rw.writeString("ANALYZE ")
+	}
+	if n.Format != "" && n.Format != ExplainFormatROW {
+		rw.writeString("FORMAT = ")
+		rw.writeQuoted(n.Format)
+		rw.writeString(" ")
+	}
+	if show, ok := n.Stmt.(*ShowStmt); ok && show.Tp == ShowColumns {
+		// `EXPLAIN t [col]` is parsed as a SHOW COLUMNS statement.
+		rw.writeNode(show.Table)
+		if show.Column != nil {
+			rw.writeString(" ")
+			rw.writeNode(show.Column)
+		}
+		return errors.Trace(rw.err)
+	}
+	rw.writeNode(n.Stmt)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *TraceStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("TRACE ")
+	if n.Format != "" && n.Format != TraceFormatRow {
+		rw.writeString("FORMAT = ")
+		rw.writeQuoted(n.Format)
+		rw.writeString(" ")
+	}
+	rw.writeNode(n.Stmt)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SetStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("SET ")
+	for i, v := range n.Variables {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(v)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SetTransactionStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	switch n.Scope {
+	case TransactionScopeGlobal:
+		rw.writeString("SET GLOBAL TRANSACTION")
+	case TransactionScopeSession:
+		rw.writeString("SET SESSION TRANSACTION")
+	default:
+		rw.writeString("SET TRANSACTION")
+	}
+	sep := " "
+	if n.IsolationLevel != "" {
+		rw.writeString(" ISOLATION LEVEL " + strings.Replace(n.IsolationLevel, "-", " ", -1))
+		sep = ", "
+	}
+	if n.AccessMode != "" {
+		rw.writeString(sep + n.AccessMode)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *VariableAssignment) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	if n.Name == SetNames {
+		if n.IsDefault {
+			rw.writeString("NAMES DEFAULT")
+			return errors.Trace(rw.err)
+		}
+		rw.writeString("NAMES ")
+		rw.writeNode(n.Value)
+		if n.ExtendValue != nil {
+			rw.writeString(" COLLATE ")
+			rw.writeNode(n.ExtendValue)
+		}
+		return errors.Trace(rw.err)
+	}
+	if n.IsSystem {
+		scope := "SESSION"
+		if n.IsGlobal {
+			scope = "GLOBAL"
+		} else if n.ImplicitScope {
+			scope = ""
+		}
+		switch {
+		case n.Syntax == SysVarSyntaxKeyword && scope != "":
+			rw.writeString(scope + " " + n.Name)
+		case n.Syntax == SysVarSyntaxKeyword:
+			rw.writeString(n.Name)
+		case scope != "":
+			rw.writeString("@@" + scope + "." + n.Name)
+		default:
+			rw.writeString("@@" + n.Name)
+		}
+	} else {
+		rw.writeString("@" + n.Name)
+	}
+	rw.writeString(" = ")
+	if n.IsDefault {
+		rw.writeString("DEFAULT")
+	} else {
+		rw.writeNode(n.Value)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ShowStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("SHOW ")
+	switch n.Tp {
+	case ShowEngines:
+		rw.writeString("ENGINES")
+	case ShowPlugins:
+		rw.writeString("PLUGINS")
+	case ShowPrivileges:
+		rw.writeString("PRIVILEGES")
+	case ShowProfiles:
+		rw.writeString("PROFILES")
+	case ShowProfile:
+		rw.writeString("PROFILE")
+		for i, tp := range n.ProfileTypes {
+			if i > 0 {
+				rw.writeString(",")
+			}
+			rw.writeString(" " + tp.String())
+		}
+		if n.ProfileQueryID != nil {
+			rw.writeString(" FOR QUERY " + strconv.FormatUint(*n.ProfileQueryID, 10))
+		}
+	case ShowDatabases:
+		rw.writeString("DATABASES")
+	case ShowTables:
+		if n.Full {
+			rw.writeString("FULL ")
+		}
+		rw.writeString("TABLES")
+		n.restoreDBName(rw)
+	case ShowTableStatus:
+		rw.writeString("TABLE STATUS")
+		n.restoreDBName(rw)
+	case ShowOpenTables:
+		rw.writeString("OPEN TABLES")
+		n.restoreDBName(rw)
+	case ShowColumns:
+		if n.Extended {
+			rw.writeString("EXTENDED ")
+		}
+		if n.Full {
+			rw.writeString("FULL ")
+		}
+		rw.writeString("COLUMNS FROM ")
+		rw.writeNode(n.Table)
+		n.restoreDBName(rw)
+	case ShowWarnings, ShowErrors:
+		if n.CountOnly {
+			rw.writeString("COUNT(*) ")
+		}
+		if n.Tp == ShowWarnings {
+			rw.writeString("WARNINGS")
+		} else {
+			rw.writeString("ERRORS")
+		}
+	case ShowCharset:
+		rw.writeString("CHARACTER SET")
+	case ShowVariables, ShowStatus, ShowBindings:
+		switch n.Scope {
+		case ShowScopeGlobal:
+			rw.writeString("GLOBAL ")
+		case ShowScopeSession:
+			rw.writeString("SESSION ")
+		}
+		switch n.Tp {
+		case ShowVariables:
+			rw.writeString("VARIABLES")
+		case ShowStatus:
+			rw.writeString("STATUS")
+		default:
+			rw.writeString("BINDINGS")
+		}
+	case ShowCollation:
+		rw.writeString("COLLATION")
+	case ShowStatsMeta:
+		rw.writeString("STATS_META")
+	case ShowStatsHistograms:
+		rw.writeString("STATS_HISTOGRAMS")
+	case ShowStatsBuckets:
+		rw.writeString("STATS_BUCKETS")
+	case ShowStatsHealthy:
+		rw.writeString("STATS_HEALTHY")
+	case ShowBuiltins:
+		rw.writeString("BUILTINS")
+	case ShowCreateTable:
+		rw.writeString("CREATE TABLE ")
+		rw.writeNode(n.Table)
+	case ShowCreateDatabase:
+		rw.writeString("CREATE DATABASE ")
+		rw.writeName(n.DBName)
+	case ShowGrants:
+		rw.writeString("GRANTS")
+		if n.User != nil {
+			rw.writeString(" FOR " + n.User.String())
+		}
+		if len(n.Roles) > 0 {
+			rw.writeString(" USING ")
+			rw.writeRoles(n.Roles)
+		}
+	case ShowTriggers:
+		rw.writeString("TRIGGERS")
+		n.restoreDBName(rw)
+	case ShowProcedureStatus:
+		rw.writeString("PROCEDURE STATUS")
+	case ShowIndex:
+		if n.Extended {
+			rw.writeString("EXTENDED ")
+		}
+		rw.writeString("INDEX FROM ")
+		rw.writeNode(n.Table)
+	case ShowProcessList:
+		if n.Full {
+			rw.writeString("FULL ")
+		}
+		rw.writeString("PROCESSLIST")
+	case ShowEvents:
+		rw.writeString("EVENTS")
+		n.restoreDBName(rw)
+	default:
+		return errors.Errorf("restore show type %d is not supported", n.Tp)
+	}
+	if n.Pattern != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.Pattern)
+	} else if n.Where != nil {
+		rw.writeString(" WHERE ")
+		rw.writeNode(n.Where)
+	}
+	if n.OrderBy != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.OrderBy)
+	}
+	if n.Limit != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.Limit)
+	}
+	return errors.Trace(rw.err)
+}
+
+func (n *ShowStmt) restoreDBName(rw *restoreWriter) {
+	if n.DBName != "" {
+		rw.writeString(" FROM ")
+		rw.writeName(n.DBName)
+	}
+}
+
+// Restore implements RestoreNode interface.
+func (n *SelectStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("SELECT ")
+	restoreTableHints(rw, n.TableHints)
+	if n.Distinct {
+		rw.writeString("DISTINCT ")
+	}
+	rw.writeNode(n.Fields)
+	if n.From != nil {
+		rw.writeString(" FROM ")
+		rw.writeNode(n.From)
+	}
+	if n.Where != nil {
+		rw.writeString(" WHERE ")
+		rw.writeNode(n.Where)
+	}
+	if n.GroupBy != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.GroupBy)
+	}
+	if n.Having != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.Having)
+	}
+	if n.OrderBy != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.OrderBy)
+	}
+	if n.Limit != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.Limit)
+	}
+	switch n.LockTp {
+	case SelectLockForUpdate:
+		rw.writeString(" FOR UPDATE")
+	case SelectLockInShareMode:
+		rw.writeString(" LOCK IN SHARE MODE")
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *UnionStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	for i, sel := range n.SelectList.Selects {
+		if i > 0 {
+			if n.Distinct {
+				rw.writeString(" UNION ")
+			} else {
+				rw.writeString(" UNION ALL ")
+			}
+		}
+		rw.writeString("(")
+		rw.writeNode(sel)
+		rw.writeString(")")
+	}
+	if n.OrderBy != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.OrderBy)
+	}
+	if n.Limit != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.Limit)
+	}
+	return errors.Trace(rw.err)
+}
+
+// restoreTableHints writes the optimizer hints comment followed by a space,
+// nothing is written if there is no hint.
+func restoreTableHints(rw *restoreWriter, hints []*TableOptimizerHint) {
+	if len(hints) == 0 {
+		return
+	}
+	rw.writeString("/*+ ")
+	for i, hint := range hints {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(hint)
+	}
+	rw.writeString(" */ ")
+}
+
+var priorityNames = map[int]string{
+	LowPriority:     "LOW_PRIORITY ",
+	HighPriority:    "HIGH_PRIORITY ",
+	DelayedPriority: "DELAYED ",
+}
+
+// Restore implements RestoreNode interface.
+func (n *InsertStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	if n.IsReplace {
+		rw.writeString("REPLACE ")
+	} else {
+		rw.writeString("INSERT ")
+	}
+	rw.writeString(priorityNames[n.Priority])
+	if n.Ignore {
+		rw.writeString("IGNORE ")
+	}
+	rw.writeString("INTO ")
+	rw.writeNode(n.Table)
+	if len(n.Columns) > 0 {
+		rw.writeString(" (")
+		restoreColumnNames(rw, n.Columns)
+		rw.writeString(")")
+	}
+	switch {
+	case n.Select != nil:
+		rw.writeString(" ")
+		rw.writeNode(n.Select)
+	case len(n.Setlist) > 0:
+		rw.writeString(" SET ")
+		restoreAssignments(rw, n.Setlist)
+	default:
+		rw.writeString(" VALUES ")
+		for i, list := range n.Lists {
+			if i > 0 {
+				rw.writeString(", ")
+			}
+			rw.writeString("(")
+			rw.writeExprs(list)
+			rw.writeString(")")
+		}
+	}
+	if len(n.OnDuplicate) > 0 {
+		rw.writeString(" ON DUPLICATE KEY UPDATE ")
+		restoreAssignments(rw, n.OnDuplicate)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *UpdateStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("UPDATE ")
+	restoreTableHints(rw, n.TableHints)
+	if n.LowPriority {
+		rw.writeString("LOW_PRIORITY ")
+	}
+	if n.Ignore {
+		rw.writeString("IGNORE ")
+	}
+	rw.writeNode(n.TableRefs)
+	rw.writeString(" SET ")
+	restoreAssignments(rw, n.List)
+	restoreWhereOrderLimit(rw, n.Where, n.Order, n.Limit)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *DeleteStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("DELETE ")
+	restoreTableHints(rw, n.TableHints)
+	if n.LowPriority {
+		rw.writeString("LOW_PRIORITY ")
+	}
+	if n.Quick {
+		rw.writeString("QUICK ")
+	}
+	if n.Ignore {
+		rw.writeString("IGNORE ")
+	}
+	switch {
+	case !n.IsMultiTable:
+		rw.writeString("FROM ")
+		rw.writeNode(n.TableRefs)
+	case n.BeforeFrom:
+		restoreTableNames(rw, n.Tables.Tables)
+		rw.writeString(" FROM ")
+		rw.writeNode(n.TableRefs)
+	default:
+		rw.writeString("FROM ")
+		restoreTableNames(rw, n.Tables.Tables)
+		rw.writeString(" USING ")
+		rw.writeNode(n.TableRefs)
+	}
+	restoreWhereOrderLimit(rw, n.Where, n.Order, n.Limit)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *LoadDataStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("LOAD DATA ")
+	if n.IsLocal {
+		rw.writeString("LOCAL ")
+	}
+	rw.writeString("INFILE ")
+	rw.writeQuoted(n.Path)
+	rw.writeString(" INTO TABLE ")
+	rw.writeNode(n.Table)
+	if n.FieldsInfo != nil {
+		rw.writeString(" FIELDS TERMINATED BY ")
+		rw.writeQuoted(n.FieldsInfo.Terminated)
+		if n.FieldsInfo.Enclosed != 0 {
+			rw.writeString(" ENCLOSED BY ")
+			rw.writeQuoted(string(n.FieldsInfo.Enclosed))
+		}
+		if n.FieldsInfo.Escaped != 0 {
+			rw.writeString(" ESCAPED BY ")
+			rw.writeQuoted(string(n.FieldsInfo.Escaped))
+		}
+	}
+	if n.LinesInfo != nil {
+		rw.writeString(" LINES")
+		if n.LinesInfo.Starting != "" {
+			rw.writeString(" STARTING BY ")
+			rw.writeQuoted(n.LinesInfo.Starting)
+		}
+		rw.writeString(" TERMINATED BY ")
+		rw.writeQuoted(n.LinesInfo.Terminated)
+	}
+	if len(n.Columns) > 0 {
+		rw.writeString(" (")
+		restoreColumnNames(rw, n.Columns)
+		rw.writeString(")")
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ImportIntoStmt) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("IMPORT INTO ")
+	rw.writeNode(n.Table)
+	if len(n.ColumnsAndUserVars) > 0 {
+		rw.writeString(" (")
+		for i, item := range n.ColumnsAndUserVars {
+			if i > 0 {
+				rw.writeString(", ")
+			}
+			if item.ColumnName != nil {
+				rw.writeNode(item.ColumnName)
+			} else {
+				rw.writeNode(item.UserVar)
+			}
+		}
+		rw.writeString(")")
+	}
+	rw.writeString(" FROM ")
+	rw.writeQuoted(n.Path)
+	if n.Format != "" {
+		rw.writeString(" FORMAT ")
+		rw.writeQuoted(n.Format)
+	}
+	for i, opt := range n.Options {
+		if i == 0 {
+			rw.writeString(" WITH ")
+		} else {
+			rw.writeString(", ")
+		}
+		rw.writeString(opt.Name)
+		if opt.Value != nil {
+			rw.writeString(" = ")
+			rw.writeNode(opt.Value)
+		}
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *Assignment) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeNode(n.Column)
+	rw.writeString(" = ")
+	rw.writeNode(n.Expr)
+	return errors.Trace(rw.err)
+}
+
+func restoreAssignments(rw *restoreWriter, list []*Assignment) {
+	for i, assignment := range list {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(assignment)
+	}
+}
+
+func restoreColumnNames(rw *restoreWriter, columns []*ColumnName) {
+	for i, column := range columns {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(column)
+	}
+}
+
+func restoreTableNames(rw *restoreWriter, tables []*TableName) {
+	for i, table := range tables {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(table)
+	}
+}
+
+// restoreWhereOrderLimit writes the optional WHERE, ORDER BY and LIMIT clauses
+// of UPDATE and DELETE statements.
+func restoreWhereOrderLimit(rw *restoreWriter, where ExprNode, order *OrderByClause, limit *Limit) {
+	if where != nil {
+		rw.writeString(" WHERE ")
+		rw.writeNode(where)
+	}
+	if order != nil {
+		rw.writeString(" ")
+		rw.writeNode(order)
+	}
+	if limit != nil {
+		rw.writeString(" ")
+		rw.writeNode(limit)
+	}
+}
+
+// Restore implements RestoreNode interface.
+func (n *FieldList) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	for i, field := range n.Fields {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(field)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SelectField) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	if n.WildCard != nil {
+		rw.writeNode(n.WildCard)
+		return errors.Trace(rw.err)
+	}
+	rw.writeNode(n.Expr)
+	if n.AsName.O != "" {
+		rw.writeString(" AS ")
+		rw.writeName(n.AsName.O)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *WildCardField) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	if n.Table.O != "" {
+		writeTableName(rw, n.Schema, n.Table)
+		rw.writeString(".")
+	}
+	rw.writeString("*")
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *TableRefsClause) Restore(w io.Writer) error {
+	return errors.Trace(Restore(w, n.TableRefs))
+}
+
+// Restore implements RestoreNode interface.
+func (n *Join) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	if n.Right == nil {
+		rw.writeNode(n.Left)
+		return errors.Trace(rw.err)
+	}
+	if left, ok := n.Left.(*Join); ok && !isTableRefsList(n) && isTableRefsList(left) {
+		rw.writeString("(")
+		rw.writeNode(n.Left)
+		rw.writeString(")")
+	} else {
+		rw.writeNode(n.Left)
+	}
+	switch {
+	case isTableRefsList(n):
+		rw.writeString(", ")
+	case n.Tp == LeftJoin:
+		rw.writeString(" LEFT JOIN ")
+	case n.Tp == RightJoin:
+		rw.writeString(" RIGHT JOIN ")
+	default:
+		rw.writeString(" JOIN ")
+	}
+	if _, ok := n.Right.(*Join); ok {
+		rw.writeString("(")
+		rw.writeNode(n.Right)
+		rw.writeString(")")
+	} else {
+		rw.writeNode(n.Right)
+	}
+	if n.On != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.On)
+	}
+	return errors.Trace(rw.err)
+}
+
+// isTableRefsList reports whether n is a list of tables separated by commas, like "t1, t2".
+// The parser wraps the first table of the list in a Join without Right.
+func isTableRefsList(n *Join) bool {
+	if n.Right == nil {
+		return true
+	}
+	left, ok := n.Left.(*Join)
+	return ok && n.Tp == CrossJoin && n.On == nil && isTableRefsList(left)
+}
+
+// Restore implements RestoreNode interface.
+func (n *OnCondition) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("ON ")
+	rw.writeNode(n.Expr)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *TableSource) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	tn, isTableName := n.Source.(*TableName)
+	if isTableName {
+		writeTableName(rw, tn.Schema, tn.Name)
+	} else {
+		rw.writeString("(")
+		rw.writeNode(n.Source)
+		rw.writeString(")")
+	}
+	if n.AsName.O != "" {
+		rw.writeString(" AS ")
+		rw.writeName(n.AsName.O)
+	}
+	if isTableName {
+		for _, hint := range tn.IndexHints {
+			rw.writeString(" ")
+			restoreIndexHint(rw, hint)
+		}
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *TableName) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	writeTableName(rw, n.Schema, n.Name)
+	for _, hint := range n.IndexHints {
+		rw.writeString(" ")
+		restoreIndexHint(rw, hint)
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *TableOptimizerHint) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString(n.HintName.O)
+	rw.writeString("(")
+	for i, table := range n.Tables {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeName(table.O)
+	}
+	rw.writeString(")")
+	return errors.Trace(rw.err)
+}
+
+func restoreIndexHint(rw *restoreWriter, hint *IndexHint) {
+	switch hint.HintType {
+	case HintUse:
+		rw.writeString("USE INDEX ")
+	case HintIgnore:
+		rw.writeString("IGNORE INDEX ")
+	case HintForce:
+		rw.writeString("FORCE INDEX ")
+	}
+	switch hint.HintScope {
+	case HintForJoin:
+		rw.writeString("FOR JOIN ")
+	case HintForOrderBy:
+		rw.writeString("FOR ORDER BY ")
+	case HintForGroupBy:
+		rw.writeString("FOR GROUP BY ")
+	}
+	rw.writeString("(")
+	for i, name := range hint.IndexNames {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeName(name.O)
+	}
+	rw.writeString(")")
+}
+
+// Restore implements RestoreNode interface.
+func (n *ByItem) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeNode(n.Expr)
+	if n.Desc {
+		rw.writeString(" DESC")
+	}
+	return errors.Trace(rw.err)
+}
+
+func restoreByItems(rw *restoreWriter, items []*ByItem) {
+	for i, item := range items {
+		if i > 0 {
+			rw.writeString(", ")
+		}
+		rw.writeNode(item)
+	}
+}
+
+// Restore implements RestoreNode interface.
+func (n *GroupByClause) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("GROUP BY ")
+	restoreByItems(rw, n.Items)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *HavingClause) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("HAVING ")
+	rw.writeNode(n.Expr)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *OrderByClause) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("ORDER BY ")
+	restoreByItems(rw, n.Items)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *Limit) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("LIMIT ")
+	if n.Offset != nil {
+		rw.writeNode(n.Offset)
+		rw.writeString(", ")
+	}
+	rw.writeNode(n.Count)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ValueExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	switch n.Kind() {
+	case types.KindNull:
+		rw.writeString("NULL")
+	case types.KindInt64:
+		rw.writeString(strconv.FormatInt(n.GetInt64(), 10))
+	case types.KindUint64:
+		rw.writeString(strconv.FormatUint(n.GetUint64(), 10))
+	case types.KindFloat32:
+		rw.writeString(strconv.FormatFloat(float64(n.GetFloat32()), 'e', -1, 32))
+	case types.KindFloat64:
+		rw.writeString(strconv.FormatFloat(n.GetFloat64(), 'e', -1, 64))
+	case types.KindString, types.KindBytes:
+		rw.writeQuoted(n.GetString())
+	case types.KindMysqlDecimal:
+		rw.writeString(n.GetMysqlDecimal().String())
+	case types.KindMysqlHex:
+		rw.writeString(n.GetMysqlHex().String())
+	case types.KindMysqlBit:
+		rw.writeString(n.GetMysqlBit().String())
+	default:
+		return errors.Errorf("restore value of kind %d is not supported", n.Kind())
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ParamMarkerExpr) Restore(w io.Writer) error {
+	text := "?"
+	if n.Name != "" {
+		text = ":" + n.Name
+	}
+	_, err := io.WriteString(w, text)
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ColumnName) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	if n.Schema.O != "" {
+		rw.writeName(n.Schema.O)
+		rw.writeString(".")
+	}
+	if n.Table.O != "" {
+		rw.writeName(n.Table.O)
+		rw.writeString(".")
+	}
+	rw.writeName(n.Name.O)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ColumnNameExpr) Restore(w io.Writer) error {
+	return errors.Trace(Restore(w, n.Name))
+}
+
+// Restore implements RestoreNode interface.
+func (n *VariableExpr) Restore(w io.Writer) error {
+	var s string
+	switch {
+	case n.IsGlobal:
+		s = "@@GLOBAL." + n.Name
+	case n.IsSystem:
+		s = "@@" + n.Name
+	default:
+		s = "@" + n.Name
+	}
+	_, err := io.WriteString(w, s)
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *DefaultExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("DEFAULT")
+	if n.Name != nil {
+		rw.writeString("(")
+		rw.writeNode(n.Name)
+		rw.writeString(")")
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ParenthesesExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("(")
+	rw.writeNode(n.Expr)
+	rw.writeString(")")
+	return errors.Trace(rw.err)
+}
+
+var binaryOps = map[opcode.Op]string{
+	opcode.AndAnd:     "AND",
+	opcode.OrOr:       "OR",
+	opcode.LogicXor:   "XOR",
+	opcode.GE:         ">=",
+	opcode.LE:         "<=",
+	opcode.EQ:         "=",
+	opcode.NE:         "!=",
+	opcode.LT:         "<",
+	opcode.GT:         ">",
+	opcode.NullEQ:     "<=>",
+	opcode.Plus:       "+",
+	opcode.Minus:      "-",
+	opcode.Mul:        "*",
+	opcode.Div:        "/",
+	opcode.IntDiv:     "DIV",
+	opcode.Mod:        "%",
+	opcode.And:        "&",
+	opcode.Or:         "|",
+	opcode.Xor:        "^",
+	opcode.LeftShift:  "<<",
+	opcode.RightShift: ">>",
+}
+
+// Restore implements RestoreNode interface.
+func (n *BinaryOperationExpr) Restore(w io.Writer) error {
+	op, ok := binaryOps[n.Op]
+	if !ok {
+		return errors.Errorf("restore binary operator %s is not supported", n.Op)
+	}
+	rw := newRestoreWriter(w)
+	rw.writeNode(n.L)
+	rw.writeString(" " + op + " ")
+	rw.writeNode(n.R)
+	return errors.Trace(rw.err)
+}
+
+var unaryOps = map[opcode.Op]string{
+	opcode.Not:    "NOT ",
+	opcode.BitNeg: "~",
+	opcode.Minus:  "-",
+	opcode.Plus:   "+",
+}
+
+// Restore implements RestoreNode interface.
+func (n *UnaryOperationExpr) Restore(w io.Writer) error {
+	op, ok := unaryOps[n.Op]
+	if !ok {
+		return errors.Errorf("restore unary operator %s is not supported", n.Op)
+	}
+	var operand bytes.Buffer
+	if err := Restore(&operand, n.V); err != nil {
+		return errors.Trace(err)
+	}
+	s := operand.String()
+	// Keep "- -1" from being restored as a comment.
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		op += " "
+	}
+	_, err := io.WriteString(w, op+s)
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *BetweenExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeNode(n.Expr)
+	if n.Not {
+		rw.writeString(" NOT BETWEEN ")
+	} else {
+		rw.writeString(" BETWEEN ")
+	}
+	rw.writeNode(n.Left)
+	rw.writeString(" AND ")
+	rw.writeNode(n.Right)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *IsNullExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeNode(n.Expr)
+	if n.Not {
+		rw.writeString(" IS NOT NULL")
+	} else {
+		rw.writeString(" IS NULL")
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *IsTruthExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeNode(n.Expr)
+	rw.writeString(" IS ")
+	if n.Not {
+		rw.writeString("NOT ")
+	}
+	if n.True > 0 {
+		rw.writeString("TRUE")
+	} else {
+		rw.writeString("FALSE")
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *PatternLikeExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	// Expr is nil for the LIKE clause of SHOW statement.
+	if n.Expr != nil {
+		rw.writeNode(n.Expr)
+		rw.writeString(" ")
+	}
+	if n.Not {
+		rw.writeString("NOT ")
+	}
+	rw.writeString("LIKE ")
+	rw.writeNode(n.Pattern)
+	if n.Expr != nil && n.Escape != '\\' {
+		rw.writeString(" ESCAPE ")
+		rw.writeQuoted(string(n.Escape))
+	}
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *PatternRegexpExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeNode(n.Expr)
+	if n.Not {
+		rw.writeString(" NOT REGEXP ")
+	} else {
+		rw.writeString(" REGEXP ")
+	}
+	rw.writeNode(n.Pattern)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *PatternInExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeNode(n.Expr)
+	if n.Not {
+		rw.writeString(" NOT IN ")
+	} else {
+		rw.writeString(" IN ")
+	}
+	if n.Sel != nil {
+		rw.writeNode(n.Sel)
+		return errors.Trace(rw.err)
+	}
+	rw.writeString("(")
+	rw.writeExprs(n.List)
+	rw.writeString(")")
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *RowExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("ROW(")
+	rw.writeExprs(n.Values)
+	rw.writeString(")")
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *CaseExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("CASE")
+	if n.Value != nil {
+		rw.writeString(" ")
+		rw.writeNode(n.Value)
+	}
+	for _, clause := range n.WhenClauses {
+		rw.writeString(" ")
+		rw.writeNode(clause)
+	}
+	if n.ElseClause != nil {
+		rw.writeString(" ELSE ")
+		rw.writeNode(n.ElseClause)
+	}
+	rw.writeString(" END")
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *WhenClause) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("WHEN ")
+	rw.writeNode(n.Expr)
+	rw.writeString(" THEN ")
+	rw.writeNode(n.Result)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *SubqueryExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("(")
+	rw.writeNode(n.Query)
+	rw.writeString(")")
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ExistsSubqueryExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("EXISTS ")
+	rw.writeNode(n.Sel)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *CompareSubqueryExpr) Restore(w io.Writer) error {
+	op, ok := binaryOps[n.Op]
+	if !ok {
+		return errors.Errorf("restore compare operator %s is not supported", n.Op)
+	}
+	rw := newRestoreWriter(w)
+	rw.writeNode(n.L)
+	rw.writeString(" " + op + " ")
+	if n.All {
+		rw.writeString("ALL ")
+	} else {
+		rw.writeString("ANY ")
+	}
+	rw.writeNode(n.R)
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *PositionExpr) Restore(w io.Writer) error {
+	_, err := io.WriteString(w, strconv.Itoa(n.N))
+	return errors.Trace(err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *ValuesExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString("VALUES(")
+	rw.writeNode(n.Column)
+	rw.writeString(")")
+	return errors.Trace(rw.err)
+}
+
+// Restore implements RestoreNode interface.
+func (n *FuncCallExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	// Some functions have their own syntax, the parser stores the keywords
+	// of the syntax, like the time unit of DATE_ADD, as arguments.
+	switch n.FnName.L {
+	case DateAdd, DateSub, AddDate, SubDate:
+		unit, err := n.keywordArg(2)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rw.writeString(n.FnName.O + "(")
+		rw.writeNode(n.Args[0])
+		rw.writeString(", INTERVAL ")
+		rw.writeNode(n.Args[1])
+		rw.writeString(" " + unit + ")")
+	case Extract:
+		if len(n.Args) != 2 {
+			return errors.Errorf("restore function %s is not supported", n.FnName.O)
+		}
+		unit, err := n.keywordArg(0)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rw.writeString(n.FnName.O + "(" + unit + " FROM ")
+		rw.writeNode(n.Args[1])
+		rw.writeString(")")
+	case TimestampAdd, TimestampDiff:
+		unit, err := n.keywordArg(0)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rw.writeString(n.FnName.O + "(" + unit + ", ")
+		rw.writeExprs(n.Args[1:])
+		rw.writeString(")")
+	case Convert:
+		cs, err := n.keywordArg(1)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rw.writeString(n.FnName.O + "(")
+		rw.writeNode(n.Args[0])
+		rw.writeString(" USING " + cs + ")")
+	case Trim:
+		return errors.Trace(n.restoreTrim(rw))
+	case CharFunc:
+		if len(n.Args) == 0 {
+			return errors.Errorf("restore function %s is not supported", n.FnName.O)
+		}
+		last := len(n.Args) - 1
+		rw.writeString("CHAR(")
+		rw.writeExprs(n.Args[:last])
+		if !isNullValue(n.Args[last]) {
+			cs, err := n.keywordArg(last)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			rw.writeString(" USING " + cs)
+		}
+		rw.writeString(")")
+	default:
+		rw.writeString(n.FnName.O + "(")
+		rw.writeExprs(n.Args)
+		rw.writeString(")")
+	}
+	return errors.Trace(rw.err)
+}
+
+// restoreTrim restores the TRIM function, whose arguments are the string,
+// the string to remove and the trim direction.
+func (n *FuncCallExpr) restoreTrim(rw *restoreWriter) error {
+	rw.writeString(n.FnName.O + "(")
+	switch len(n.Args) {
+	case 1:
+		rw.writeNode(n.Args[0])
+	case 2:
+		rw.writeNode(n.Args[1])
+		rw.writeString(" FROM ")
+		rw.writeNode(n.Args[0])
+	case 3:
+		direction, err := n.keywordArg(2)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rw.writeString(direction + " ")
+		if !isNullValue(n.Args[1]) {
+			rw.writeNode(n.Args[1])
+			rw.writeString(" ")
+		}
+		rw.writeString("FROM ")
+		rw.writeNode(n.Args[0])
+	default:
+		return errors.Errorf("restore function %s is not supported", n.FnName.O)
+	}
+	rw.writeString(")")
+	return errors.Trace(rw.err)
+}
+
+var trimDirections = map[TrimDirectionType]string{
+	TrimBothDefault: "BOTH",
+	TrimBoth:        "BOTH",
+	TrimLeading:     "LEADING",
+	TrimTrailing:    "TRAILING",
+}
+
+// keywordArg returns the keyword stored as the i-th argument of n.
+func (n *FuncCallExpr) keywordArg(i int) (string, error) {
+	if i < len(n.Args) {
+		if v, ok := n.Args[i].(*ValueExpr); ok {
+			switch x := v.GetValue().(type) {
+			case string:
+				return x, nil
+			case TrimDirectionType:
+				if direction, ok := trimDirections[x]; ok {
+					return direction, nil
+				}
+			}
+		}
+	}
+	return "", errors.Errorf("restore function %s is not supported", n.FnName.O)
+}
+
+// isKeywordArg reports whether the i-th argument of n is a keyword of the function
+// syntax rather than an expression, see FuncCallExpr.Restore.
+func (n *FuncCallExpr) isKeywordArg(i int) bool {
+	switch n.FnName.L {
+	case DateAdd, DateSub, AddDate, SubDate:
+		return i == 2
+	case Extract, TimestampAdd, TimestampDiff:
+		return i == 0
+	case Convert:
+		return i == 1
+	case Trim:
+		// The string to remove is NULL if it's omitted.
+		return len(n.Args) == 3 && (i == 2 || i == 1 && isNullValue(n.Args[1]))
+	case CharFunc:
+		return i == len(n.Args)-1
+	}
+	return false
+}
+
+func isNullValue(expr ExprNode) bool {
+	v, ok := expr.(*ValueExpr)
+	return ok && v.IsNull()
+}
+
+// Restore implements RestoreNode interface.
+func (n *FuncCastExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	if n.FunctionType == CastBinaryOperator {
+		rw.writeString("BINARY ")
+		rw.writeNode(n.Expr)
+		return errors.Trace(rw.err)
+	}
+	tp, err := restoreCastType(n.Tp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch n.FunctionType {
+	case CastFunction:
+		rw.writeString("CAST(")
+		rw.writeNode(n.Expr)
+		rw.writeString(" AS " + tp + ")")
+	case CastConvertFunction:
+		rw.writeString("CONVERT(")
+		rw.writeNode(n.Expr)
+		rw.writeString(", " + tp + ")")
+	default:
+		return errors.Errorf("restore cast function type %d is not supported", n.FunctionType)
+	}
+	return errors.Trace(rw.err)
+}
+
+// restoreCastType returns the target type of CAST and CONVERT.
+func restoreCastType(tp *types.FieldType) (string, error) {
+	switch tp.Tp {
+	case mysql.TypeString:
+		if tp.Charset == charset.CharsetBin {
+			return "BINARY" + restoreFieldLen(tp.Flen), nil
+		}
+		s := "CHAR" + restoreFieldLen(tp.Flen)
+		if mysql.HasBinaryFlag(tp.Flag) {
+			s += " BINARY"
+		}
+		if tp.Charset != "" {
+			s += " CHARACTER SET " + tp.Charset
+		}
+		return s, nil
+	case mysql.TypeDate:
+		return "DATE", nil
+	case mysql.TypeDatetime:
+		return "DATETIME" + restoreFieldLen(tp.Decimal), nil
+	case mysql.TypeDuration:
+		return "TIME" + restoreFieldLen(tp.Decimal), nil
+	case mysql.TypeNewDecimal:
+		if tp.Flen == types.UnspecifiedLength || tp.Decimal == types.UnspecifiedLength {
+			return "DECIMAL" + restoreFieldLen(tp.Flen), nil
+		}
+		return "DECIMAL(" + strconv.Itoa(tp.Flen) + ", " + strconv.Itoa(tp.Decimal) + ")", nil
+	case mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(tp.Flag) {
+			return "UNSIGNED", nil
+		}
+		return "SIGNED", nil
+	}
+	return "", errors.Errorf("restore cast type %s is not supported", tp)
+}
+
+// restoreFieldLen returns the parenthesized length, or an empty string if it's unspecified.
+func restoreFieldLen(flen int) string {
+	if flen == types.UnspecifiedLength {
+		return ""
+	}
+	return "(" + strconv.Itoa(flen) + ")"
+}
+
+// Restore implements RestoreNode interface.
+func (n *AggregateFuncExpr) Restore(w io.Writer) error {
+	rw := newRestoreWriter(w)
+	rw.writeString(n.F + "(")
+	if n.Distinct {
+		rw.writeString("DISTINCT ")
+	}
+	rw.writeExprs(n.Args)
+	rw.writeString(")")
+	return errors.Trace(rw.err)
+}
@@ -0,0 +1,227 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	"bytes"
+
+	. "github.com/pingcap/check"
+	. "github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/model"
+	"github.com/pingcap/tidb/parser"
+)
+
+var _ = Suite(&testRestoreSuite{})
+
+type testRestoreSuite struct {
+}
+
+func restoreSQL(c *C, sql string) string {
+	stmt, err := parser.New().ParseOneStmt(sql, "", "")
+	c.Assert(err, IsNil, Commentf("source %s", sql))
+	var buf bytes.Buffer
+	err = Restore(&buf, stmt)
+	c.Assert(err, IsNil, Commentf("source %s", sql))
+	return buf.String()
+}
+
+func (ts *testRestoreSuite) TestRestore(c *C) {
+	cases := []struct {
+		sql    string
+		expect string
+	}{
+		{"begin", "START TRANSACTION"},
+		{"start transaction", "START TRANSACTION"},
+		{"start transaction read write", "START TRANSACTION"},
+		{"start transaction with consistent snapshot, read only", "START TRANSACTION READ ONLY, WITH CONSISTENT SNAPSHOT"},
+		{"commit", "COMMIT"},
+		{"rollback", "ROLLBACK"},
+		{"rollback and no chain no release", "ROLLBACK AND NO CHAIN NO RELEASE"},
+		{"commit and chain", "COMMIT AND CHAIN"},
+		{"rollback to sp", "ROLLBACK TO SAVEPOINT `sp`"},
+		{"savepoint `a``b`", "SAVEPOINT `a``b`"},
+		{"release savepoint sp", "RELEASE SAVEPOINT `sp`"},
+		{"set password = password('pwd')", "SET PASSWORD = 'pwd'"},
+		{"set password for 'u'@'%' = 'it''s'", "SET PASSWORD FOR 'u'@'%' = 'it''s'"},
+		{"set password for current_user = 'pwd'", "SET PASSWORD FOR CURRENT_USER() = 'pwd'"},
+		{"kill connection 10", "KILL 10"},
+		{"kill tidb query 10", "KILL TIDB QUERY 10"},
+		{"shutdown", "SHUTDOWN"},
+		{"restart", "RESTART"},
+		{"alter instance reload tls", "ALTER INSTANCE RELOAD TLS"},
+		{"alter instance reload tls no rollback on error", "ALTER INSTANCE RELOAD TLS NO ROLLBACK ON ERROR"},
+		{"drop stats t1, db.t2", "DROP STATS `t1`, `db`.`t2`"},
+		{"drop stats t partition p0, p1", "DROP STATS `t` PARTITION `p0`, `p1`"},
+		{"drop stats t global", "DROP STATS `t` GLOBAL"},
+		{"backup database * to 's3://bucket/path'", "BACKUP DATABASE * TO 's3://bucket/path'"},
+		{"backup schema a, b to 'local:///tmp/backup' with rate_limit = 120, checksum = false", "BACKUP DATABASE `a`, `b` TO 'local:///tmp/backup' WITH rate_limit = 120, checksum = 0"},
+		{"restore table t1, db.t2 from 'local:///tmp/backup' with ONLINE = 1", "RESTORE TABLE `t1`, `db`.`t2` FROM 'local:///tmp/backup' WITH online = 1"},
+		{"help 'it''s'", "HELP 'it''s'"},
+		{"load stats '/tmp/stats.json'", "LOAD STATS '/tmp/stats.json'"},
+		{"plan replayer dump explain select a from t", "PLAN REPLAYER DUMP EXPLAIN SELECT `a` FROM `t`"},
+		{"plan replayer dump explain analyze select 1", "PLAN REPLAYER DUMP EXPLAIN ANALYZE SELECT 1"},
+		{"plan replayer load '/tmp/replayer.zip'", "PLAN REPLAYER LOAD '/tmp/replayer.zip'"},
+		{"calibrate resource", "CALIBRATE RESOURCE"},
+		{"query watch add resource group rg1 action = kill sql text exact to 'select 1'", "QUERY WATCH ADD RESOURCE GROUP `rg1` ACTION KILL SQL TEXT EXACT TO 'select 1'"},
+		{"query watch add sql digest 'd1', action cooldown", "QUERY WATCH ADD SQL DIGEST 'd1' ACTION COOLDOWN"},
+		{"query watch add plan digest 'd2'", "QUERY WATCH ADD PLAN DIGEST 'd2'"},
+		{"query watch remove 1", "QUERY WATCH REMOVE 1"},
+		{"calibrate resource workload OLTP_READ_ONLY", "CALIBRATE RESOURCE WORKLOAD oltp_read_only"},
+		{"calibrate resource start_time = '2023-04-18 08:00:00', duration = '20m'", "CALIBRATE RESOURCE START_TIME '2023-04-18 08:00:00' DURATION '20m'"},
+		{"create global binding for select * from t where a = 1 using select * from t use index(a) where a = 1", "CREATE GLOBAL BINDING FOR SELECT * FROM `t` WHERE `a` = 1 USING SELECT * FROM `t` USE INDEX (`a`) WHERE `a` = 1"},
+		{"create binding for select 1 union select 2 using select 1 union select 2", "CREATE SESSION BINDING FOR (SELECT 1) UNION (SELECT 2) USING (SELECT 1) UNION (SELECT 2)"},
+		{"drop session binding for select * from t", "DROP SESSION BINDING FOR SELECT * FROM `t`"},
+		{"binlog 'BxSFVw8JAAAA+/='", "BINLOG 'BxSFVw8JAAAA+/='"},
+		{"show table status where rows > 10 order by name desc limit 5", "SHOW TABLE STATUS WHERE `rows` > 10 ORDER BY `name` DESC LIMIT 5"},
+		{"analyze table t1, db.t2", "ANALYZE TABLE `t1`, `db`.`t2`"},
+		{"analyze table t index a, b with 8 buckets", "ANALYZE TABLE `t` INDEX `a`, `b` WITH 8 BUCKETS"},
+		{"analyze table t index", "ANALYZE TABLE `t` INDEX"},
+		{"lock tables t read, db.u write, v read local, w low_priority write", "LOCK TABLES `t` READ, `db`.`u` WRITE, `v` READ LOCAL, `w` LOW_PRIORITY WRITE"},
+		{"unlock tables", "UNLOCK TABLES"},
+		{"set config TiKV split.qps-threshold = 1000", "SET CONFIG tikv split.qps-threshold = 1000"},
+		{"set config '127.0.0.1:20180' log-level = 'info'", "SET CONFIG '127.0.0.1:20180' log-level = 'info'"},
+		{"set role default", "SET ROLE DEFAULT"},
+		{"set role all except 'r1', 'r2'@'localhost'", "SET ROLE ALL EXCEPT 'r1'@'%', 'r2'@'localhost'"},
+		{"set role 'r1'", "SET ROLE 'r1'@'%'"},
+		{"set default role none to 'u1'", "SET DEFAULT ROLE NONE TO 'u1'@'%'"},
+		{"set default role 'r1', 'r2'@'localhost' to 'u1', 'u2'@'localhost'", "SET DEFAULT ROLE 'r1'@'%', 'r2'@'localhost' TO 'u1'@'%', 'u2'@'localhost'"},
+		{"create role if not exists 'r1', 'r2'@'localhost'", "CREATE ROLE IF NOT EXISTS 'r1'@'%', 'r2'@'localhost'"},
+		{"drop role 'r1'", "DROP ROLE 'r1'@'%'"},
+		{"grant all on *.* to 'u'@'%' identified by 'pw'", "GRANT ALL PRIVILEGES ON *.* TO 'u'@'%' IDENTIFIED BY 'pw'"},
+		{"grant select (a), show databases on table db.* to 'u'@'%', 'v'@'h' with grant option",
+			"GRANT SELECT (`a`), SHOW DATABASES ON TABLE `db`.* TO 'u'@'%', 'v'@'h' WITH GRANT OPTION"},
+		{"grant create, drop on * to 'u'", "GRANT CREATE, DROP ON * TO 'u'@'%'"},
+		{"grant 'r1', 'r2' to 'u1', 'u2'@'localhost'", "GRANT 'r1'@'%', 'r2'@'%' TO 'u1'@'%', 'u2'@'localhost'"},
+		{"revoke 'r1' from 'u1'", "REVOKE 'r1'@'%' FROM 'u1'@'%'"},
+		{"split table t between (0) and (100) regions 4", "SPLIT TABLE `t` BETWEEN (0) AND (100) REGIONS 4"},
+		{"split table t index idx by (1, 'a'), (2, 'b')", "SPLIT TABLE `t` INDEX `idx` BY (1, 'a'), (2, 'b')"},
+		{"change pump to node_state = 'paused' for node_id 'pump-1'", "CHANGE PUMP TO NODE_STATE = 'paused' FOR NODE_ID 'pump-1'"},
+		{"CHANGE drainer TO NODE_STATE ='online' FOR NODE_ID \"d'1\"", "CHANGE DRAINER TO NODE_STATE = 'online' FOR NODE_ID 'd''1'"},
+		{"use test", "USE `test`"},
+		{"do sleep(1), 1 + 1", "DO sleep(1), 1 + 1"},
+		{"select ?, :a", "SELECT ?, :a"},
+		{"use `a``b`", "USE `a``b`"},
+		{"set @a = 1, @@global.autocommit = 0, autocommit = default", "SET @a = 1, @@GLOBAL.autocommit = 0, autocommit = DEFAULT"},
+		{"set global autocommit = 1, session autocommit = 1, local autocommit = 1", "SET GLOBAL autocommit = 1, SESSION autocommit = 1, SESSION autocommit = 1"},
+		{"set @@global.autocommit = 1, @@session.autocommit = 1, @@local.autocommit = 1, @@autocommit = 1", "SET @@GLOBAL.autocommit = 1, @@SESSION.autocommit = 1, @@SESSION.autocommit = 1, @@autocommit = 1"},
+		{"set @a = 'it''s', @b = '\\\\'", "SET @a = 'it''s', @b = '\\\\'"},
+		{"set names utf8 collate utf8_bin", "SET NAMES 'utf8' COLLATE 'utf8_bin'"},
+		{"set charset gbk", "SET NAMES 'gbk'"},
+		{"set names default", "SET NAMES DEFAULT"},
+		{"create user 'u'@'%'", "CREATE USER 'u'@'%'"},
+		{"create user if not exists 'u'@'%' identified by 'pw', 'v'@'localhost' identified by password 'hash'", "CREATE USER IF NOT EXISTS 'u'@'%' IDENTIFIED BY 'pw', 'v'@'localhost' IDENTIFIED BY PASSWORD 'hash'"},
+		{"create user 'u'@'%' identified with mysql_native_password as 'hash'", "CREATE USER 'u'@'%' IDENTIFIED WITH 'mysql_native_password' AS 'hash'"},
+		{"alter user if exists 'u'@'%' identified with 'mysql_native_password' by 'pw'", "ALTER USER IF EXISTS 'u'@'%' IDENTIFIED WITH 'mysql_native_password' BY 'pw'"},
+		{"alter user user() identified by 'pw'", "ALTER USER USER() IDENTIFIED BY 'pw'"},
+		{"drop user if exists 'u'@'%', 'v'@'localhost'", "DROP USER IF EXISTS 'u'@'%', 'v'@'localhost'"},
+		{"drop user 'u', ''@'localhost'", "DROP USER 'u'@'%', ''@'localhost'"},
+		{"set transaction read only", "SET TRANSACTION READ ONLY"},
+		{"set session transaction isolation level read committed", "SET SESSION TRANSACTION ISOLATION LEVEL READ COMMITTED"},
+		{"set global transaction read write, isolation level repeatable read", "SET GLOBAL TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ WRITE"},
+		{"show full tables from test like 't%'", "SHOW FULL TABLES FROM `test` LIKE 't%'"},
+		{"show full columns from t", "SHOW FULL COLUMNS FROM `t`"},
+		{"show extended full fields in t", "SHOW EXTENDED FULL COLUMNS FROM `t`"},
+		{"show extended keys from t", "SHOW EXTENDED INDEX FROM `t`"},
+		{"show processlist", "SHOW PROCESSLIST"},
+		{"show engines", "SHOW ENGINES"},
+		{"show plugins", "SHOW PLUGINS"},
+		{"show privileges", "SHOW PRIVILEGES"},
+		{"show profiles", "SHOW PROFILES"},
+		{"show stats_meta", "SHOW STATS_META"},
+		{"show builtins", "SHOW BUILTINS"},
+		{"show stats_histograms where db_name = 'test'", "SHOW STATS_HISTOGRAMS WHERE `db_name` = 'test'"},
+		{"show stats_buckets like 't%'", "SHOW STATS_BUCKETS LIKE 't%'"},
+		{"show stats_healthy where healthy < 50", "SHOW STATS_HEALTHY WHERE `healthy` < 50"},
+		{"show profile", "SHOW PROFILE"},
+		{"show profile cpu, block io, context switches for query 3 limit 1, 2", "SHOW PROFILE CPU, BLOCK IO, CONTEXT SWITCHES FOR QUERY 3 LIMIT 1, 2"},
+		{"show open tables in test like 't%'", "SHOW OPEN TABLES FROM `test` LIKE 't%'"},
+		{"show full processlist", "SHOW FULL PROCESSLIST"},
+		{"show global variables where variable_name = 'autocommit'", "SHOW GLOBAL VARIABLES WHERE `variable_name` = 'autocommit'"},
+		{"show session status like 'a%'", "SHOW SESSION STATUS LIKE 'a%'"},
+		{"show variables", "SHOW VARIABLES"},
+		{"show global bindings like 'select%'", "SHOW GLOBAL BINDINGS LIKE 'select%'"},
+		{"show session bindings", "SHOW SESSION BINDINGS"},
+		{"show bindings", "SHOW BINDINGS"},
+		{"show create table test.t", "SHOW CREATE TABLE `test`.`t`"},
+		{"show create schema test", "SHOW CREATE DATABASE `test`"},
+		{"show warnings limit 10", "SHOW WARNINGS LIMIT 10"},
+		{"show tables like 't%' limit 50, 50", "SHOW TABLES LIKE 't%' LIMIT 50, 50"},
+		{"show count(*) errors", "SHOW COUNT(*) ERRORS"},
+		{"show grants for 'root'@'%'", "SHOW GRANTS FOR 'root'@'%'"},
+		{"show grants", "SHOW GRANTS"},
+		{"show grants for current_user()", "SHOW GRANTS FOR CURRENT_USER()"},
+		{"show grants for 'u'@'localhost' using 'r1', 'r2'@'h'", "SHOW GRANTS FOR 'u'@'localhost' USING 'r1'@'%', 'r2'@'h'"},
+		{"explain t", "EXPLAIN `t`"},
+		{"explain analyze select 1", "EXPLAIN ANALYZE SELECT 1"},
+		{"explain format = json select 1", "EXPLAIN FORMAT = 'json' SELECT 1"},
+		{"explain format = row select 1", "EXPLAIN SELECT 1"},
+		{"trace format = 'json' select 1", "TRACE FORMAT = 'json' SELECT 1"},
+		{"trace begin", "TRACE START TRANSACTION"},
+		{"explain t c", "EXPLAIN `t` `c`"},
+		{"explain select distinct a, t.*, count(*) as c from t as t1 use index (i) join t2 on t1.a = t2.a where a > -1 and b in (1, 2) group by a having c > 1 order by a desc limit 1, 10 for update",
+			"EXPLAIN SELECT DISTINCT `a`, `t`.*, count(1) AS `c` FROM `t` AS `t1` USE INDEX (`i`) JOIN `t2` ON `t1`.`a` = `t2`.`a` WHERE `a` > -1 AND `b` IN (1, 2) GROUP BY `a` HAVING `c` > 1 ORDER BY `a` DESC LIMIT 1, 10 FOR UPDATE"},
+		{"select /*+ tidb_smj(t1, T2) TIDB_HJ() */ distinct a from t1, t2", "SELECT /*+ tidb_smj(`t1`, `T2`), TIDB_HJ() */ DISTINCT `a` FROM `t1`, `t2`"},
+		{"select date_add(a, interval 1 day), DATE_SUB(a, interval b hour), adddate(a, 1), subdate(a, interval 2 month)",
+			"SELECT date_add(`a`, INTERVAL 1 DAY), DATE_SUB(`a`, INTERVAL `b` HOUR), adddate(`a`, INTERVAL 1 DAY), subdate(`a`, INTERVAL 2 MONTH)"},
+		{"select extract(year from a), timestampdiff(day, a, b), timestampadd(minute, 1, a)",
+			"SELECT extract(YEAR FROM `a`), timestampdiff(DAY, `a`, `b`), timestampadd(MINUTE, 1, `a`)"},
+		{"select trim(a), trim('x' from a), trim(leading 'x' from a), trim(trailing from a), trim(both from a)",
+			"SELECT trim(`a`), trim('x' FROM `a`), trim(LEADING 'x' FROM `a`), trim(TRAILING FROM `a`), trim(BOTH FROM `a`)"},
+		{"select convert(a using utf8), char(65, 66), char(65 using utf8)",
+			"SELECT convert(`a` USING utf8), CHAR(65, 66), CHAR(65 USING utf8)"},
+		{"select cast(a as char), cast(a as char(10) binary charset utf8), cast(a as binary(4)), binary a",
+			"SELECT CAST(`a` AS CHAR), CAST(`a` AS CHAR(10) BINARY CHARACTER SET utf8), CAST(`a` AS BINARY(4)), BINARY `a`"},
+		{"select cast(a as signed integer), convert(a, unsigned), cast(a as decimal), cast(a as decimal(10, 2)), cast(a as date), cast(a as datetime(3)), cast(a as time)",
+			"SELECT CAST(`a` AS SIGNED), CONVERT(`a`, UNSIGNED), CAST(`a` AS DECIMAL(10, 0)), CAST(`a` AS DECIMAL(10, 2)), CAST(`a` AS DATE), CAST(`a` AS DATETIME(3)), CAST(`a` AS TIME)"},
+		{"insert low_priority ignore into db.t (a, b) values (1, 'a'), (2, default) on duplicate key update a = values(a), b = b + 1",
+			"INSERT LOW_PRIORITY IGNORE INTO `db`.`t` (`a`, `b`) VALUES (1, 'a'), (2, DEFAULT) ON DUPLICATE KEY UPDATE `a` = VALUES(`a`), `b` = `b` + 1"},
+		{"insert into t set a = 1, b = 'x'", "INSERT INTO `t` SET `a` = 1, `b` = 'x'"},
+		{"insert into t () values ()", "INSERT INTO `t` VALUES ()"},
+		{"insert into t (a) select a from s union select 1", "INSERT INTO `t` (`a`) (SELECT `a` FROM `s`) UNION (SELECT 1)"},
+		{"replace delayed into t select * from s", "REPLACE DELAYED INTO `t` SELECT * FROM `s`"},
+		{"update /*+ tidb_hj(t) */ low_priority t set a = 1, b = 'x' where c = 2 order by d limit 3",
+			"UPDATE /*+ tidb_hj(`t`) */ LOW_PRIORITY `t` SET `a` = 1, `b` = 'x' WHERE `c` = 2 ORDER BY `d` LIMIT 3"},
+		{"update t1 join t2 on t1.a = t2.a set t1.b = t2.b", "UPDATE `t1` JOIN `t2` ON `t1`.`a` = `t2`.`a` SET `t1`.`b` = `t2`.`b`"},
+		{"delete low_priority quick ignore from t where a = 1 order by b limit 2", "DELETE LOW_PRIORITY QUICK IGNORE FROM `t` WHERE `a` = 1 ORDER BY `b` LIMIT 2"},
+		{"delete /*+ tidb_smj(t1) */ t1, t2 from t1 join t2 on t1.a = t2.a", "DELETE /*+ tidb_smj(`t1`) */ `t1`, `t2` FROM `t1` JOIN `t2` ON `t1`.`a` = `t2`.`a`"},
+		{"select * from t1, t2 join t3, (t4, t5) left join t6 on t6.a = 1", "SELECT * FROM `t1`, (`t2` JOIN `t3`), ((`t4`, `t5`) LEFT JOIN `t6` ON `t6`.`a` = 1)"},
+		{"delete from t1, t2 using t1 join t2 where t1.a = t2.a", "DELETE FROM `t1`, `t2` USING `t1` JOIN `t2` WHERE `t1`.`a` = `t2`.`a`"},
+		{"load data local infile '/tmp/t.csv' into table t fields terminated by ',' enclosed by '\"' lines starting by 'x' terminated by ';' (a, b)",
+			"LOAD DATA LOCAL INFILE '/tmp/t.csv' INTO TABLE `t` FIELDS TERMINATED BY ',' ENCLOSED BY '\"' ESCAPED BY '\\\\' LINES STARTING BY 'x' TERMINATED BY ';' (`a`, `b`)"},
+		{"import into t (a, @b) from 's3://bucket/*.csv' format 'csv' with thread = 8, detached",
+			"IMPORT INTO `t` (`a`, @b) FROM 's3://bucket/*.csv' FORMAT 'csv' WITH thread = 8, detached"},
+		{"explain select a from t where exists (select 1) and a is not null union all select 1.5",
+			"EXPLAIN (SELECT `a` FROM `t` WHERE EXISTS (SELECT 1) AND `a` IS NOT NULL) UNION ALL (SELECT 1.5)"},
+	}
+	for _, ca := range cases {
+		restored := restoreSQL(c, ca.sql)
+		c.Assert(restored, Equals, ca.expect, Commentf("source %s", ca.sql))
+		// The restored text must parse to the same statement, and restoring it again must be stable.
+		origin, err := parser.New().ParseOneStmt(ca.sql, "", "")
+		c.Assert(err, IsNil)
+		reparsed, err := parser.New().ParseOneStmt(restored, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", ca.sql))
+		c.Assert(NodesEqual(origin, reparsed), IsTrue, Commentf("source %s", ca.sql))
+		c.Assert(restoreSQL(c, restored), Equals, restored, Commentf("source %s", ca.sql))
+	}
+}
+
+func (ts *testRestoreSuite) TestRestoreNotSupported(c *C) {
+	var buf bytes.Buffer
+	err := Restore(&buf, &DropTableStmt{})
+	c.Assert(err, NotNil)
+	// The time unit is missing.
+	err = Restore(&buf, &FuncCallExpr{FnName: model.NewCIStr(DateAdd), Args: []ExprNode{&ColumnNameExpr{}}})
+	c.Assert(err, NotNil)
+}
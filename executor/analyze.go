@@ -37,6 +37,7 @@ type AnalyzeExec struct {
 	idxOffsets []int
 	colOffsets []int
 	pkOffset   int
+	numBuckets int64
 	Srcs       []Executor
 }
 
@@ -98,12 +99,16 @@ func (e *AnalyzeExec) Next() (*Row, error) {
 
 func (e *AnalyzeExec) buildStatisticsAndSaveToKV(count int64, columnSamples [][]types.Datum, idxRS []ast.RecordSet, pkRS ast.RecordSet) error {
 	txn := e.ctx.Txn()
+	numBuckets := e.numBuckets
+	if numBuckets == 0 {
+		numBuckets = defaultBucketCount
+	}
 	statBuilder := &statistics.Builder{
 		Sc:            e.ctx.GetSessionVars().StmtCtx,
 		TblInfo:       e.tblInfo,
 		StartTS:       int64(txn.StartTS()),
 		Count:         count,
-		NumBuckets:    defaultBucketCount,
+		NumBuckets:    numBuckets,
 		ColumnSamples: columnSamples,
 		ColOffsets:    e.colOffsets,
 		IdxRecords:    idxRS,
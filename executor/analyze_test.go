@@ -42,4 +42,13 @@ func (s *testSuite) TestAnalyzeTable(c *C) {
 	result = tk.MustQuery("explain select * from t1 where t1.a = 1")
 	rowStr = fmt.Sprintf("%s", result.Rows())
 	c.Check(strings.Split(rowStr, "{")[0], Equals, "[[TableScan_4 ")
+
+	tk.MustExec("analyze table t1 index ind_a with 4 buckets")
+	tk.MustExec("analyze table t1 index")
+	_, err := tk.Exec("analyze table t1 index ind_b")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("analyze table t1 with 9223372036854775808 buckets")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("analyze table t1 index ind_a with 1025 buckets")
+	c.Assert(err, NotNil)
 }
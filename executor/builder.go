@@ -158,8 +158,10 @@ func (b *executorBuilder) buildCheckTable(v *plan.CheckTable) Executor {
 
 func (b *executorBuilder) buildDeallocate(v *plan.Deallocate) Executor {
 	return &DeallocateExec{
-		ctx:  b.ctx,
-		Name: v.Name,
+		ctx:     b.ctx,
+		Name:    v.Name,
+		ID:      v.ExecID,
+		UsingID: v.UsingID,
 	}
 }
 
@@ -198,6 +200,7 @@ func (b *executorBuilder) buildPrepare(v *plan.Prepare) Executor {
 		IS:      b.is,
 		Name:    v.Name,
 		SQLText: v.SQLText,
+		Stmt:    v.Stmt,
 	}
 }
 
@@ -217,16 +220,20 @@ func (b *executorBuilder) buildShow(v *plan.Show) Executor {
 		DBName:      model.NewCIStr(v.DBName),
 		Table:       v.Table,
 		Column:      v.Column,
-		User:        v.User,
 		Flag:        v.Flag,
 		Full:        v.Full,
 		GlobalScope: v.GlobalScope,
+		CountOnly:   v.CountOnly,
 		ctx:         b.ctx,
 		is:          b.is,
 		schema:      v.Schema(),
 	}
-	if e.Tp == ast.ShowGrants && len(e.User) == 0 {
-		e.User = e.ctx.GetSessionVars().User
+	if e.Tp == ast.ShowGrants {
+		if v.User == nil || v.User.CurrentUser {
+			e.User = e.ctx.GetSessionVars().User
+		} else {
+			e.User = v.User.Username + "@" + v.User.Hostname
+		}
 	}
 	return e
 }
@@ -235,6 +242,10 @@ func (b *executorBuilder) buildSimple(v *plan.Simple) Executor {
 	switch s := v.Statement.(type) {
 	case *ast.GrantStmt:
 		return b.buildGrant(s)
+	case *ast.RevokeStmt:
+		return b.buildRevoke(s)
+	case *ast.OptimizeTableStmt, *ast.CheckTableStmt:
+		return &TableMaintenanceExec{Statement: s, schema: v.Schema()}
 	}
 	return &SimpleExec{Statement: v.Statement, ctx: b.ctx, is: b.is}
 }
@@ -276,11 +287,19 @@ func (b *executorBuilder) buildLoadData(v *plan.LoadData) Executor {
 		return nil
 	}
 
+	insertVal := &InsertValues{ctx: b.ctx, Table: tbl, Columns: v.Columns}
+	columns, err := insertVal.getColumns(tbl.Cols())
+	if err != nil {
+		b.err = errors.Trace(err)
+		return nil
+	}
+
 	return &LoadData{
 		IsLocal: v.IsLocal,
 		loadDataInfo: &LoadDataInfo{
-			row:        make([]types.Datum, len(tbl.Cols())),
-			insertVal:  &InsertValues{ctx: b.ctx, Table: tbl},
+			row:        make([]types.Datum, len(columns)),
+			insertVal:  insertVal,
+			columns:    columns,
 			Path:       v.Path,
 			Table:      tbl,
 			FieldsInfo: v.FieldsInfo,
@@ -308,6 +327,18 @@ func (b *executorBuilder) buildGrant(grant *ast.GrantStmt) Executor {
 	}
 }
 
+func (b *executorBuilder) buildRevoke(revoke *ast.RevokeStmt) Executor {
+	return &RevokeExec{
+		ctx:        b.ctx,
+		Privs:      revoke.Privs,
+		ObjectType: revoke.ObjectType,
+		Level:      revoke.Level,
+		Users:      revoke.Users,
+		AllLevels:  revoke.AllLevels,
+		is:         b.is,
+	}
+}
+
 func (b *executorBuilder) buildDDL(v *plan.DDL) Executor {
 	return &DDLExec{Statement: v.Statement, ctx: b.ctx, is: b.is}
 }
@@ -696,6 +727,7 @@ func (b *executorBuilder) buildAnalyze(v *plan.Analyze) Executor {
 		idxOffsets: v.IdxOffsets,
 		colOffsets: v.ColOffsets,
 		pkOffset:   v.PkOffset,
+		numBuckets: int64(v.NumBuckets),
 		Srcs:       make([]Executor, len(v.Children())),
 	}
 	for i, child := range v.Children() {
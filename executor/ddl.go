@@ -116,8 +116,10 @@ func (e *DDLExec) executeTruncateTable(s *ast.TruncateTableStmt) error {
 }
 
 func (e *DDLExec) executeRenameTable(s *ast.RenameTableStmt) error {
-	oldIdent := ast.Ident{Schema: s.OldTable.Schema, Name: s.OldTable.Name}
-	newIdent := ast.Ident{Schema: s.NewTable.Schema, Name: s.NewTable.Name}
+	// The plan builder rejects renaming multiple tables.
+	t := s.TableToTables[0]
+	oldIdent := ast.Ident{Schema: t.OldTable.Schema, Name: t.OldTable.Name}
+	newIdent := ast.Ident{Schema: t.NewTable.Schema, Name: t.NewTable.Name}
 	err := sessionctx.GetDomain(e.ctx).DDL().RenameTable(e.ctx, oldIdent, newIdent)
 	return errors.Trace(err)
 }
@@ -38,6 +38,37 @@ func (s *testSuite) TestTruncateTable(c *C) {
 	result.Check(nil)
 }
 
+func (s *testSuite) TestRecoverTable(c *C) {
+	defer func() {
+		s.cleanEnv(c)
+		testleak.AfterTest(c)()
+	}()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	// Recovering dropped tables is not supported yet.
+	_, err := tk.Exec("recover table t")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("recover table by job 1")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("flashback table t to t1")
+	c.Assert(err, NotNil)
+}
+
+func (s *testSuite) TestSplitRegion(c *C) {
+	defer func() {
+		s.cleanEnv(c)
+		testleak.AfterTest(c)()
+	}()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, index idx(b))")
+	// Pre-splitting regions is not supported yet.
+	_, err := tk.Exec("split table t between (0) and (100) regions 4")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("split table t index idx by (1), (2)")
+	c.Assert(err, NotNil)
+}
+
 func (s *testSuite) TestCreateTable(c *C) {
 	defer func() {
 		s.cleanEnv(c)
@@ -222,6 +253,12 @@ func (s *testSuite) TestRenameTable(c *C) {
 	tk.MustExec("insert rename3.t values ()")
 	tk.MustQuery("select * from rename3.t").Check(testkit.Rows("1", "2", "3"))
 
+	// Renaming multiple tables is not supported as it can't be done atomically yet.
+	tk.MustExec("create table rename1.t (a int)")
+	_, err := tk.Exec("rename table rename1.t to rename2.t, rename3.t to rename1.t1")
+	c.Assert(err, NotNil)
+	tk.MustExec("select * from rename1.t")
+
 	tk.MustExec("drop database rename1")
 	tk.MustExec("drop database rename2")
 	tk.MustExec("drop database rename3")
@@ -63,6 +63,7 @@ var (
 	ErrWrongParamCount = terror.ClassExecutor.New(codeWrongParamCount, "Wrong parameter count")
 	ErrRowKeyCount     = terror.ClassExecutor.New(codeRowKeyCount, "Wrong row key entry count")
 	ErrPrepareDDL      = terror.ClassExecutor.New(codePrepareDDL, "Can not prepare DDL statements")
+	ErrMixedParamMarks = terror.ClassExecutor.New(codeMixedParamMarks, "Can not mix positional and named parameter markers")
 	ErrPasswordNoMatch = terror.ClassExecutor.New(CodePasswordNoMatch, "Can't find any matching row in the user table")
 )
 
@@ -75,6 +76,7 @@ const (
 	codeWrongParamCount terror.ErrCode = 5
 	codeRowKeyCount     terror.ErrCode = 6
 	codePrepareDDL      terror.ErrCode = 7
+	codeMixedParamMarks terror.ErrCode = 8
 	// MySQL error code
 	CodePasswordNoMatch terror.ErrCode = 1133
 	CodeCannotUser      terror.ErrCode = 1396
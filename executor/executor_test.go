@@ -132,6 +132,18 @@ func (s *testSuite) TestAdmin(c *C) {
 	// error table name
 	r, err = tk.Exec("admin check table admin_test_error")
 	c.Assert(err, NotNil)
+	// The other admin statements are not supported yet.
+	for _, ca := range []struct {
+		sql string
+		msg string
+	}{
+		{"admin show ddl jobs", "ADMIN SHOW DDL JOBS is not supported"},
+		{"admin cancel ddl jobs 1, 2", "ADMIN CANCEL DDL JOBS is not supported"},
+		{"admin check index admin_test c1", "ADMIN CHECK INDEX is not supported"},
+	} {
+		_, err = tk.Exec(ca.sql)
+		c.Assert(err, ErrorMatches, ".*"+ca.msg, Commentf("sql: %s", ca.sql))
+	}
 	// different index values
 	ctx := tk.Se.(context.Context)
 	domain := sessionctx.GetDomain(ctx)
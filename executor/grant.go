@@ -64,7 +64,10 @@ func (e *GrantExec) Next() (*Row, error) {
 	// Grant for each user
 	for _, user := range e.Users {
 		// Check if user exists.
-		userName, host := parseUser(user.User)
+		userName, host, err := resolveUser(e.ctx, user.User)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		exists, err := userExists(e.ctx, userName, host)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -103,7 +106,7 @@ func (e *GrantExec) Next() (*Row, error) {
 					return nil, errors.Trace(err)
 				}
 			}
-			err := e.grantPriv(priv, user)
+			err := e.grantPriv(priv, userName, host)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -124,7 +127,7 @@ func (e *GrantExec) Close() error {
 // Check if DB scope privilege entry exists in mysql.DB.
 // If unexists, insert a new one.
 func (e *GrantExec) checkAndInitDBPriv(user string, host string) error {
-	db, err := e.getTargetSchema()
+	db, err := getTargetSchema(e.ctx, e.Level.DBName, e.is)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -142,7 +145,7 @@ func (e *GrantExec) checkAndInitDBPriv(user string, host string) error {
 // Check if table scope privilege entry exists in mysql.Tables_priv.
 // If unexists, insert a new one.
 func (e *GrantExec) checkAndInitTablePriv(user string, host string) error {
-	db, tbl, err := e.getTargetSchemaAndTable()
+	db, tbl, err := getTargetSchemaAndTable(e.ctx, e.Level.DBName, e.Level.TableName, e.is)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -160,7 +163,7 @@ func (e *GrantExec) checkAndInitTablePriv(user string, host string) error {
 // Check if column scope privilege entry exists in mysql.Columns_priv.
 // If unexists, insert a new one.
 func (e *GrantExec) checkAndInitColumnPriv(user string, host string, cols []*ast.ColumnName) error {
-	db, tbl, err := e.getTargetSchemaAndTable()
+	db, tbl, err := getTargetSchemaAndTable(e.ctx, e.Level.DBName, e.Level.TableName, e.is)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -207,57 +210,54 @@ func initColumnPrivEntry(ctx context.Context, user string, host string, db strin
 }
 
 // Grant priv to user in s.Level scope.
-func (e *GrantExec) grantPriv(priv *ast.PrivElem, user *ast.UserSpec) error {
+func (e *GrantExec) grantPriv(priv *ast.PrivElem, userName, host string) error {
 	switch e.Level.Level {
 	case ast.GrantLevelGlobal:
-		return e.grantGlobalPriv(priv, user)
+		return e.grantGlobalPriv(priv, userName, host)
 	case ast.GrantLevelDB:
-		return e.grantDBPriv(priv, user)
+		return e.grantDBPriv(priv, userName, host)
 	case ast.GrantLevelTable:
 		if len(priv.Cols) == 0 {
-			return e.grantTablePriv(priv, user)
+			return e.grantTablePriv(priv, userName, host)
 		}
-		return e.grantColumnPriv(priv, user)
+		return e.grantColumnPriv(priv, userName, host)
 	default:
 		return errors.Errorf("Unknown grant level: %#v", e.Level)
 	}
 }
 
 // Manipulate mysql.user table.
-func (e *GrantExec) grantGlobalPriv(priv *ast.PrivElem, user *ast.UserSpec) error {
-	asgns, err := composeGlobalPrivUpdate(priv.Priv)
+func (e *GrantExec) grantGlobalPriv(priv *ast.PrivElem, userName, host string) error {
+	asgns, err := composeGlobalPrivUpdate(priv.Priv, "Y")
 	if err != nil {
 		return errors.Trace(err)
 	}
-	userName, host := parseUser(user.User)
 	sql := fmt.Sprintf(`UPDATE %s.%s SET %s WHERE User="%s" AND Host="%s"`, mysql.SystemDB, mysql.UserTable, asgns, userName, host)
 	_, err = e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(e.ctx, sql)
 	return errors.Trace(err)
 }
 
 // Manipulate mysql.db table.
-func (e *GrantExec) grantDBPriv(priv *ast.PrivElem, user *ast.UserSpec) error {
-	db, err := e.getTargetSchema()
+func (e *GrantExec) grantDBPriv(priv *ast.PrivElem, userName, host string) error {
+	db, err := getTargetSchema(e.ctx, e.Level.DBName, e.is)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	asgns, err := composeDBPrivUpdate(priv.Priv)
+	asgns, err := composeDBPrivUpdate(priv.Priv, "Y")
 	if err != nil {
 		return errors.Trace(err)
 	}
-	userName, host := parseUser(user.User)
 	sql := fmt.Sprintf(`UPDATE %s.%s SET %s WHERE User="%s" AND Host="%s" AND DB="%s";`, mysql.SystemDB, mysql.DBTable, asgns, userName, host, db.Name.O)
 	_, err = e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(e.ctx, sql)
 	return errors.Trace(err)
 }
 
 // Manipulate mysql.tables_priv table.
-func (e *GrantExec) grantTablePriv(priv *ast.PrivElem, user *ast.UserSpec) error {
-	db, tbl, err := e.getTargetSchemaAndTable()
+func (e *GrantExec) grantTablePriv(priv *ast.PrivElem, userName, host string) error {
+	db, tbl, err := getTargetSchemaAndTable(e.ctx, e.Level.DBName, e.Level.TableName, e.is)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	userName, host := parseUser(user.User)
 	asgns, err := composeTablePrivUpdate(e.ctx, priv.Priv, userName, host, db.Name.O, tbl.Meta().Name.O)
 	if err != nil {
 		return errors.Trace(err)
@@ -268,12 +268,11 @@ func (e *GrantExec) grantTablePriv(priv *ast.PrivElem, user *ast.UserSpec) error
 }
 
 // Manipulate mysql.tables_priv table.
-func (e *GrantExec) grantColumnPriv(priv *ast.PrivElem, user *ast.UserSpec) error {
-	db, tbl, err := e.getTargetSchemaAndTable()
+func (e *GrantExec) grantColumnPriv(priv *ast.PrivElem, userName, host string) error {
+	db, tbl, err := getTargetSchemaAndTable(e.ctx, e.Level.DBName, e.Level.TableName, e.is)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	userName, host := parseUser(user.User)
 	for _, c := range priv.Cols {
 		col := table.FindCol(tbl.Cols(), c.Name.L)
 		if col == nil {
@@ -293,11 +292,11 @@ func (e *GrantExec) grantColumnPriv(priv *ast.PrivElem, user *ast.UserSpec) erro
 }
 
 // Compose update stmt assignment list string for global scope privilege update.
-func composeGlobalPrivUpdate(priv mysql.PrivilegeType) (string, error) {
+func composeGlobalPrivUpdate(priv mysql.PrivilegeType, value string) (string, error) {
 	if priv == mysql.AllPriv {
 		strs := make([]string, 0, len(mysql.Priv2UserCol))
 		for _, v := range mysql.Priv2UserCol {
-			strs = append(strs, fmt.Sprintf(`%s="Y"`, v))
+			strs = append(strs, fmt.Sprintf(`%s="%s"`, v, value))
 		}
 		return strings.Join(strs, ", "), nil
 	}
@@ -305,11 +304,11 @@ func composeGlobalPrivUpdate(priv mysql.PrivilegeType) (string, error) {
 	if !ok {
 		return "", errors.Errorf("Unknown priv: %v", priv)
 	}
-	return fmt.Sprintf(`%s="Y"`, col), nil
+	return fmt.Sprintf(`%s="%s"`, col, value), nil
 }
 
 // Compose update stmt assignment list for db scope privilege update.
-func composeDBPrivUpdate(priv mysql.PrivilegeType) (string, error) {
+func composeDBPrivUpdate(priv mysql.PrivilegeType, value string) (string, error) {
 	if priv == mysql.AllPriv {
 		strs := make([]string, 0, len(mysql.AllDBPrivs))
 		for _, p := range mysql.AllDBPrivs {
@@ -317,7 +316,7 @@ func composeDBPrivUpdate(priv mysql.PrivilegeType) (string, error) {
 			if !ok {
 				return "", errors.Errorf("Unknown db privilege %v", priv)
 			}
-			strs = append(strs, fmt.Sprintf(`%s="Y"`, v))
+			strs = append(strs, fmt.Sprintf(`%s="%s"`, v, value))
 		}
 		return strings.Join(strs, ", "), nil
 	}
@@ -325,7 +324,7 @@ func composeDBPrivUpdate(priv mysql.PrivilegeType) (string, error) {
 	if !ok {
 		return "", errors.Errorf("Unknown priv: %v", priv)
 	}
-	return fmt.Sprintf(`%s="Y"`, col), nil
+	return fmt.Sprintf(`%s="%s"`, col, value), nil
 }
 
 // Compose update stmt assignment list for table scope privilege update.
@@ -493,18 +492,17 @@ func getColumnPriv(ctx context.Context, name string, host string, db string, tbl
 }
 
 // Find the schema by dbName.
-func (e *GrantExec) getTargetSchema() (*model.DBInfo, error) {
-	dbName := e.Level.DBName
+func getTargetSchema(ctx context.Context, dbName string, is infoschema.InfoSchema) (*model.DBInfo, error) {
 	if len(dbName) == 0 {
 		// Grant *, use current schema
-		dbName = e.ctx.GetSessionVars().CurrentDB
+		dbName = ctx.GetSessionVars().CurrentDB
 		if len(dbName) == 0 {
 			return nil, errors.New("miss DB name for grant privilege")
 		}
 	}
 	//check if db exists
 	schema := model.NewCIStr(dbName)
-	db, ok := e.is.SchemaByName(schema)
+	db, ok := is.SchemaByName(schema)
 	if !ok {
 		return nil, errors.Errorf("Unknown schema name: %s", dbName)
 	}
@@ -512,13 +510,13 @@ func (e *GrantExec) getTargetSchema() (*model.DBInfo, error) {
 }
 
 // Find the schema and table by dbName and tableName.
-func (e *GrantExec) getTargetSchemaAndTable() (*model.DBInfo, table.Table, error) {
-	db, err := e.getTargetSchema()
+func getTargetSchemaAndTable(ctx context.Context, dbName, tableName string, is infoschema.InfoSchema) (*model.DBInfo, table.Table, error) {
+	db, err := getTargetSchema(ctx, dbName, is)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-	name := model.NewCIStr(e.Level.TableName)
-	tbl, err := e.is.TableByName(db.Name, name)
+	name := model.NewCIStr(tableName)
+	tbl, err := is.TableByName(db.Name, name)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -134,7 +134,7 @@ func StatementLabel(node ast.StmtNode, p plan.Plan) string {
 		return RollBack
 	case *ast.SelectStmt:
 		return getSelectStmtLabel(x, p)
-	case *ast.SetStmt, *ast.SetPwdStmt:
+	case *ast.SetStmt, *ast.SetNamesStmt, *ast.SetPwdStmt:
 		return Set
 	case *ast.ShowStmt:
 		return Show
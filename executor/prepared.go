@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/plan"
 	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/types"
 )
 
 var (
@@ -66,8 +67,11 @@ func (e *paramMarkerExtractor) Leave(in ast.Node) (ast.Node, bool) {
 
 // Prepared represents a prepared statement.
 type Prepared struct {
-	Stmt          ast.StmtNode
-	Params        []*ast.ParamMarkerExpr
+	Stmt   ast.StmtNode
+	Params []*ast.ParamMarkerExpr
+	// ParamNames is set if the statement uses named parameter markers,
+	// the using variables are paired with the names instead of Params.
+	ParamNames    []string
 	SchemaVersion int64
 }
 
@@ -77,6 +81,9 @@ type PrepareExec struct {
 	Ctx     context.Context
 	Name    string
 	SQLText string
+	// Stmt is the PREPARE statement, it is nil for the binary protocol.
+	// Its SQLStmt and ParamNames are set when the statement is prepared.
+	Stmt *ast.PrepareStmt
 
 	ID         uint32
 	ParamCount int
@@ -152,10 +159,19 @@ func (e *PrepareExec) DoPrepare() {
 	// sort it by position.
 	sorter := &paramMarkerSorter{markers: extractor.markers}
 	sort.Sort(sorter)
+	names, mixed := ast.ParamMarkerNames(sorter.markers)
+	if mixed {
+		e.Err = errors.Trace(ErrMixedParamMarks)
+		return
+	}
 	e.ParamCount = len(sorter.markers)
+	if names != nil {
+		e.ParamCount = len(names)
+	}
 	prepared := &Prepared{
 		Stmt:          stmt,
 		Params:        sorter.markers,
+		ParamNames:    names,
 		SchemaVersion: e.IS.SchemaMetaVersion(),
 	}
 
@@ -172,6 +188,10 @@ func (e *PrepareExec) DoPrepare() {
 		vars.PreparedStmtNameToID[e.Name] = e.ID
 	}
 	vars.PreparedStmts[e.ID] = prepared
+	if e.Stmt != nil {
+		e.Stmt.SQLStmt = stmt
+		e.Stmt.ParamNames = names
+	}
 }
 
 // ExecuteExec represents an EXECUTE executor.
@@ -206,6 +226,26 @@ func (e *ExecuteExec) Close() error {
 	return nil
 }
 
+// setNamedParams pairs the using variables with the parameter names by position,
+// and sets the values to the parameter markers with the same name.
+func (e *ExecuteExec) setNamedParams(prepared *Prepared) error {
+	if len(prepared.ParamNames) != len(e.UsingVars) {
+		return errors.Trace(ErrWrongParamCount)
+	}
+	values := make(map[string]types.Datum, len(prepared.ParamNames))
+	for i, usingVar := range e.UsingVars {
+		val, err := usingVar.Eval(nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		values[prepared.ParamNames[i]] = val
+	}
+	for _, param := range prepared.Params {
+		param.SetDatum(values[param.Name])
+	}
+	return nil
+}
+
 // Build builds a prepared statement into an executor.
 // After Build, e.StmtExec will be used to do the real execution.
 func (e *ExecuteExec) Build() error {
@@ -219,16 +259,22 @@ func (e *ExecuteExec) Build() error {
 	}
 	prepared := v.(*Prepared)
 
-	if len(prepared.Params) != len(e.UsingVars) {
-		return errors.Trace(ErrWrongParamCount)
-	}
-
-	for i, usingVar := range e.UsingVars {
-		val, err := usingVar.Eval(nil)
+	if prepared.ParamNames != nil {
+		err := e.setNamedParams(prepared)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		prepared.Params[i].SetDatum(val)
+	} else {
+		if len(prepared.Params) != len(e.UsingVars) {
+			return errors.Trace(ErrWrongParamCount)
+		}
+		for i, usingVar := range e.UsingVars {
+			val, err := usingVar.Eval(nil)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			prepared.Params[i].SetDatum(val)
+		}
 	}
 
 	if prepared.SchemaVersion != e.IS.SchemaMetaVersion() {
@@ -265,8 +311,10 @@ func (e *ExecuteExec) Build() error {
 
 // DeallocateExec represent a DEALLOCATE executor.
 type DeallocateExec struct {
-	Name string
-	ctx  context.Context
+	Name    string
+	ID      uint32
+	UsingID bool
+	ctx     context.Context
 }
 
 // Schema implements the Executor Schema interface.
@@ -278,6 +326,18 @@ func (e *DeallocateExec) Schema() *expression.Schema {
 // Next implements the Executor Next interface.
 func (e *DeallocateExec) Next() (*Row, error) {
 	vars := e.ctx.GetSessionVars()
+	if e.UsingID {
+		if _, ok := vars.PreparedStmts[e.ID]; !ok {
+			return nil, errors.Trace(ErrStmtNotFound)
+		}
+		for name, id := range vars.PreparedStmtNameToID {
+			if id == e.ID {
+				delete(vars.PreparedStmtNameToID, name)
+			}
+		}
+		delete(vars.PreparedStmts, e.ID)
+		return nil, nil
+	}
 	id, ok := vars.PreparedStmtNameToID[e.Name]
 	if !ok {
 		return nil, errors.Trace(ErrStmtNotFound)
@@ -15,7 +15,10 @@ package executor_test
 
 import (
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/context"
 	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/plan"
 	"github.com/pingcap/tidb/util/testkit"
 	"github.com/pingcap/tidb/util/testleak"
@@ -34,12 +37,26 @@ func (s *testSuite) TestPrepared(c *C) {
 
 	tk.MustExec(`prepare stmt_test_1 from 'select id from prepare_test where id > ?'; set @a = 1; execute stmt_test_1 using @a;`)
 	tk.MustExec(`prepare stmt_test_2 from 'select 1'`)
+	// The trailing empty statements are not counted.
+	tk.MustExec(`prepare stmt_test_8 from 'select 1;;'`)
+	tk.MustQuery(`execute stmt_test_8`).Check(testkit.Rows("1"))
+	tk.MustExec(`prepare stmt_test_9 from ';;'`)
+	tk.MustExec(`execute stmt_test_9`)
 	// Prepare multiple statement is not allowed.
 	_, err := tk.Exec(`prepare stmt_test_3 from 'select id from prepare_test where id > ?;select id from prepare_test where id > ?;'`)
 	c.Assert(executor.ErrPrepareMulti.Equal(err), IsTrue)
 	// The variable count does not match.
 	_, err = tk.Exec(`prepare stmt_test_4 from 'select id from prepare_test where id > ? and id < ?'; set @a = 1; execute stmt_test_4 using @a;`)
 	c.Assert(executor.ErrWrongParamCount.Equal(err), IsTrue)
+	// Named parameter markers are paired with the variables by name.
+	tk.MustExec(`prepare stmt_test_6 from 'select id from prepare_test where id > :low and id < :high and id <> :low'`)
+	tk.MustExec(`set @a = 1, @b = 3`)
+	tk.MustQuery(`execute stmt_test_6 using @a, @b`).Check(testkit.Rows("2"))
+	_, err = tk.Exec(`execute stmt_test_6 using @a, @b, @a`)
+	c.Assert(executor.ErrWrongParamCount.Equal(err), IsTrue)
+	// Positional and named parameter markers can not be mixed.
+	_, err = tk.Exec(`prepare stmt_test_7 from 'select id from prepare_test where id > :low and id < ?'`)
+	c.Assert(executor.ErrMixedParamMarks.Equal(err), IsTrue)
 	// Prepare and deallocate prepared statement immediately.
 	tk.MustExec(`prepare stmt_test_5 from 'select id from prepare_test where id > ?'; deallocate prepare stmt_test_5;`)
 
@@ -80,12 +97,92 @@ func (s *testSuite) TestPrepared(c *C) {
 	_, err = tk.Se.ExecutePreparedStmt(stmtId, 1)
 	c.Assert(executor.ErrSchemaChanged.Equal(err), IsTrue)
 
+	// Deallocate a statement prepared by the binary protocol by its ID.
+	stmtNode := &ast.DeallocateStmt{ID: stmtId, UsingID: true}
+	ctx := tk.Se.(context.Context)
+	compiler := &executor.Compiler{}
+	dealloc, err := compiler.Compile(ctx, stmtNode)
+	c.Assert(err, IsNil)
+	c.Assert(ctx.NewTxn(), IsNil)
+	_, err = dealloc.Exec(ctx)
+	c.Assert(err, IsNil)
+	_, err = tk.Se.ExecutePreparedStmt(stmtId, 1)
+	c.Assert(executor.ErrStmtNotFound.Equal(err), IsTrue)
+
 	// Coverage.
 	exec := &executor.ExecuteExec{}
 	exec.Next()
 	exec.Close()
 }
 
+func (s *testSuite) TestPrepareStmtParamCount(c *C) {
+	defer func() {
+		s.cleanEnv(c)
+		testleak.AfterTest(c)()
+	}()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists prepare_test")
+	tk.MustExec("create table prepare_test (id int PRIMARY KEY AUTO_INCREMENT, c1 int)")
+	cases := []struct {
+		sql   string
+		count int
+	}{
+		{"prepare s from 'select 1'", 0},
+		{"prepare s from 'select id from prepare_test where id > ? and c1 < ?'", 2},
+		{"prepare s from 'select abs(?) + 1 from prepare_test where id in (select c1 from prepare_test where c1 > ?)'", 2},
+		{"prepare s from 'insert into prepare_test (c1) values (?), (?), (?)'", 3},
+	}
+	ctx := tk.Se.(context.Context)
+	for _, ca := range cases {
+		stmtNode, err := parser.New().ParseOneStmt(ca.sql, "", "")
+		c.Assert(err, IsNil)
+		prepare := stmtNode.(*ast.PrepareStmt)
+		c.Assert(prepare.SQLStmt, IsNil)
+		compiler := &executor.Compiler{}
+		stmt, err := compiler.Compile(ctx, stmtNode)
+		c.Assert(err, IsNil)
+		c.Assert(ctx.NewTxn(), IsNil)
+		_, err = stmt.Exec(ctx)
+		c.Assert(err, IsNil)
+		c.Assert(prepare.SQLStmt, NotNil)
+		c.Assert(prepare.ParamCount(), Equals, ca.count, Commentf("sql %s", ca.sql))
+	}
+}
+
+func (s *testSuite) TestPrepareStmtParamNames(c *C) {
+	defer func() {
+		s.cleanEnv(c)
+		testleak.AfterTest(c)()
+	}()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists prepare_test")
+	tk.MustExec("create table prepare_test (id int PRIMARY KEY AUTO_INCREMENT, c1 int)")
+	cases := []struct {
+		sql   string
+		names []string
+	}{
+		{"prepare s from 'select id from prepare_test where id > ?'", nil},
+		{"prepare s from 'select id from prepare_test where id > :low and id < :high and c1 <> :low'", []string{"low", "high"}},
+		{"prepare s from 'select :b + 1 from prepare_test where id in (select c1 from prepare_test where c1 > :a)'", []string{"b", "a"}},
+	}
+	ctx := tk.Se.(context.Context)
+	for _, ca := range cases {
+		stmtNode, err := parser.New().ParseOneStmt(ca.sql, "", "")
+		c.Assert(err, IsNil)
+		prepare := stmtNode.(*ast.PrepareStmt)
+		c.Assert(prepare.ParamNames, IsNil)
+		compiler := &executor.Compiler{}
+		stmt, err := compiler.Compile(ctx, stmtNode)
+		c.Assert(err, IsNil)
+		c.Assert(ctx.NewTxn(), IsNil)
+		_, err = stmt.Exec(ctx)
+		c.Assert(err, IsNil)
+		c.Assert(prepare.ParamNames, DeepEquals, ca.names, Commentf("sql %s", ca.sql))
+	}
+}
+
 func (s *testSuite) TestPreparedLimitOffset(c *C) {
 	defer func() {
 		s.cleanEnv(c)
@@ -0,0 +1,266 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/context"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+/***
+ * Revoke Statement
+ * See https://dev.mysql.com/doc/refman/5.7/en/revoke.html
+ ************************************************************************************/
+var (
+	_ Executor = (*RevokeExec)(nil)
+)
+
+// RevokeExec executes RevokeStmt.
+type RevokeExec struct {
+	Privs      []*ast.PrivElem
+	ObjectType ast.ObjectTypeType
+	Level      *ast.GrantLevel
+	Users      []*ast.UserSpec
+	AllLevels  bool
+
+	ctx  context.Context
+	is   infoschema.InfoSchema
+	done bool
+}
+
+// Schema implements the Executor Schema interface.
+func (e *RevokeExec) Schema() *expression.Schema {
+	return expression.NewSchema()
+}
+
+// Next implements Execution Next interface.
+func (e *RevokeExec) Next() (*Row, error) {
+	if e.done {
+		return nil, nil
+	}
+	// Revoke for each user
+	for _, user := range e.Users {
+		// Check if user exists.
+		userName, host, err := resolveUser(e.ctx, user.User)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		exists, err := userExists(e.ctx, userName, host)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !exists {
+			return nil, errors.Errorf("Unknown user: %s", user.User)
+		}
+
+		if e.AllLevels {
+			err = e.revokeAllLevels(userName, host)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		for _, priv := range e.Privs {
+			err := e.revokePriv(priv, userName, host)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
+	e.done = true
+	// Flush privileges.
+	dom := sessionctx.GetDomain(e.ctx)
+	err := dom.PrivilegeHandle().Update()
+	return nil, errors.Trace(err)
+}
+
+// Close implements the Executor Close interface.
+func (e *RevokeExec) Close() error {
+	return nil
+}
+
+// Revoke priv from user in s.Level scope.
+func (e *RevokeExec) revokePriv(priv *ast.PrivElem, user, host string) error {
+	switch e.Level.Level {
+	case ast.GrantLevelGlobal:
+		return e.revokeGlobalPriv(priv, user, host)
+	case ast.GrantLevelDB:
+		return e.revokeDBPriv(priv, user, host)
+	case ast.GrantLevelTable:
+		if len(priv.Cols) == 0 {
+			return e.revokeTablePriv(priv, user, host)
+		}
+		return e.revokeColumnPriv(priv, user, host)
+	default:
+		return errors.Errorf("Unknown revoke level: %#v", e.Level)
+	}
+}
+
+// revokeAllLevels removes the database, table and column level privileges of the user,
+// the global ones are revoked by revokeGlobalPriv.
+func (e *RevokeExec) revokeAllLevels(user, host string) error {
+	for _, tbl := range []string{mysql.DBTable, mysql.TablePrivTable, mysql.ColumnPrivTable} {
+		sql := fmt.Sprintf(`DELETE FROM %s.%s WHERE User="%s" AND Host="%s";`, mysql.SystemDB, tbl, user, host)
+		_, err := e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(e.ctx, sql)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Manipulate mysql.user table.
+func (e *RevokeExec) revokeGlobalPriv(priv *ast.PrivElem, user, host string) error {
+	asgns, err := composeGlobalPrivUpdate(priv.Priv, "N")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sql := fmt.Sprintf(`UPDATE %s.%s SET %s WHERE User="%s" AND Host="%s"`, mysql.SystemDB, mysql.UserTable, asgns, user, host)
+	_, err = e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(e.ctx, sql)
+	return errors.Trace(err)
+}
+
+// Manipulate mysql.db table.
+func (e *RevokeExec) revokeDBPriv(priv *ast.PrivElem, user, host string) error {
+	db, err := getTargetSchema(e.ctx, e.Level.DBName, e.is)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	exists, err := dbUserExists(e.ctx, user, host, db.Name.O)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !exists {
+		return errors.Errorf("There is no such grant defined for user '%s' on host '%s'", user, host)
+	}
+	asgns, err := composeDBPrivUpdate(priv.Priv, "N")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sql := fmt.Sprintf(`UPDATE %s.%s SET %s WHERE User="%s" AND Host="%s" AND DB="%s";`, mysql.SystemDB, mysql.DBTable, asgns, user, host, db.Name.O)
+	_, err = e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(e.ctx, sql)
+	return errors.Trace(err)
+}
+
+// Manipulate mysql.tables_priv table.
+func (e *RevokeExec) revokeTablePriv(priv *ast.PrivElem, user, host string) error {
+	db, tbl, err := getTargetSchemaAndTable(e.ctx, e.Level.DBName, e.Level.TableName, e.is)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	exists, err := tableUserExists(e.ctx, user, host, db.Name.O, tbl.Meta().Name.O)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !exists {
+		return errors.Errorf("There is no such grant defined for user '%s' on host '%s' on table %s", user, host, tbl.Meta().Name.O)
+	}
+	asgns, err := composeTablePrivRevoke(e.ctx, priv.Priv, user, host, db.Name.O, tbl.Meta().Name.O)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sql := fmt.Sprintf(`UPDATE %s.%s SET %s WHERE User="%s" AND Host="%s" AND DB="%s" AND Table_name="%s";`, mysql.SystemDB, mysql.TablePrivTable, asgns, user, host, db.Name.O, tbl.Meta().Name.O)
+	_, err = e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(e.ctx, sql)
+	return errors.Trace(err)
+}
+
+// Manipulate mysql.columns_priv table.
+func (e *RevokeExec) revokeColumnPriv(priv *ast.PrivElem, user, host string) error {
+	db, tbl, err := getTargetSchemaAndTable(e.ctx, e.Level.DBName, e.Level.TableName, e.is)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, c := range priv.Cols {
+		col := table.FindCol(tbl.Cols(), c.Name.L)
+		if col == nil {
+			return errors.Errorf("Unknown column: %s", c.Name.O)
+		}
+		exists, err := columnPrivEntryExists(e.ctx, user, host, db.Name.O, tbl.Meta().Name.O, col.Name.O)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !exists {
+			return errors.Errorf("There is no such grant defined for user '%s' on host '%s' on column %s", user, host, col.Name.O)
+		}
+		asgns, err := composeColumnPrivRevoke(e.ctx, priv.Priv, user, host, db.Name.O, tbl.Meta().Name.O, col.Name.O)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sql := fmt.Sprintf(`UPDATE %s.%s SET %s WHERE User="%s" AND Host="%s" AND DB="%s" AND Table_name="%s" AND Column_name="%s";`, mysql.SystemDB, mysql.ColumnPrivTable, asgns, user, host, db.Name.O, tbl.Meta().Name.O, col.Name.O)
+		_, err = e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(e.ctx, sql)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Compose update stmt assignment list for table scope privilege revoke.
+func composeTablePrivRevoke(ctx context.Context, priv mysql.PrivilegeType, name string, host string, db string, tbl string) (string, error) {
+	var newTablePriv, newColumnPriv string
+	if priv != mysql.AllPriv {
+		currTablePriv, currColumnPriv, err := getTablePriv(ctx, name, host, db, tbl)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		p, ok := mysql.Priv2SetStr[priv]
+		if !ok {
+			return "", errors.Errorf("Unknown priv: %v", priv)
+		}
+		newTablePriv = removePrivFromSet(currTablePriv, p)
+		newColumnPriv = removePrivFromSet(currColumnPriv, p)
+	}
+	return fmt.Sprintf(`Table_priv="%s", Column_priv="%s", Grantor="%s"`, newTablePriv, newColumnPriv, ctx.GetSessionVars().User), nil
+}
+
+// Compose update stmt assignment list for column scope privilege revoke.
+func composeColumnPrivRevoke(ctx context.Context, priv mysql.PrivilegeType, name string, host string, db string, tbl string, col string) (string, error) {
+	var newColumnPriv string
+	if priv != mysql.AllPriv {
+		currColumnPriv, err := getColumnPriv(ctx, name, host, db, tbl, col)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		p, ok := mysql.Priv2SetStr[priv]
+		if !ok {
+			return "", errors.Errorf("Unknown priv: %v", priv)
+		}
+		newColumnPriv = removePrivFromSet(currColumnPriv, p)
+	}
+	return fmt.Sprintf(`Column_priv="%s"`, newColumnPriv), nil
+}
+
+// removePrivFromSet removes priv from the comma separated privilege set.
+func removePrivFromSet(set string, priv string) string {
+	if len(set) == 0 {
+		return set
+	}
+	privs := strings.Split(set, ",")
+	kept := privs[:0]
+	for _, p := range privs {
+		if !strings.EqualFold(p, priv) {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, ",")
+}
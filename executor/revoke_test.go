@@ -0,0 +1,138 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/mysql"
+	"github.com/pingcap/tidb/util/testkit"
+	"github.com/pingcap/tidb/util/testleak"
+)
+
+func (s *testSuite) TestRevokeGlobal(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+
+	_, err := tk.Exec(`REVOKE ALL PRIVILEGES ON *.* FROM 'nonexistuser'@'host'`)
+	c.Assert(err, NotNil)
+
+	// Create a new user.
+	createUserSQL := `CREATE USER 'testGlobalRevoke'@'localhost' IDENTIFIED BY '123';`
+	tk.MustExec(createUserSQL)
+	grantPrivSQL := `GRANT ALL PRIVILEGES ON *.* to 'testGlobalRevoke'@'localhost';`
+	tk.MustExec(grantPrivSQL)
+
+	// Revoke each priv from the user.
+	for _, v := range mysql.AllGlobalPrivs {
+		sql := fmt.Sprintf("SELECT %s FROM mysql.User WHERE User=\"testGlobalRevoke\" and host=\"localhost\"", mysql.Priv2UserCol[v])
+		tk.MustQuery(sql).Check(testkit.Rows("Y"))
+		sql = fmt.Sprintf("REVOKE %s ON *.* FROM 'testGlobalRevoke'@'localhost';", mysql.Priv2Str[v])
+		tk.MustExec(sql)
+		sql = fmt.Sprintf("SELECT %s FROM mysql.User WHERE User=\"testGlobalRevoke\" and host=\"localhost\"", mysql.Priv2UserCol[v])
+		tk.MustQuery(sql).Check(testkit.Rows("N"))
+	}
+
+	// Revoke all privileges and the grant option at once.
+	tk.MustExec(grantPrivSQL)
+	tk.MustExec(`REVOKE ALL PRIVILEGES, GRANT OPTION ON *.* FROM 'testGlobalRevoke'@'localhost'`)
+	for _, v := range mysql.AllGlobalPrivs {
+		sql := fmt.Sprintf("SELECT %s FROM mysql.User WHERE User=\"testGlobalRevoke\" and host=\"localhost\"", mysql.Priv2UserCol[v])
+		tk.MustQuery(sql).Check(testkit.Rows("N"))
+	}
+
+	// The ON clause may be omitted when revoking everything.
+	tk.MustExec(grantPrivSQL)
+	tk.MustExec(`CREATE TABLE test.test_revoke_all(c1 int);`)
+	tk.MustExec(`GRANT ALL ON test.* TO 'testGlobalRevoke'@'localhost';`)
+	tk.MustExec(`GRANT ALL ON test.test_revoke_all TO 'testGlobalRevoke'@'localhost';`)
+	tk.MustExec(`GRANT SELECT(c1) ON test.test_revoke_all TO 'testGlobalRevoke'@'localhost';`)
+	tk.MustExec(`REVOKE ALL PRIVILEGES, GRANT OPTION FROM 'testGlobalRevoke'@'localhost'`)
+	for _, v := range mysql.AllGlobalPrivs {
+		sql := fmt.Sprintf("SELECT %s FROM mysql.User WHERE User=\"testGlobalRevoke\" and host=\"localhost\"", mysql.Priv2UserCol[v])
+		tk.MustQuery(sql).Check(testkit.Rows("N"))
+	}
+	for _, tbl := range []string{"mysql.DB", "mysql.Tables_priv", "mysql.Columns_priv"} {
+		sql := fmt.Sprintf("SELECT count(*) FROM %s WHERE User=\"testGlobalRevoke\" and host=\"localhost\"", tbl)
+		tk.MustQuery(sql).Check(testkit.Rows("0"))
+	}
+}
+
+func (s *testSuite) TestRevokeDBScope(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Create a new user.
+	tk.MustExec(`CREATE USER 'testDBRevoke'@'localhost' IDENTIFIED BY '123';`)
+	// There is no grant on db test yet.
+	_, err := tk.Exec(`REVOKE SELECT ON test.* FROM 'testDBRevoke'@'localhost'`)
+	c.Assert(err, NotNil)
+
+	tk.MustExec(`GRANT ALL ON test.* TO 'testDBRevoke'@'localhost';`)
+	// Revoke each priv from the user.
+	for _, v := range mysql.AllDBPrivs {
+		sql := fmt.Sprintf("REVOKE %s ON test.* FROM 'testDBRevoke'@'localhost';", mysql.Priv2Str[v])
+		tk.MustExec(sql)
+		sql = fmt.Sprintf("SELECT %s FROM mysql.DB WHERE User=\"testDBRevoke\" and host=\"localhost\" and db=\"test\"", mysql.Priv2UserCol[v])
+		tk.MustQuery(sql).Check(testkit.Rows("N"))
+	}
+}
+
+func (s *testSuite) TestRevokeTableScope(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Create a new user.
+	tk.MustExec(`CREATE USER 'testTblRevoke'@'localhost' IDENTIFIED BY '123';`)
+	tk.MustExec(`CREATE TABLE test.test4(c1 int);`)
+	tk.MustExec(`GRANT ALL ON test.test4 TO 'testTblRevoke'@'localhost';`)
+
+	// Revoke each priv from the user.
+	for _, v := range mysql.AllTablePrivs {
+		sql := fmt.Sprintf("REVOKE %s ON test.test4 FROM 'testTblRevoke'@'localhost';", mysql.Priv2Str[v])
+		tk.MustExec(sql)
+		rows := tk.MustQuery(`SELECT Table_priv FROM mysql.Tables_priv WHERE User="testTblRevoke" and host="localhost" and db="test" and Table_name="test4";`).Rows()
+		c.Assert(rows, HasLen, 1)
+		p := fmt.Sprintf("%v", rows[0][0])
+		c.Assert(strings.Index(p, mysql.Priv2SetStr[v]), Equals, -1)
+	}
+
+	// Revoke all table scope privs.
+	tk.MustExec(`GRANT ALL ON test.test4 TO 'testTblRevoke'@'localhost';`)
+	tk.MustExec(`REVOKE ALL ON test.test4 FROM 'testTblRevoke'@'localhost';`)
+	tk.MustQuery(`SELECT Table_priv FROM mysql.Tables_priv WHERE User="testTblRevoke" and host="localhost" and db="test" and Table_name="test4";`).Check(testkit.Rows(""))
+}
+
+func (s *testSuite) TestRevokeColumnScope(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Create a new user.
+	tk.MustExec(`CREATE USER 'testColRevoke'@'localhost' IDENTIFIED BY '123';`)
+	tk.MustExec(`CREATE TABLE test.test5(c1 int, c2 int);`)
+
+	// There is no grant on the column yet.
+	_, err := tk.Exec(`REVOKE SELECT(c1) ON test.test5 FROM 'testColRevoke'@'localhost'`)
+	c.Assert(err, NotNil)
+
+	tk.MustExec(`GRANT ALL(c1) ON test.test5 TO 'testColRevoke'@'localhost';`)
+	// Revoke each priv from the user.
+	for _, v := range mysql.AllColumnPrivs {
+		sql := fmt.Sprintf("REVOKE %s(c1) ON test.test5 FROM 'testColRevoke'@'localhost';", mysql.Priv2Str[v])
+		tk.MustExec(sql)
+		rows := tk.MustQuery(`SELECT Column_priv FROM mysql.Columns_priv WHERE User="testColRevoke" and host="localhost" and db="test" and Table_name="test5" and Column_name="c1";`).Rows()
+		c.Assert(rows, HasLen, 1)
+		p := fmt.Sprintf("%v", rows[0][0])
+		c.Assert(strings.Index(p, mysql.Priv2SetStr[v]), Equals, -1)
+	}
+}
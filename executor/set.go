@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb/ast"
 	"github.com/pingcap/tidb/context"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/sessionctx/varsutil"
@@ -55,8 +56,12 @@ func (e *SetExecutor) executeSet() error {
 		// Variable is case insensitive, we use lower case.
 		if v.Name == ast.SetNames {
 			// This is set charset stmt.
-			cs := v.Expr.(*expression.Constant).Value.GetString()
-			var co string
+			var cs, co string
+			if v.IsDefault {
+				cs = mysql.DefaultCharset
+			} else {
+				cs = v.Expr.(*expression.Constant).Value.GetString()
+			}
 			if v.ExtendValue != nil {
 				co = v.ExtendValue.Value.GetString()
 			}
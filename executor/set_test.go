@@ -147,6 +147,19 @@ func (s *testSuite) TestSetCharset(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(sVar, Equals, "utf8_general_ci")
 
+	tk.MustExec(`SET NAMES utf8mb4 COLLATE utf8mb4_bin`)
+	sVar, err = varsutil.GetSessionSystemVar(sessionVars, variable.CollationConnection)
+	c.Assert(err, IsNil)
+	c.Assert(sVar, Equals, "utf8mb4_bin")
+
+	tk.MustExec(`SET NAMES latin1`)
+	tk.MustExec(`SET NAMES DEFAULT`)
+	for _, v := range variable.SetNamesVariables {
+		sVar, err = varsutil.GetSessionSystemVar(sessionVars, v)
+		c.Assert(err, IsNil)
+		c.Assert(sVar, Equals, "utf8")
+	}
+
 	// Issue 1523
 	tk.MustExec(`SET NAMES binary`)
 }
@@ -46,6 +46,8 @@ type ShowExec struct {
 
 	// Used by show variables
 	GlobalScope bool
+	// Used by show warnings/errors.
+	CountOnly bool
 
 	schema *expression.Schema
 	ctx    context.Context
@@ -93,6 +95,16 @@ func (e *ShowExec) fetchAll() error {
 		return e.fetchShowDatabases()
 	case ast.ShowEngines:
 		return e.fetchShowEngines()
+	case ast.ShowPlugins:
+		return e.fetchShowPlugins()
+	case ast.ShowOpenTables:
+		return e.fetchShowOpenTables()
+	case ast.ShowPrivileges:
+		return e.fetchShowPrivileges()
+	case ast.ShowBindings:
+		return e.fetchShowBindings()
+	case ast.ShowProfiles, ast.ShowProfile:
+		return e.fetchShowProfiles()
 	case ast.ShowGrants:
 		return e.fetchShowGrants()
 	case ast.ShowIndex:
@@ -109,12 +121,22 @@ func (e *ShowExec) fetchAll() error {
 		return e.fetchShowTriggers()
 	case ast.ShowVariables:
 		return e.fetchShowVariables()
-	case ast.ShowWarnings, ast.ShowProcessList, ast.ShowEvents:
+	case ast.ShowWarnings, ast.ShowErrors:
+		return e.fetchShowWarnings()
+	case ast.ShowProcessList, ast.ShowEvents:
 		// empty result
 	}
 	return nil
 }
 
+func (e *ShowExec) fetchShowWarnings() error {
+	// We don't keep warnings and errors for now, so the result is always empty.
+	if e.CountOnly {
+		e.rows = append(e.rows, &Row{Data: types.MakeDatums(0)})
+	}
+	return nil
+}
+
 func (e *ShowExec) fetchShowEngines() error {
 	row := &Row{
 		Data: types.MakeDatums(
@@ -130,6 +152,54 @@ func (e *ShowExec) fetchShowEngines() error {
 	return nil
 }
 
+// fetchShowPlugins returns no rows, TiDB doesn't support plugins.
+func (e *ShowExec) fetchShowPlugins() error {
+	return nil
+}
+
+// fetchShowOpenTables returns no rows, TiDB doesn't cache opened tables.
+func (e *ShowExec) fetchShowOpenTables() error {
+	return nil
+}
+
+// fetchShowBindings returns no rows, SQL binding is not supported yet.
+func (e *ShowExec) fetchShowBindings() error {
+	return nil
+}
+
+// fetchShowProfiles returns no rows for SHOW PROFILES and SHOW PROFILE, statements are not profiled.
+func (e *ShowExec) fetchShowProfiles() error {
+	return nil
+}
+
+// privilegeDescs describes the privileges in mysql.AllGlobalPrivs by their context and comment.
+var privilegeDescs = map[mysql.PrivilegeType]struct {
+	context string
+	comment string
+}{
+	mysql.SelectPriv:     {"Tables", "To retrieve rows from table"},
+	mysql.InsertPriv:     {"Tables", "To insert data into tables"},
+	mysql.UpdatePriv:     {"Tables", "To update existing rows"},
+	mysql.DeletePriv:     {"Tables", "To delete existing rows"},
+	mysql.CreatePriv:     {"Databases,Tables,Indexes", "To create new databases and tables"},
+	mysql.DropPriv:       {"Databases,Tables", "To drop databases, tables, and views"},
+	mysql.GrantPriv:      {"Databases,Tables,Functions,Procedures", "To give to other users those privileges you possess"},
+	mysql.AlterPriv:      {"Tables", "To alter the table"},
+	mysql.ShowDBPriv:     {"Server Admin", "To see all databases with SHOW DATABASES"},
+	mysql.ExecutePriv:    {"Functions,Procedures", "To execute stored routines"},
+	mysql.IndexPriv:      {"Tables", "To create or drop indexes"},
+	mysql.CreateUserPriv: {"Server Admin", "To create new users"},
+}
+
+func (e *ShowExec) fetchShowPrivileges() error {
+	for _, priv := range mysql.AllGlobalPrivs {
+		d := privilegeDescs[priv]
+		row := &Row{Data: types.MakeDatums(mysql.Priv2Str[priv], d.context, d.comment)}
+		e.rows = append(e.rows, row)
+	}
+	return nil
+}
+
 func (e *ShowExec) fetchShowDatabases() error {
 	dbs := e.is.AllSchemaNames()
 	// TODO: let information_schema be the first database
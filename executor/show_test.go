@@ -15,6 +15,7 @@ package executor_test
 
 import (
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/mysql"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util/testkit"
 	"github.com/pingcap/tidb/util/testleak"
@@ -99,6 +100,10 @@ func (s *testSuite) TestShow(c *C) {
 	c.Check(rows, HasLen, 1)
 	c.Check(rows[0], DeepEquals, []interface{}{"SHOW_test"})
 
+	// For show with limit
+	tk.MustQuery("show tables like 'show%' limit 1").Check(testkit.Rows("SHOW_test"))
+	tk.MustQuery("show tables like 'show%' limit 1, 1").Check(testkit.Rows())
+
 	var ss stats
 	variable.RegisterStatistics(ss)
 	testSQL = "show status like 'character_set_results';"
@@ -108,7 +113,14 @@ func (s *testSuite) TestShow(c *C) {
 	tk.MustQuery("SHOW PROCEDURE STATUS WHERE Db='test'").Check(testkit.Rows())
 	tk.MustQuery("SHOW TRIGGERS WHERE Trigger ='test'").Check(testkit.Rows())
 	tk.MustQuery("SHOW processlist;").Check(testkit.Rows())
+	tk.MustQuery("SHOW FULL processlist;").Check(testkit.Rows())
+	_, err := tk.Exec("SHOW GRANTS FOR 'root'@'%' USING 'r1'")
+	c.Assert(err, NotNil)
 	tk.MustQuery("SHOW EVENTS WHERE Db = 'test'").Check(testkit.Rows())
+	tk.MustQuery("SHOW WARNINGS LIMIT 1").Check(testkit.Rows())
+	tk.MustQuery("SHOW ERRORS").Check(testkit.Rows())
+	tk.MustQuery("SHOW COUNT(*) WARNINGS").Check(testkit.Rows("0"))
+	tk.MustQuery("SHOW COUNT(*) ERRORS").Check(testkit.Rows("0"))
 	// Test show create database
 	testSQL = `create database show_test_DB`
 	tk.MustExec(testSQL)
@@ -125,6 +137,66 @@ func (s *testSuite) TestShow(c *C) {
 	tk.MustExec("use show_test_DB")
 	result = tk.MustQuery("SHOW index from show_index from test where Column_name = 'c'")
 	c.Check(result.Rows(), HasLen, 1)
+
+	// For show with order by
+	tk.MustExec("use test")
+	tk.MustQuery("show tables like 'show%' order by Tables_in_test desc").Check(testkit.Rows("show_index", "SHOW_test"))
+	tk.MustQuery("show index from show_index order by Key_name desc limit 1").Check(testkit.Rows(
+		"show_index 1 cIdx 1 c utf8_bin 0 <nil> <nil> YES HASH  index_comment_for_cIdx"))
+	result = tk.MustQuery("show table status where Name = 'show_index' order by 1")
+	c.Check(result.Rows(), HasLen, 1)
+	result = tk.MustQuery("show table status from test like 'show_index'")
+	c.Check(result.Rows(), HasLen, 1)
+	tk.MustQuery("SHOW TRIGGERS WHERE `Table` = 't' ORDER BY `Trigger`").Check(testkit.Rows())
+
+	// For show collation and charset
+	tk.MustQuery("show collation like 'utf8_bin'").Check(testkit.Rows("utf8_bin utf8 83  Yes 1"))
+	tk.MustQuery("show collation where Charset = 'utf8' and Collation = 'utf8_bin'").Check(testkit.Rows("utf8_bin utf8 83  Yes 1"))
+	result = tk.MustQuery("show charset like 'utf8%'")
+	c.Check(result.Rows(), HasLen, 2)
+	tk.MustQuery("show character set where Charset = 'ascii'").Check(testkit.Rows("ascii US ASCII ascii_general_ci 1"))
+
+	// For show engines and plugins
+	tk.MustQuery("show engines").Check(testkit.Rows("InnoDB DEFAULT Supports transactions, row-level locking, and foreign keys YES YES YES"))
+	tk.MustQuery("show plugins").Check(testkit.Rows())
+	result = tk.MustQuery("show privileges")
+	c.Check(result.Rows(), HasLen, len(mysql.AllGlobalPrivs))
+	c.Check(result.Rows()[0], DeepEquals, []interface{}{"Select", "Tables", "To retrieve rows from table"})
+	for i, row := range result.Rows() {
+		// Every privilege is described.
+		c.Check(row[0], Equals, mysql.Priv2Str[mysql.AllGlobalPrivs[i]])
+		c.Check(row[2], Not(Equals), "")
+	}
+	tk.MustQuery("show open tables from test like 'show%'").Check(testkit.Rows())
+	tk.MustQuery("show global bindings").Check(testkit.Rows())
+	tk.MustQuery("show session bindings like 'select%'").Check(testkit.Rows())
+
+	// For show profiles and profile, statements are not profiled.
+	tk.MustQuery("show profiles").Check(testkit.Rows())
+	tk.MustQuery("show profile source, cpu for query 1 limit 1").Check(testkit.Rows())
+	rs, err := tk.Exec("show profile source, cpu, memory")
+	c.Assert(err, IsNil)
+	fields, err := rs.Fields()
+	c.Assert(err, IsNil)
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Column.Name.O)
+	}
+	c.Assert(names, DeepEquals, []string{"Status", "Duration", "CPU_user", "CPU_system", "Source_function", "Source_file", "Source_line"})
+	c.Assert(rs.Close(), IsNil)
+
+	// The filter on the statistics columns is resolved, but showing statistics is not supported yet.
+	for _, sql := range []string{
+		"show stats_meta where `Row_count` > 0",
+		"show stats_histograms where Is_index = 1",
+		"show stats_buckets where Upper_Bound = '1'",
+		"show stats_healthy like 't%'",
+	} {
+		_, err = tk.Exec(sql)
+		c.Assert(err, ErrorMatches, ".*Showing statistics is not supported", Commentf("sql: %s", sql))
+	}
+	_, err = tk.Exec("show builtins")
+	c.Assert(err, ErrorMatches, ".*SHOW BUILTINS is not supported")
 }
 
 type stats struct {
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/terror"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/types"
 )
 
 // SimpleExec represents simple statement executor.
@@ -77,6 +78,12 @@ func (e *SimpleExec) Next() (*Row, error) {
 	case *ast.BinlogStmt:
 		// We just ignore it.
 		return nil, nil
+	case *ast.SetTransactionStmt:
+		// Parsed but ignored, we only support the default transaction characteristics.
+		return nil, nil
+	case *ast.EmptyStmt:
+		// A script of semicolons only does nothing.
+		return nil, nil
 	}
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -128,6 +135,9 @@ func (e *SimpleExec) executeCommit(s *ast.CommitStmt) {
 }
 
 func (e *SimpleExec) executeRollback(s *ast.RollbackStmt) error {
+	if s.SavepointName != "" {
+		return errors.Errorf("SAVEPOINT %s does not exist", s.SavepointName)
+	}
 	sessVars := e.ctx.GetSessionVars()
 	log.Infof("[%d] execute rollback statement", sessVars.ConnectionID)
 	sessVars.SetStatusFlag(mysql.ServerStatusInTrans, false)
@@ -140,7 +150,10 @@ func (e *SimpleExec) executeRollback(s *ast.RollbackStmt) error {
 func (e *SimpleExec) executeCreateUser(s *ast.CreateUserStmt) error {
 	users := make([]string, 0, len(s.Specs))
 	for _, spec := range s.Specs {
-		userName, host := parseUser(spec.User)
+		userName, host, err1 := resolveUser(e.ctx, spec.User)
+		if err1 != nil {
+			return errors.Trace(err1)
+		}
 		exists, err1 := userExists(e.ctx, userName, host)
 		if err1 != nil {
 			return errors.Trace(err1)
@@ -151,13 +164,9 @@ func (e *SimpleExec) executeCreateUser(s *ast.CreateUserStmt) error {
 			}
 			continue
 		}
-		pwd := ""
-		if spec.AuthOpt != nil {
-			if spec.AuthOpt.ByAuthString {
-				pwd = util.EncodePassword(spec.AuthOpt.AuthString)
-			} else {
-				pwd = util.EncodePassword(spec.AuthOpt.HashString)
-			}
+		pwd, err := encodePassword(spec.AuthOpt)
+		if err != nil {
+			return errors.Trace(err)
 		}
 		user := fmt.Sprintf(`("%s", "%s", "%s")`, host, userName, pwd)
 		users = append(users, user)
@@ -177,46 +186,58 @@ func (e *SimpleExec) executeCreateUser(s *ast.CreateUserStmt) error {
 	return errors.Trace(err)
 }
 
+// encodePassword returns the password to be stored in the user table for the auth option.
+func encodePassword(opt *ast.AuthOption) (string, error) {
+	if opt == nil {
+		return "", nil
+	}
+	if !opt.ByAuthString {
+		return util.EncodePassword(opt.HashString), nil
+	}
+	switch strings.ToLower(opt.AuthPlugin) {
+	case "", mysql.AuthName:
+		return util.EncodePassword(opt.AuthString), nil
+	default:
+		return "", errors.Errorf("Plugin '%s' is not loaded", opt.AuthPlugin)
+	}
+}
+
 func (e *SimpleExec) executeAlterUser(s *ast.AlterUserStmt) error {
+	specs := s.Specs
 	if s.CurrentAuth != nil {
-		user := e.ctx.GetSessionVars().User
-		if len(user) == 0 {
-			return errors.New("Session user is empty")
-		}
 		spec := &ast.UserSpec{
-			User:    user,
+			User:    &ast.UserIdentity{CurrentUser: true},
 			AuthOpt: s.CurrentAuth,
 		}
-		s.Specs = []*ast.UserSpec{spec}
+		specs = []*ast.UserSpec{spec}
 	}
 
-	failedUsers := make([]string, 0, len(s.Specs))
-	for _, spec := range s.Specs {
-		userName, host := parseUser(spec.User)
+	failedUsers := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		userName, host, err := resolveUser(e.ctx, spec.User)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		exists, err := userExists(e.ctx, userName, host)
 		if err != nil {
 			return errors.Trace(err)
 		}
 		if !exists {
-			failedUsers = append(failedUsers, spec.User)
+			failedUsers = append(failedUsers, spec.User.String())
 			if s.IfExists {
 				// TODO: Make this error as a warning.
 			}
 			continue
 		}
-		pwd := ""
-		if spec.AuthOpt != nil {
-			if spec.AuthOpt.ByAuthString {
-				pwd = util.EncodePassword(spec.AuthOpt.AuthString)
-			} else {
-				pwd = util.EncodePassword(spec.AuthOpt.HashString)
-			}
+		pwd, err := encodePassword(spec.AuthOpt)
+		if err != nil {
+			return errors.Trace(err)
 		}
 		sql := fmt.Sprintf(`UPDATE %s.%s SET Password = "%s" WHERE Host = "%s" and User = "%s";`,
 			mysql.SystemDB, mysql.UserTable, pwd, host, userName)
 		_, err = e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(e.ctx, sql)
 		if err != nil {
-			failedUsers = append(failedUsers, spec.User)
+			failedUsers = append(failedUsers, spec.User.String())
 		}
 	}
 	if len(failedUsers) > 0 {
@@ -234,21 +255,24 @@ func (e *SimpleExec) executeAlterUser(s *ast.AlterUserStmt) error {
 func (e *SimpleExec) executeDropUser(s *ast.DropUserStmt) error {
 	failedUsers := make([]string, 0, len(s.UserList))
 	for _, user := range s.UserList {
-		userName, host := parseUser(user)
+		userName, host, err := resolveUser(e.ctx, user)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		exists, err := userExists(e.ctx, userName, host)
 		if err != nil {
 			return errors.Trace(err)
 		}
 		if !exists {
 			if !s.IfExists {
-				failedUsers = append(failedUsers, user)
+				failedUsers = append(failedUsers, user.String())
 			}
 			continue
 		}
 		sql := fmt.Sprintf(`DELETE FROM %s.%s WHERE Host = "%s" and User = "%s";`, mysql.SystemDB, mysql.UserTable, host, userName)
 		_, err = e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(e.ctx, sql)
 		if err != nil {
-			failedUsers = append(failedUsers, user)
+			failedUsers = append(failedUsers, user.String())
 		}
 	}
 	if len(failedUsers) > 0 {
@@ -270,6 +294,20 @@ func parseUser(user string) (string, string) {
 	return strs[0], strs[1]
 }
 
+// resolveUser returns the user name and host of user.
+// The session user is used if user is nil or refers to CURRENT_USER.
+func resolveUser(ctx context.Context, user *ast.UserIdentity) (string, string, error) {
+	if user != nil && !user.CurrentUser {
+		return user.Username, user.Hostname, nil
+	}
+	sessionUser := ctx.GetSessionVars().User
+	if len(sessionUser) == 0 {
+		return "", "", errors.New("Session user is empty")
+	}
+	userName, host := parseUser(sessionUser)
+	return userName, host, nil
+}
+
 func userExists(ctx context.Context, name string, host string) (bool, error) {
 	sql := fmt.Sprintf(`SELECT * FROM %s.%s WHERE User="%s" AND Host="%s";`, mysql.SystemDB, mysql.UserTable, name, host)
 	rs, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, sql)
@@ -285,14 +323,10 @@ func userExists(ctx context.Context, name string, host string) (bool, error) {
 }
 
 func (e *SimpleExec) executeSetPwd(s *ast.SetPwdStmt) error {
-	if len(s.User) == 0 {
-		vars := e.ctx.GetSessionVars()
-		s.User = vars.User
-		if len(s.User) == 0 {
-			return errors.New("Session error is empty")
-		}
+	userName, host, err := resolveUser(e.ctx, s.User)
+	if err != nil {
+		return errors.Trace(err)
 	}
-	userName, host := parseUser(s.User)
 	exists, err := userExists(e.ctx, userName, host)
 	if err != nil {
 		return errors.Trace(err)
@@ -309,7 +343,7 @@ func (e *SimpleExec) executeSetPwd(s *ast.SetPwdStmt) error {
 
 func (e *SimpleExec) executeFlush(s *ast.FlushStmt) error {
 	switch s.Tp {
-	case ast.FlushTables:
+	case ast.FlushTables, ast.FlushStatus, ast.FlushLogs:
 		// TODO: A dummy implement
 	case ast.FlushPrivileges:
 		dom := sessionctx.GetDomain(e.ctx)
@@ -318,3 +352,51 @@ func (e *SimpleExec) executeFlush(s *ast.FlushStmt) error {
 	}
 	return nil
 }
+
+// TableMaintenanceExec executes OPTIMIZE TABLE and CHECK TABLE. The storage is reorganized
+// by TiKV itself and the data is checked by ADMIN CHECK TABLE, so it only returns a row
+// for each table telling that the operation is not supported.
+type TableMaintenanceExec struct {
+	Statement ast.StmtNode
+	schema    *expression.Schema
+	rows      []*Row
+	cursor    int
+}
+
+// Schema implements the Executor Schema interface.
+func (e *TableMaintenanceExec) Schema() *expression.Schema {
+	return e.schema
+}
+
+// Next implements the Executor Next interface.
+func (e *TableMaintenanceExec) Next() (*Row, error) {
+	if e.rows == nil {
+		var (
+			op     string
+			tables []*ast.TableName
+		)
+		switch x := e.Statement.(type) {
+		case *ast.OptimizeTableStmt:
+			op, tables = "optimize", x.Tables
+		case *ast.CheckTableStmt:
+			op, tables = "check", x.Tables
+		}
+		msg := fmt.Sprintf("The storage engine for the table doesn't support %s", op)
+		for _, t := range tables {
+			name := fmt.Sprintf("%s.%s", t.Schema.O, t.Name.O)
+			e.rows = append(e.rows, &Row{Data: types.MakeDatums(name, op, "note", msg)})
+		}
+	}
+	if e.cursor >= len(e.rows) {
+		return nil, nil
+	}
+	row := e.rows[e.cursor]
+	e.cursor++
+	return row, nil
+}
+
+// Close implements the Executor Close interface.
+func (e *TableMaintenanceExec) Close() error {
+	e.rows = nil
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/tidb"
 	"github.com/pingcap/tidb/context"
 	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/mysql"
 	"github.com/pingcap/tidb/privilege/privileges"
 	"github.com/pingcap/tidb/terror"
@@ -121,6 +122,16 @@ func (s *testSuite) TestUser(c *C) {
 	result.Check(testkit.Rows(rowStr))
 	dropUserSQL = `DROP USER IF EXISTS 'test1'@'localhost' ;`
 	tk.MustExec(dropUserSQL)
+	// Create user with auth plugin.
+	createUserSQL = `CREATE USER 'test1'@'localhost' IDENTIFIED WITH mysql_native_password BY '123';`
+	tk.MustExec(createUserSQL)
+	result = tk.MustQuery(`SELECT Password FROM mysql.User WHERE User="test1" and Host="localhost"`)
+	rowStr = fmt.Sprintf("%v", []byte(util.EncodePassword("123")))
+	result.Check(testkit.Rows(rowStr))
+	tk.MustExec(dropUserSQL)
+	createUserSQL = `CREATE USER 'test1'@'localhost' IDENTIFIED WITH 'unknown_plugin' BY '123';`
+	_, err = tk.Exec(createUserSQL)
+	c.Check(err, NotNil)
 
 	// Test alter user.
 	createUserSQL = `CREATE USER 'test1'@'localhost' IDENTIFIED BY '123', 'test2'@'localhost' IDENTIFIED BY '123', 'test3'@'localhost' IDENTIFIED BY '123';`
@@ -217,6 +228,40 @@ func (s *testSuite) TestSetPwd(c *C) {
 	result.Check(testkit.Rows(rowStr))
 }
 
+func (s *testSuite) TestFlushStatusAndLogs(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("FLUSH STATUS")
+	tk.MustExec("FLUSH NO_WRITE_TO_BINLOG LOGS")
+}
+
+func (s *testSuite) TestLockUnlockTables(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Table locks are not supported yet.
+	_, err := tk.Exec("LOCK TABLES t1 READ, t2 WRITE")
+	c.Assert(err, ErrorMatches, ".*LOCK TABLES and UNLOCK TABLES are not supported")
+	_, err = tk.Exec("UNLOCK TABLES")
+	c.Assert(err, ErrorMatches, ".*LOCK TABLES and UNLOCK TABLES are not supported")
+}
+
+func (s *testSuite) TestRoleStmts(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Roles are not supported yet.
+	for _, sql := range []string{
+		"CREATE ROLE 'r1'",
+		"DROP ROLE IF EXISTS 'r1'",
+		"GRANT 'r1' TO 'root'",
+		"REVOKE 'r1' FROM 'root'",
+		"SET ROLE ALL",
+		"SET DEFAULT ROLE 'r1' TO 'root'",
+	} {
+		_, err := tk.Exec(sql)
+		c.Assert(err, NotNil, Commentf("sql: %s", sql))
+	}
+}
+
 func (s *testSuite) TestFlushPrivileges(c *C) {
 	defer testleak.AfterTest(c)()
 	// Global variables is really bad, when the test cases run concurrently.
@@ -245,3 +290,176 @@ func (s *testSuite) TestFlushPrivileges(c *C) {
 
 	privileges.Enable = save
 }
+
+func (s *testSuite) TestSetConfig(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Changing the config of cluster components is not supported yet.
+	_, err := tk.Exec("set config tikv split.qps-threshold = 1000")
+	c.Assert(err, NotNil)
+}
+
+func (s *testSuite) TestImportInto(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists import_into")
+	tk.MustExec("create table import_into (a int, b int)")
+	// Importing data in the background is not supported yet.
+	_, err := tk.Exec("import into import_into (a, @b) from '/tmp/t.csv' with thread = 8")
+	c.Assert(err, ErrorMatches, ".*IMPORT INTO is not supported")
+	_, err = tk.Exec("import into import_into from '/tmp/t.csv' with threads = 8")
+	c.Assert(err, ErrorMatches, ".*Unknown IMPORT INTO option 'threads'")
+}
+
+func (s *testSuite) TestNonTransactionalDML(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists batch_dml")
+	tk.MustExec("create table batch_dml (a int primary key, b int)")
+	// Splitting a DML into batches is not supported yet.
+	_, err := tk.Exec("batch on a limit 100 delete from batch_dml where b > 1")
+	c.Assert(err, ErrorMatches, ".*Non-transactional DML is not supported")
+	_, err = tk.Exec("batch limit 0 delete from batch_dml")
+	c.Assert(err, ErrorMatches, ".*BATCH LIMIT must be greater than 0")
+}
+
+func (s *testSuite) TestOptimizeTable(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists optimize1, optimize2")
+	tk.MustExec("create table optimize1 (a int)")
+	tk.MustExec("create table optimize2 (a int)")
+	// OPTIMIZE TABLE returns a row for each table as the storage doesn't support it.
+	msg := "The storage engine for the table doesn't support optimize"
+	tk.MustQuery("optimize table optimize1, test.optimize2").Check(testkit.Rows(
+		"test.optimize1 optimize note "+msg,
+		"test.optimize2 optimize note "+msg,
+	))
+	tk.MustQuery("optimize local table optimize1").Check(testkit.Rows("test.optimize1 optimize note " + msg))
+	_, err := tk.Exec("optimize table optimize3")
+	c.Assert(infoschema.ErrTableNotExists.Equal(err), IsTrue)
+}
+
+func (s *testSuite) TestCheckTable(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists check1")
+	tk.MustExec("create table check1 (a int)")
+	// CHECK TABLE returns a row for each table as the storage doesn't support it,
+	// ADMIN CHECK TABLE checks the data and indices instead.
+	msg := "The storage engine for the table doesn't support check"
+	tk.MustQuery("check table check1").Check(testkit.Rows("test.check1 check note " + msg))
+	tk.MustQuery("check table check1, test.check1 for upgrade quick").Check(testkit.Rows(
+		"test.check1 check note "+msg,
+		"test.check1 check note "+msg,
+	))
+	_, err := tk.Exec("check table check2")
+	c.Assert(infoschema.ErrTableNotExists.Equal(err), IsTrue)
+}
+
+func (s *testSuite) TestCalibrateResource(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Resource control is not supported yet.
+	_, err := tk.Exec("calibrate resource workload tpcc")
+	c.Assert(err, ErrorMatches, ".*CALIBRATE RESOURCE is not supported")
+}
+
+func (s *testSuite) TestQueryWatch(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Runaway query management is not supported yet.
+	_, err := tk.Exec("query watch add action kill sql text exact to 'select 1'")
+	c.Assert(err, ErrorMatches, ".*QUERY WATCH is not supported")
+	_, err = tk.Exec("query watch remove 1")
+	c.Assert(err, ErrorMatches, ".*QUERY WATCH is not supported")
+	_, err = tk.Exec("query watch add action kill")
+	c.Assert(err, ErrorMatches, ".*QUERY WATCH needs one of SQL TEXT, SQL DIGEST and PLAN DIGEST")
+}
+
+func (s *testSuite) TestRepairTable(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Repairing the table metadata is not supported yet.
+	_, err := tk.Exec("admin repair table test.t create table t (a int)")
+	c.Assert(err, NotNil)
+}
+
+func (s *testSuite) TestLoadStats(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Loading the dumped statistics is not supported yet.
+	_, err := tk.Exec("load stats '/tmp/stats.json'")
+	c.Assert(err, ErrorMatches, ".*LOAD STATS is not supported")
+}
+
+func (s *testSuite) TestAlterInstance(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// Reloading the TLS context is not supported yet.
+	_, err := tk.Exec("alter instance reload tls")
+	c.Assert(err, ErrorMatches, ".*ALTER INSTANCE is not supported")
+}
+
+func (s *testSuite) TestBRIE(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	// Backup and restore are done by BR, they are not supported by TiDB yet.
+	_, err := tk.Exec("backup database * to 'local:///tmp/backup' with rate_limit = 120")
+	c.Assert(err, ErrorMatches, ".*BACKUP is not supported")
+	_, err = tk.Exec("restore table not_exists from 'local:///tmp/backup'")
+	c.Assert(err, ErrorMatches, ".*RESTORE is not supported")
+	_, err = tk.Exec("restore database * from 'local:///tmp/backup' with snapshot = 1")
+	c.Assert(err, ErrorMatches, ".*RESTORE doesn't take option 'snapshot'")
+}
+
+func (s *testSuite) TestDropStats(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	// Dropping the statistics is not supported yet.
+	_, err := tk.Exec("drop stats t partition p0")
+	c.Assert(err, ErrorMatches, ".*DROP STATS is not supported")
+}
+
+func (s *testSuite) TestPlanReplayer(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists replayer")
+	tk.MustExec("create table replayer (a int)")
+	// The plan replayer is not supported yet.
+	_, err := tk.Exec("plan replayer dump explain select * from replayer")
+	c.Assert(err, ErrorMatches, ".*PLAN REPLAYER is not supported")
+	_, err = tk.Exec("plan replayer load '/tmp/replayer.zip'")
+	c.Assert(err, ErrorMatches, ".*PLAN REPLAYER is not supported")
+}
+
+func (s *testSuite) TestBinding(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	// SQL binding is not supported yet.
+	_, err := tk.Exec("create global binding for select 1 using select 1")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("drop binding for select 1")
+	c.Assert(err, NotNil)
+}
+
+func (s *testSuite) TestCommitCompletionType(c *C) {
+	defer testleak.AfterTest(c)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("begin")
+	// Chaining and releasing are not supported yet.
+	_, err := tk.Exec("commit and chain")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("rollback release")
+	c.Assert(err, NotNil)
+	tk.MustExec("commit and no chain no release")
+	tk.MustExec("begin")
+	tk.MustExec("rollback and no chain")
+}
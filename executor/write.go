@@ -280,6 +280,7 @@ func NewLoadDataInfo(row []types.Datum, ctx context.Context, tbl table.Table) *L
 	return &LoadDataInfo{
 		row:       row,
 		insertVal: &InsertValues{ctx: ctx, Table: tbl},
+		columns:   tbl.Cols(),
 		Table:     tbl,
 		Ctx:       ctx,
 	}
@@ -289,6 +290,7 @@ func NewLoadDataInfo(row []types.Datum, ctx context.Context, tbl table.Table) *L
 type LoadDataInfo struct {
 	row       []types.Datum
 	insertVal *InsertValues
+	columns   []*table.Column
 
 	Path       string
 	Table      table.Table
@@ -510,7 +512,7 @@ func (e *LoadDataInfo) insertData(cols []string) {
 		}
 		e.row[i].SetString(cols[i])
 	}
-	row, err := e.insertVal.fillRowData(e.Table.Cols(), e.row, true)
+	row, err := e.insertVal.fillRowData(e.columns, e.row, true)
 	if err != nil {
 		log.Warnf("Load Data: insert data:%v failed:%v", e.row, errors.ErrorStack(err))
 		return
@@ -19,6 +19,9 @@ import (
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/model"
 )
 
 var _ = yyLexer(&Scanner{})
@@ -37,6 +40,13 @@ type Scanner struct {
 
 	errs         []error
 	stmtStartPos int
+	// delimiterPos is the offset of the last token returned by Lex if it is ';', otherwise -1.
+	delimiterPos int
+	// lastKeyword is the last keyword token returned by Lex, it decides whether
+	// a following "/*+ ... */" comment is optimizer hints.
+	lastKeyword int
+	// tableHints is the hints parsed from the last "/*+ ... */" comment, returned as tableHints.
+	tableHints []*ast.TableOptimizerHint
 
 	// for scanning such kind of comment: /*! MySQL-specific code */
 	specialComment *specialCommentScanner
@@ -58,21 +68,24 @@ func (s *Scanner) reset(sql string) {
 	s.buf.Reset()
 	s.errs = s.errs[:0]
 	s.stmtStartPos = 0
+	s.delimiterPos = -1
+	s.lastKeyword = 0
 }
 
+// stmtText returns the text of the statement which ends at the current position.
+// The text doesn't include the ';' ending the statement and the spaces around,
+// so each statement of a batch has only its own text.
+// The ';' of the empty statements before it are skipped as well.
 func (s *Scanner) stmtText() string {
-	endPos := s.r.pos().Offset
-	if s.r.s[endPos-1] == '\n' {
-		endPos = endPos - 1 // trim new line
+	startPos, endPos := s.stmtStartPos, s.r.pos().Offset
+	s.stmtStartPos = endPos
+	if s.delimiterPos >= startPos {
+		endPos = s.delimiterPos
 	}
-	if s.r.s[s.stmtStartPos] == '\n' {
-		s.stmtStartPos++
+	for startPos < endPos && (s.r.s[startPos] == ';' || unicode.IsSpace(rune(s.r.s[startPos]))) {
+		startPos++
 	}
-
-	text := s.r.s[s.stmtStartPos:endPos]
-
-	s.stmtStartPos = endPos
-	return text
+	return strings.TrimSpace(s.r.s[startPos:endPos])
 }
 
 // Errorf tells scanner something is wrong.
@@ -96,6 +109,10 @@ func (s *Scanner) Lex(v *yySymType) int {
 	tok, pos, lit := s.scan()
 	v.offset = pos.Offset
 	v.ident = lit
+	s.delimiterPos = -1
+	if tok == ';' {
+		s.delimiterPos = pos.Offset
+	}
 	if tok == identifier {
 		tok = handleIdent(v)
 	}
@@ -104,6 +121,7 @@ func (s *Scanner) Lex(v *yySymType) int {
 			tok = tok1
 		}
 	}
+	s.lastKeyword = tok
 
 	switch tok {
 	case intLit:
@@ -116,9 +134,12 @@ func (s *Scanner) Lex(v *yySymType) int {
 		return toHex(s, v, lit)
 	case bitLit:
 		return toBit(s, v, lit)
-	case userVar, sysVar, cast, curDate, extract:
+	case userVar, sysVar, namedPlaceholder, cast, curDate, extract:
 		v.item = lit
 		return tok
+	case tableHints:
+		v.item = s.tableHints
+		return tok
 	case null:
 		v.item = nil
 	case quotedIdentifier:
@@ -132,7 +153,7 @@ func (s *Scanner) Lex(v *yySymType) int {
 
 // NewScanner returns a new scanner object.
 func NewScanner(s string) *Scanner {
-	return &Scanner{r: reader{s: s}}
+	return &Scanner{r: reader{s: s}, delimiterPos: -1}
 }
 
 func (s *Scanner) skipWhitespace() rune {
@@ -280,6 +301,15 @@ func startWithSlash(s *Scanner) (tok int, pos Pos, lit string) {
 				},
 			}
 		}
+		// Convert "/*+ hints */" after SELECT, UPDATE or DELETE to tableHints.
+		// Hints which are not table level hints are ignored as a plain comment, like MySQL does.
+		if strings.HasPrefix(comment, "/*+") && isHintKeyword(s.lastKeyword) {
+			if hints, ok := parseTableHints(comment[3 : len(comment)-2]); ok {
+				s.tableHints = hints
+				tok = tableHints
+				return
+			}
+		}
 
 		return s.scan()
 	}
@@ -287,6 +317,67 @@ func startWithSlash(s *Scanner) (tok int, pos Pos, lit string) {
 	return
 }
 
+// isHintKeyword checks whether tok is a keyword which can be followed by optimizer hints.
+func isHintKeyword(tok int) bool {
+	return tok == selectKwd || tok == update || tok == deleteKwd
+}
+
+// parseTableHints parses the table level optimizer hints in a "/*+ ... */" comment,
+// like "TIDB_SMJ(t1, t2) TIDB_INLJ(t3)", the hints may be separated by commas.
+// The other hints, like "MAX_EXECUTION_TIME(1000)", and the text which is not a hint
+// are skipped. ok is false if there is no table level hint.
+func parseTableHints(text string) (hints []*ast.TableOptimizerHint, ok bool) {
+	s := NewScanner(text)
+	scan := func() (int, string) {
+		offset := s.r.pos().Offset
+		tok, _, lit := s.scan()
+		if tok != 0 && s.r.pos().Offset == offset {
+			// Skip the illegal character, the scanner doesn't consume it.
+			s.r.inc()
+		}
+		return tok, lit
+	}
+	tok, lit := scan()
+	for tok != 0 {
+		if tok != identifier && tok != quotedIdentifier {
+			tok, lit = scan()
+			continue
+		}
+		hint := &ast.TableOptimizerHint{HintName: model.NewCIStr(lit)}
+		if tok, lit = scan(); tok != '(' {
+			// Not a hint, the token may begin the next one.
+			continue
+		}
+		// The arguments of a table level hint are table names separated by commas.
+		isTableHint, expectTable := true, true
+		for depth := 1; depth > 0; {
+			tok, lit = scan()
+			switch {
+			case tok == 0:
+				// The hint is not closed.
+				return hints, len(hints) > 0
+			case tok == '(':
+				depth++
+				isTableHint = false
+			case tok == ')':
+				depth--
+			case depth == 1 && expectTable && (tok == identifier || tok == quotedIdentifier):
+				hint.Tables = append(hint.Tables, model.NewCIStr(lit))
+				expectTable = false
+			case depth == 1 && !expectTable && tok == ',':
+				expectTable = true
+			default:
+				isTableHint = false
+			}
+		}
+		if isTableHint && (len(hint.Tables) == 0 || !expectTable) {
+			hints = append(hints, hint)
+		}
+		tok, lit = scan()
+	}
+	return hints, len(hints) > 0
+}
+
 func sqlOffsetInComment(comment string) int {
 	// find the first SQL token offset in pattern like "/*!40101 mysql specific code */"
 	offset := 0
@@ -332,6 +423,23 @@ func startWithAt(s *Scanner) (tok int, pos Pos, lit string) {
 	return
 }
 
+func startWithColon(s *Scanner) (tok int, pos Pos, lit string) {
+	pos = s.r.pos()
+	s.r.inc()
+	ch1 := s.r.peek()
+	if ch1 == '=' {
+		s.r.inc()
+		tok = assignmentEq
+	} else if isIdentFirstChar(ch1) {
+		s.r.incAsLongAs(isIdentChar)
+		tok = namedPlaceholder
+	} else {
+		tok = int(':')
+	}
+	lit = s.r.data(&pos)
+	return
+}
+
 func scanIdentifier(s *Scanner) (int, Pos, string) {
 	pos := s.r.pos()
 	s.r.inc()
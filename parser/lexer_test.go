@@ -61,6 +61,11 @@ func (s *testLexerSuite) TestSingleCharOther(c *C) {
 		{"PLACEHOLDER", identifier},
 		{"=", eq},
 		{".", int('.')},
+		{":", int(':')},
+		{":=", assignmentEq},
+		{":name", namedPlaceholder},
+		{":_a1", namedPlaceholder},
+		{":1", int(':')},
 	}
 	runTest(c, table)
 }
@@ -99,7 +99,6 @@ func init() {
 	initTokenByte(',', int(','))
 	initTokenByte('&', int('&'))
 	initTokenByte('%', int('%'))
-	initTokenByte(':', int(':'))
 	initTokenByte('|', int('|'))
 	initTokenByte('!', int('!'))
 	initTokenByte('^', int('^'))
@@ -111,7 +110,6 @@ func init() {
 	initTokenString("||", oror)
 	initTokenString("&&", andand)
 	initTokenString("&^", andnot)
-	initTokenString(":=", assignmentEq)
 	initTokenString("<=>", nulleq)
 	initTokenString(">=", ge)
 	initTokenString("<=", le)
@@ -121,6 +119,7 @@ func init() {
 	initTokenString(">>", rsh)
 
 	initTokenFunc("@", startWithAt)
+	initTokenFunc(":", startWithColon)
 	initTokenFunc("/", startWithSlash)
 	initTokenFunc("-", startWithDash)
 	initTokenFunc("#", startWithSharp)
@@ -157,20 +156,31 @@ var tokenMap = map[string]int{
 	"ATAN2":                      atan2,
 	"AUTO_INCREMENT":             autoIncrement,
 	"AVG":                        avg,
+	"BACKUP":                     backup,
 	"AVG_ROW_LENGTH":             avgRowLength,
 	"BEGIN":                      begin,
 	"BETWEEN":                    between,
 	"BIN":                        bin,
+	"BINDING":                    binding,
+	"BINDINGS":                   bindings,
 	"BINLOG":                     binlog,
+	"BLOCK":                      block,
 	"BOTH":                       both,
 	"BTREE":                      btree,
+	"BUCKETS":                    buckets,
+	"BATCH":                      batch,
+	"BUILTINS":                   builtins,
 	"BY":                         by,
 	"BYTE":                       byteType,
+	"CALIBRATE":                  calibrate,
+	"CANCEL":                     cancel,
+	"CHAIN":                      chain,
 	"CASE":                       caseKwd,
 	"CAST":                       cast,
 	"CEIL":                       ceil,
 	"CEILING":                    ceiling,
 	"CHANGE":                     change,
+	"CHANGED":                    changed,
 	"CHARACTER":                  character,
 	"CHARSET":                    charsetKwd,
 	"CHECK":                      check,
@@ -186,6 +196,7 @@ var tokenMap = map[string]int{
 	"COMPACT":                    compact,
 	"COMPRESSED":                 compressed,
 	"COMPRESSION":                compression,
+	"CONFIG":                     config,
 	"CONCAT":                     concat,
 	"CONCAT_WS":                  concatWs,
 	"CONVERT_TZ":                 convertTz,
@@ -193,10 +204,13 @@ var tokenMap = map[string]int{
 	"CONNECTION_ID":              connectionID,
 	"CONSTRAINT":                 constraint,
 	"CONSISTENT":                 consistent,
+	"CONTEXT":                    context,
+	"COOLDOWN":                   cooldown,
 	"CONVERT":                    convert,
 	"COS":                        cos,
 	"COT":                        cot,
 	"COUNT":                      count,
+	"CPU":                        cpu,
 	"CREATE":                     create,
 	"CROSS":                      cross,
 	"CURDATE":                    curDate,
@@ -227,13 +241,18 @@ var tokenMap = map[string]int{
 	"DELETE":                     deleteKwd,
 	"DESC":                       desc,
 	"DESCRIBE":                   describe,
+	"DIGEST":                     digest,
 	"DISABLE":                    disable,
 	"DISTINCT":                   distinct,
 	"DIV":                        div,
 	"DO":                         do,
 	"DROP":                       drop,
 	"DUAL":                       dual,
+	"DUMP":                       dump,
+	"DRY":                        dry,
+	"DRYRUN":                     dryRun,
 	"DUPLICATE":                  duplicate,
+	"DURATION":                   duration,
 	"DYNAMIC":                    dynamic,
 	"FROM_DAYS":                  fromDays,
 	"ELSE":                       elseKwd,
@@ -241,19 +260,27 @@ var tokenMap = map[string]int{
 	"ENABLE":                     enable,
 	"ENCLOSED":                   enclosed,
 	"END":                        end,
+	"END_TIME":                   endTime,
 	"ENGINE":                     engine,
 	"ENGINES":                    engines,
 	"ENUM":                       enum,
+	"ERROR":                      errorKwd,
+	"ERRORS":                     errorsKwd,
 	"ESCAPE":                     escape,
 	"ESCAPED":                    escaped,
 	"EVENTS":                     events,
+	"EXACT":                      exact,
 	"EXECUTE":                    execute,
+	"EXCEPT":                     except,
 	"EXISTS":                     exists,
 	"EXP":                        exp,
 	"EXPLAIN":                    explain,
+	"EXTENDED":                   extended,
 	"EXPORT_SET":                 exportSet,
 	"EXTRACT":                    extract,
 	"FALSE":                      falseKwd,
+	"FAST":                       fast,
+	"FAULTS":                     faults,
 	"FIELD":                      fieldKwd,
 	"FIELDS":                     fields,
 	"FIND_IN_SET":                findInSet,
@@ -271,6 +298,7 @@ var tokenMap = map[string]int{
 	"FULLTEXT":                   fulltext,
 	"FUNCTION":                   function,
 	"FLOOR":                      floor,
+	"FLASHBACK":                  flashback,
 	"FLUSH":                      flush,
 	"GET_LOCK":                   getLock,
 	"GLOBAL":                     global,
@@ -280,6 +308,7 @@ var tokenMap = map[string]int{
 	"GROUP":                      group,
 	"GROUP_CONCAT":               groupConcat,
 	"HASH":                       hash,
+	"HELP":                       help,
 	"HAVING":                     having,
 	"HIGH_PRIORITY":              highPriority,
 	"HOUR":                       hour,
@@ -289,6 +318,7 @@ var tokenMap = map[string]int{
 	"IGNORE":                     ignore,
 	"IF":                         ifKwd,
 	"IFNULL":                     ifNull,
+	"IMPORT":                     importKwd,
 	"IN":                         in,
 	"INDEX":                      index,
 	"INDEXES":                    indexes,
@@ -296,16 +326,22 @@ var tokenMap = map[string]int{
 	"INNER":                      inner,
 	"INSERT":                     insert,
 	"INSERT_FUNC":                insertFunc,
+	"INSTANCE":                   instance,
 	"INSTR":                      instr,
 	"INTERVAL":                   interval,
 	"INTO":                       into,
+	"IO":                         io,
+	"IPC":                        ipc,
 	"IS":                         is,
 	"ISNULL":                     isNull,
 	"ISOLATION":                  isolation,
+	"JOB":                        job,
+	"JOBS":                       jobs,
 	"JOIN":                       join,
 	"KEY":                        key,
 	"KEY_BLOCK_SIZE":             keyBlockSize,
 	"KEYS":                       keys,
+	"KILL":                       kill,
 	"LAST_INSERT_ID":             lastInsertID,
 	"LEADING":                    leading,
 	"LEAST":                      least,
@@ -320,6 +356,7 @@ var tokenMap = map[string]int{
 	"LOAD":                       load,
 	"LOAD_FILE":                  loadFile,
 	"LOCAL":                      local,
+	"LOGS":                       logs,
 	"LOCATE":                     locate,
 	"LOCK":                       lock,
 	"LOG":                        log,
@@ -336,6 +373,8 @@ var tokenMap = map[string]int{
 	"MAX":                        max,
 	"MAXVALUE":                   maxValue,
 	"MAX_ROWS":                   maxRows,
+	"MEDIUM":                     medium,
+	"MEMORY":                     memory,
 	"MICROSECOND":                microsecond,
 	"MID":                        mid,
 	"MIN":                        min,
@@ -350,6 +389,9 @@ var tokenMap = map[string]int{
 	"NATIONAL":                   national,
 	"NOT":                        not,
 	"NO_WRITE_TO_BINLOG":         noWriteToBinLog,
+	"NODE_ID":                    nodeID,
+	"NODE_STATE":                 nodeState,
+	"NONE":                       none,
 	"NULL":                       null,
 	"NULLIF":                     nullIf,
 	"OCT":                        oct,
@@ -357,15 +399,20 @@ var tokenMap = map[string]int{
 	"OFFSET":                     offset,
 	"ON":                         on,
 	"ONLY":                       only,
+	"OPEN":                       open,
+	"OPTIMIZE":                   optimize,
 	"OPTION":                     option,
 	"OR":                         or,
 	"ORD":                        ord,
 	"ORDER":                      order,
 	"OUTER":                      outer,
+	"PAGE":                       page,
 	"PASSWORD":                   password,
 	"PERIOD_ADD":                 periodAdd,
 	"PERIOD_DIFF":                periodDiff,
 	"PI":                         pi,
+	"PLAN":                       plan,
+	"PLUGINS":                    plugins,
 	"POSITION":                   position,
 	"POW":                        pow,
 	"POWER":                      power,
@@ -374,29 +421,46 @@ var tokenMap = map[string]int{
 	"PRIVILEGES":                 privileges,
 	"PROCEDURE":                  procedure,
 	"PROCESSLIST":                processlist,
+	"PROFILE":                    profile,
+	"PROFILES":                   profiles,
 	"QUARTER":                    quarter,
 	"QUICK":                      quick,
+	"QUERY":                      query,
 	"RADIANS":                    radians,
 	"QUOTE":                      quote,
 	"RANGE":                      rangeKwd,
 	"RAND":                       rand,
 	"READ":                       read,
+	"RECOVER":                    recover,
 	"REDUNDANT":                  redundant,
+	"REGIONS":                    regions,
 	"REFERENCES":                 references,
 	"REGEXP":                     regexpKwd,
+	"RELEASE":                    release,
 	"RELEASE_LOCK":               releaseLock,
+	"RELOAD":                     reload,
+	"REMOVE":                     remove,
 	"RENAME":                     rename,
+	"REPAIR":                     repair,
 	"REPEAT":                     repeat,
 	"REPEATABLE":                 repeatable,
+	"REPLAYER":                   replayer,
 	"REPLACE":                    replace,
+	"RESOURCE":                   resource,
+	"RESTART":                    restart,
+	"RESTORE":                    restore,
+	"REVOKE":                     revoke,
 	"RIGHT":                      right,
 	"RLIKE":                      rlike,
+	"ROLE":                       role,
 	"ROLLBACK":                   rollback,
 	"ROUND":                      round,
 	"ROW":                        row,
 	"ROW_FORMAT":                 rowFormat,
+	"RUN":                        run,
 	"RTRIM":                      rtrim,
 	"REVERSE":                    reverse,
+	"SAVEPOINT":                  savepoint,
 	"SCHEMA":                     schema,
 	"SCHEMAS":                    schemas,
 	"SEC_TO_TIME":                secToTime,
@@ -407,19 +471,31 @@ var tokenMap = map[string]int{
 	"SET":                        set,
 	"SHARE":                      share,
 	"SHOW":                       show,
+	"SHUTDOWN":                   shutdown,
 	"SLEEP":                      sleep,
 	"SIGN":                       sign,
 	"SIGNED":                     signed,
+	"SIMILAR":                    similar,
 	"SIN":                        sin,
 	"SNAPSHOT":                   snapshot,
 	"SOME":                       some,
+	"SOURCE":                     source,
 	"SPACE":                      space,
+	"SPLIT":                      split,
 	"SQRT":                       sqrt,
 	"START":                      start,
+	"START_TIME":                 startTime,
 	"STARTING":                   starting,
+	"STATS":                      stats,
+	"STATS_BUCKETS":              statsBuckets,
+	"STATS_HEALTHY":              statsHealthy,
+	"STATS_HISTOGRAMS":           statsHistograms,
+	"STATS_META":                 statsMeta,
 	"STATS_PERSISTENT":           statsPersistent,
 	"STATUS":                     status,
 	"SUBDATE":                    subDate,
+	"SWAPS":                      swaps,
+	"SWITCHES":                   switches,
 	"SUBTIME":                    subTime,
 	"STRCMP":                     strcmp,
 	"STR_TO_DATE":                strToDate,
@@ -437,7 +513,10 @@ var tokenMap = map[string]int{
 	"TIME_TO_SEC":                timeToSec,
 	"TIMESTAMPADD":               timestampAdd,
 	"TIMESTAMPDIFF":              timestampDiff,
+	"TLS":                        tls,
 	"THAN":                       than,
+	"TIDB":                       tidb,
+	"TRACE":                      trace,
 	"THEN":                       then,
 	"TO":                         to,
 	"TO_DAYS":                    toDays,
@@ -456,6 +535,7 @@ var tokenMap = map[string]int{
 	"UNSIGNED":                   unsigned,
 	"UNIX_TIMESTAMP":             unixTimestamp,
 	"UPDATE":                     update,
+	"UPGRADE":                    upgrade,
 	"UPPER":                      upper,
 	"UCASE":                      ucase,
 	"UTC_TIME":                   utcTime,
@@ -468,17 +548,20 @@ var tokenMap = map[string]int{
 	"VERSION":                    version,
 	"VIEW":                       view,
 	"WARNINGS":                   warnings,
+	"WATCH":                      watch,
 	"WEEK":                       week,
 	"WEEKDAY":                    weekday,
 	"WEEKOFYEAR":                 weekofyear,
 	"WHEN":                       when,
 	"WHERE":                      where,
 	"WITH":                       with,
+	"WORKLOAD":                   workload,
 	"WRITE":                      write,
 	"XOR":                        xor,
 	"YEARWEEK":                   yearweek,
 	"ZEROFILL":                   zerofill,
 	"SQL_CALC_FOUND_ROWS":        calcFoundRows,
+	"SQL":                        sql,
 	"SQL_CACHE":                  sqlCache,
 	"SQL_NO_CACHE":               sqlNoCache,
 	"CURRENT_TIMESTAMP":          currentTs,
@@ -101,6 +101,7 @@ import (
 	elseKwd			"ELSE"
 	enclosed		"ENCLOSED"
 	escaped 		"ESCAPED"
+	except			"EXCEPT"
 	exists			"EXISTS"
 	explain			"EXPLAIN"
 	falseKwd		"FALSE"
@@ -132,6 +133,7 @@ import (
 	join			"JOIN"
 	key			"KEY"
 	keys			"KEYS"
+	kill			"KILL"
 	leading			"LEADING"
 	left			"LEFT"
 	like			"LIKE"
@@ -160,6 +162,7 @@ import (
 	oct			"OCT"
 	octetLength		"OCTET_LENGTH"
 	on			"ON"
+	optimize		"OPTIMIZE"
 	option			"OPTION"
 	or			"OR"
 	ord			"ORD"
@@ -177,10 +180,12 @@ import (
 	realType		"REAL"
 	references		"REFERENCES"
 	regexpKwd		"REGEXP"
+	release			"RELEASE"
 	rename         		"RENAME"
 	repeat			"REPEAT"
 	replace			"REPLACE"
 	restrict		"RESTRICT"
+	revoke			"REVOKE"
 	right			"RIGHT"
 	rlike			"RLIKE"
 	schema			"SCHEMA"
@@ -404,13 +409,24 @@ import (
 	autoIncrement	"AUTO_INCREMENT"
 	avgRowLength	"AVG_ROW_LENGTH"
 	avg		"AVG"
+	backup		"BACKUP"
 	begin		"BEGIN"
+	binding		"BINDING"
+	bindings	"BINDINGS"
 	binlog		"BINLOG"
 	bitType		"BIT"
+	block		"BLOCK"
 	booleanType	"BOOLEAN"
 	boolType	"BOOL"
 	btree		"BTREE"
+	buckets		"BUCKETS"
+	batch		"BATCH"
+	builtins	"BUILTINS"
+	calibrate	"CALIBRATE"
 	byteType	"BYTE"
+	cancel		"CANCEL"
+	chain		"CHAIN"
+	changed		"CHANGED"
 	charsetKwd	"CHARSET"
 	checksum	"CHECKSUM"
 	collation	"COLLATION"
@@ -421,87 +437,155 @@ import (
 	compact		"COMPACT"
 	compressed	"COMPRESSED"
 	compression	"COMPRESSION"
+	config		"CONFIG"
 	connection 	"CONNECTION"
 	consistent	"CONSISTENT"
+	context		"CONTEXT"
+	cooldown	"COOLDOWN"
+	cpu		"CPU"
 	data 		"DATA"
 	dateType	"DATE"
 	datetimeType	"DATETIME"
 	deallocate	"DEALLOCATE"
 	delayKeyWrite	"DELAY_KEY_WRITE"
+	digest		"DIGEST"
 	disable		"DISABLE"
 	do		"DO"
+	dry		"DRY"
+	dryRun		"DRYRUN"
+	duration	"DURATION"
+	dump		"DUMP"
 	duplicate	"DUPLICATE"
 	dynamic		"DYNAMIC"
 	enable		"ENABLE"
 	end		"END"
+	endTime		"END_TIME"
 	engine		"ENGINE"
 	engines		"ENGINES"
+	errorKwd	"ERROR"
+	errorsKwd	"ERRORS"
 	escape 		"ESCAPE"
+	exact		"EXACT"
 	execute		"EXECUTE"
+	extended	"EXTENDED"
+	fast		"FAST"
+	faults		"FAULTS"
 	fields		"FIELDS"
 	first		"FIRST"
 	fixed		"FIXED"
+	flashback	"FLASHBACK"
 	flush		"FLUSH"
 	full		"FULL"
 	function	"FUNCTION"
 	hash		"HASH"
+	help		"HELP"
 	identified	"IDENTIFIED"
+	io		"IO"
+	ipc		"IPC"
+	importKwd	"IMPORT"
+	instance	"INSTANCE"
 	isolation	"ISOLATION"
 	indexes		"INDEXES"
+	job		"JOB"
+	jobs		"JOBS"
 	keyBlockSize	"KEY_BLOCK_SIZE"
 	local		"LOCAL"
+	logs		"LOGS"
 	less		"LESS"
 	level		"LEVEL"
 	mode		"MODE"
 	modify		"MODIFY"
 	maxRows		"MAX_ROWS"
+	medium		"MEDIUM"
+	memory		"MEMORY"
 	minRows		"MIN_ROWS"
 	names		"NAMES"
 	national	"NATIONAL"
 	no		"NO"
+	nodeID		"NODE_ID"
+	nodeState	"NODE_STATE"
+	none		"NONE"
 	offset		"OFFSET"
 	only		"ONLY"
+	open		"OPEN"
+	page		"PAGE"
 	password	"PASSWORD"
+	plan		"PLAN"
+	plugins		"PLUGINS"
 	prepare		"PREPARE"
 	privileges	"PRIVILEGES"
 	processlist	"PROCESSLIST"
+	profile		"PROFILE"
+	profiles	"PROFILES"
 	quarter		"QUARTER"
+	query		"QUERY"
 	quick		"QUICK"
+	recover		"RECOVER"
 	redundant	"REDUNDANT"
+	regions		"REGIONS"
+	reload		"RELOAD"
+	remove		"REMOVE"
+	repair		"REPAIR"
 	repeatable	"REPEATABLE"
+	replayer	"REPLAYER"
+	resource	"RESOURCE"
+	restart		"RESTART"
+	restore		"RESTORE"
 	reverse		"REVERSE"
+	role		"ROLE"
 	rollback	"ROLLBACK"
 	row 		"ROW"
 	rowFormat	"ROW_FORMAT"
+	run		"RUN"
+	savepoint	"SAVEPOINT"
 	serializable	"SERIALIZABLE"
 	session		"SESSION"
 	share		"SHARE"
+	shutdown	"SHUTDOWN"
 	signed		"SIGNED"
+	similar		"SIMILAR"
 	snapshot	"SNAPSHOT"
+	source		"SOURCE"
 	space 		"SPACE"
+	split		"SPLIT"
+	sql		"SQL"
 	sqlCache	"SQL_CACHE"
 	sqlNoCache	"SQL_NO_CACHE"
 	start		"START"
+	startTime	"START_TIME"
+	stats		"STATS"
+	statsBuckets	"STATS_BUCKETS"
+	statsHealthy	"STATS_HEALTHY"
+	statsHistograms	"STATS_HISTOGRAMS"
+	statsMeta	"STATS_META"
 	status		"STATUS"
+	swaps		"SWAPS"
+	switches	"SWITCHES"
 	some 		"SOME"
 	global		"GLOBAL"
 	tables		"TABLES"
 	textType	"TEXT"
 	than		"THAN"
+	tidb		"TIDB"
+	trace		"TRACE"
 	timeType	"TIME"
 	timestampType	"TIMESTAMP"
 	timestampDiff	"TIMESTAMPDIFF"
+	tls		"TLS"
 	transaction	"TRANSACTION"
 	triggers	"TRIGGERS"
 	truncate	"TRUNCATE"
 	uncommitted	"UNCOMMITTED"
 	unknown 	"UNKNOWN"
+	upgrade		"UPGRADE"
 	user		"USER"
 	value		"VALUE"
 	variables	"VARIABLES"
 	view		"VIEW"
 	warnings	"WARNINGS"
+	watch		"WATCH"
 	week		"WEEK"
+	workload	"WORKLOAD"
 	yearType	"YEAR"
 
 %token	<item>
@@ -524,10 +608,12 @@ import (
 	ge		">="
 	le		"<="
 	lsh		"<<"
+	namedPlaceholder	"NAMED_PLACEHOLDER"
 	neq		"!="
 	neqSynonym	"<>"
 	nulleq		"<=>"
 	placeholder	"PLACEHOLDER"
+	tableHints	"table level optimizer hints in /*+ ... */"
 	rsh		">>"
 	sysVar		"SYS_VAR"
 	underscoreCS	"UNDERSCORE_CHARSET"
@@ -538,6 +624,7 @@ import (
 	AlterTableStmt		"Alter table statement"
 	AlterTableSpec		"Alter table specification"
 	AlterTableSpecList	"Alter table specification list"
+	AlterInstanceStmt	"Alter instance statement"
 	AlterUserStmt		"Alter user statement"
 	AnalyzeTableStmt	"Analyze table statement"
 	AnyOrAll		"Any or All for subquery"
@@ -548,15 +635,35 @@ import (
 	AuthString		"Password string value"
 	BeginTransactionStmt	"BEGIN TRANSACTION statement"
 	BinlogStmt		"Binlog base64 statement"
+	BRIEStmt		"BACKUP or RESTORE statement"
+	BRIETables		"BACKUP or RESTORE databases or tables"
+	BRIEOption		"BACKUP or RESTORE option"
+	BRIEOptionList		"BACKUP or RESTORE option list"
+	BRIEOptionListOpt	"BACKUP or RESTORE option list opt"
 	CastType		"Cast function target type"
+	ChangeStmt		"Change statement"
 	CharsetName		"Character set name"
 	ColumnDef		"table column definition"
 	ColumnName		"column name"
 	ColumnNameList		"column name list"
 	ColumnNameListOpt	"column name list opt"
+	ColumnNameListOptWithBrackets	"column name list opt with brackets"
+	NumList			"Some numbers"
 	ColumnSetValue		"insert statement set value by column name"
 	ColumnSetValueList	"insert statement set value by column name list"
+	CalibrateResourceStmt	"CALIBRATE RESOURCE statement"
+	CalibrateOption		"CALIBRATE RESOURCE option"
+	DynamicCalibrateOption	"CALIBRATE RESOURCE time window option"
+	DynamicCalibrateOptionList	"CALIBRATE RESOURCE time window option list"
+	AddQueryWatchStmt	"QUERY WATCH ADD statement"
+	DropQueryWatchStmt	"QUERY WATCH REMOVE statement"
+	QueryWatchOption	"QUERY WATCH option"
+	QueryWatchOptionList	"QUERY WATCH option list"
+	RunawayActionType	"QUERY WATCH action"
+	RunawayWatchType	"QUERY WATCH type of SQL text"
 	CommitStmt		"COMMIT statement"
+	CompletionChainOpt	"optional AND [NO] CHAIN clause"
+	CompletionReleaseOpt	"optional [NO] RELEASE clause"
 	CompareOp		"Compare opcode"
 	ColumnOption		"column definition option"
 	ColumnOptionList	"column definition option list"
@@ -564,6 +671,8 @@ import (
 	Constraint		"table constraint"
 	ConstraintElem		"table constraint element"
 	ConstraintKeywordOpt	"Constraint Keyword or empty"
+	BindableStmt		"statement that can be bound to a plan"
+	CreateBindingStmt	"CREATE BINDING statement"
 	CreateDatabaseStmt	"Create Database Statement"
 	CreateIndexStmt		"CREATE INDEX statement"
 	CreateIndexStmtUnique	"CREATE INDEX optional UNIQUE clause"
@@ -571,16 +680,21 @@ import (
 	DatabaseOptionList	"CREATE Database specification list"
 	DatabaseOptionListOpt	"CREATE Database specification list opt"
 	CreateTableStmt		"CREATE TABLE statement"
+	CreateRoleStmt		"CREATE ROLE statement"
 	CreateUserStmt		"CREATE User statement"
 	DBName			"Database Name"
+	DBNameList		"Database Name list"
 	DeallocateStmt		"Deallocate prepared statement"
 	DefaultValueExpr	"DefaultValueExpr(Now or Signed Literal)"
 	DeleteFromStmt		"DELETE FROM statement"
 	DistinctOpt		"Distinct option"
 	DoStmt			"Do statement"
+	DropBindingStmt		"DROP BINDING statement"
 	DropDatabaseStmt	"DROP DATABASE statement"
 	DropIndexStmt		"DROP INDEX statement"
 	DropTableStmt		"DROP TABLE statement"
+	DropRoleStmt		"DROP ROLE"
+	DropStatsStmt		"DROP STATS statement"
 	DropUserStmt		"DROP USER"
 	DropViewStmt		"DROP VIEW statement"
 	EmptyStmt		"empty statement"
@@ -603,7 +717,15 @@ import (
 	FieldAsName		"Field alias name"
 	FieldAsNameOpt		"Field alias name opt"
 	FieldList		"field expression list"
+	FlashBackTableStmt	"FLASHBACK TABLE statement"
+	FlashBackToNewName	"FLASHBACK TABLE TO new table name or empty"
+	SplitOption		"SPLIT TABLE split points"
 	FlushStmt		"Flush statement"
+	OptimizeTableStmt	"OPTIMIZE TABLE statement"
+	CheckTableStmt		"CHECK TABLE statement"
+	CheckTableOption	"CHECK TABLE option"
+	CheckTableOptionList	"CHECK TABLE option list"
+	CheckTableOptionListOpt	"CHECK TABLE optional option list"
 	FlushOption		"Flush option"
 	TableRefsClause		"Table references clause"
 	Function		"function expr"
@@ -612,7 +734,7 @@ import (
 	FunctionCallKeyword	"Function call with keyword as function name"
 	FunctionCallNonKeyword	"Function call with nonkeyword as function name"
 	FuncDatetimePrec	"Function datetime precision"
-	GlobalScope		"The scope of variable"
+	GrantRoleStmt		"GRANT role statement"
 	GrantStmt		"Grant statement"
 	GroupByClause		"GROUP BY clause"
 	HashString		"Hashed string"
@@ -632,8 +754,17 @@ import (
 	IndexOption		"Index Option"
 	IndexOptionList		"Index Option List or empty"
 	IndexType		"index type"
+	ImportIntoStmt		"IMPORT INTO statement"
+	ImportFormatOpt		"IMPORT INTO format"
+	ImportOption		"IMPORT INTO option"
+	ImportOptionList	"IMPORT INTO option list"
+	ImportOptionListOpt	"IMPORT INTO WITH clause"
+	ColumnNameOrUserVar	"column name or user variable"
+	ColumnNameOrUserVarList	"column name or user variable list"
+	ColumnNameOrUserVarListOptWithBrackets	"column name or user variable list opt with brackets"
 	IndexTypeOpt		"Optional index type"
 	InsertIntoStmt		"INSERT INTO statement"
+	KillStmt		"Kill statement"
 	InsertValues		"Rest part of INSERT/REPLACE INTO statement"
 	JoinTable 		"join table"
 	JoinType		"join type"
@@ -644,9 +775,12 @@ import (
 	LinesTerminated		"Lines terminated by"
 	Literal			"literal value"
 	LoadDataStmt		"Load data statement"
+	LoadStatsStmt		"Load statistics statement"
 	LocalOpt		"Local opt"
 	LockTablesStmt		"Lock tables statement"
+	LockType		"Table locks type"
 	LowPriorityOptional	"LOW_PRIORITY or empty"
+	MaxNumBuckets		"Max number of buckets"
 	NotOpt			"optional NOT"
 	NumLiteral		"Num/Int/Float/Decimal Literal"
 	NoWriteToBinLogAliasOpt "NO_WRITE_TO_BINLOG alias LOCAL or empty"
@@ -654,6 +788,7 @@ import (
 	OnDuplicateKeyUpdate	"ON DUPLICATE KEY UPDATE value list"
 	Operand			"operand"
 	OptFull			"Full or empty"
+	OptionalBraces		"optional braces"
 	Order			"ORDER BY clause optional collation specification"
 	OrderBy			"ORDER BY clause"
 	ByItem			"BY item"
@@ -663,12 +798,18 @@ import (
 	PartitionDefinition	"Partition definition"
 	PartitionDefinitionList "Partition definition list"
 	PartitionDefinitionListOpt	"Partition definition list option"
+	PartitionNameList	"Partition name list"
+	NonTransactionalDMLStmt	"Non-transactional DML statement"
+	ShardableStmt		"DML statement which can be non-transactional"
+	OptShardColumn		"Optional shard column of non-transactional DML"
+	DryRunOptions		"Dry run options of non-transactional DML"
 	PartitionOpt		"Partition option"
 	PartitionNumOpt		"PARTITION NUM option"
 	PasswordOpt		"Password option"
 	ColumnPosition		"Column position [First|After ColumnName]"
 	PreparedStmt		"PreparedStmt"
 	PrepareSQL		"Prepare statement sql string"
+	PlanReplayerStmt	"PLAN REPLAYER statement"
 	PrimaryExpression	"primary expression"
 	PrimaryFactor		"primary expression factor"
 	Priority		"insert statement priority"
@@ -680,11 +821,19 @@ import (
 	OnDeleteOpt		"optional ON DELETE clause"
 	OnUpdateOpt		"optional ON UPDATE clause"
 	ReferOpt		"reference option"
+	RecoverTableStmt	"RECOVER TABLE statement"
+	ReleaseSavepointStmt	"RELEASE SAVEPOINT statement"
 	RenameTableStmt         "rename table statement"
+	RepairTableStmt		"ADMIN REPAIR TABLE statement"
 	ReplaceIntoStmt		"REPLACE INTO statement"
+	RevokeRoleStmt		"REVOKE role statement"
+	RevokeStmt		"Revoke statement"
 	ReplacePriority		"replace statement priority"
+	Rolename		"Rolename"
+	RolenameList		"RolenameList"
 	RollbackStmt		"ROLLBACK statement"
 	RowFormat		"Row format option"
+	SavepointStmt		"SAVEPOINT statement"
 	SelectLockOpt		"FOR UPDATE or LOCK IN SHARE MODE,"
 	SelectStmt		"SELECT statement"
 	SelectStmtCalcFoundRows	"SELECT statement optional SQL_CALC_FOUND_ROWS"
@@ -695,19 +844,37 @@ import (
 	SelectStmtOpts		"Select statement options"
 	SelectStmtGroup		"SELECT statement optional GROUP BY clause"
 	SetStmt			"Set variable statement"
+	SetConfigStmt		"SET CONFIG statement"
+	SetRoleStmt		"SET ROLE statement"
+	SetRoleOpt		"SET ROLE option"
+	SetDefaultRoleStmt	"SET DEFAULT ROLE statement"
+	SetDefaultRoleOpt	"SET DEFAULT ROLE option"
 	ShowStmt		"Show engines/databases/tables/columns/warnings/status statement"
 	ShowTargetFilterable    "Show target that can be filtered by WHERE or LIKE"
+	ShutdownStmt		"SHUTDOWN statement"
+	RestartStmt		"RESTART statement"
+	HelpStmt		"HELP statement"
+	SplitRegionStmt		"SPLIT TABLE statement"
 	ShowDatabaseNameOpt	"Show tables/columns statement database name option"
 	ShowTableAliasOpt       "Show table alias option"
 	ShowLikeOrWhereOpt	"Show like or where clause option"
+	ShowProfileType		"SHOW PROFILE type"
+	ShowProfileTypes	"SHOW PROFILE type list"
+	ShowProfileTypesOpt	"SHOW PROFILE optional type list"
+	ShowProfileForQueryOpt	"SHOW PROFILE optional FOR QUERY clause"
+	ShowScope		"The scope of SHOW VARIABLES, SHOW STATUS and SHOW BINDINGS"
+	GlobalScope		"GLOBAL, SESSION or empty"
 	SignedLiteral		"Literal or NumLiteral with sign"
 	Starting		"Starting by"
+	StartTransactionChar	"START TRANSACTION characteristic"
+	StartTransactionCharList	"START TRANSACTION characteristic list"
 	Statement		"statement"
 	StatementList		"statement list"
 	StatsPersistentVal	"stats_persistent value"
 	StringName		"string literal or identifier"
 	StringList 		"string list"
 	ExplainableStmt		"explainable statement"
+	TraceStmt		"TRACE statement"
 	SubSelect		"Sub Select"
 	Symbol			"Constraint Symbol"
 	SystemVariable		"System defined variable name"
@@ -721,11 +888,16 @@ import (
 	TableName		"Table name"
 	TableNameList		"Table name list"
 	TableNameListOpt	"Table name list opt"
+	TableOptimizerHintsOpt	"Table level optimizer hints option"
 	TableOption		"create table option"
 	TableOptionList		"create table option list"
 	TableOptionListOpt	"create table option list opt"
 	TableRef 		"table reference"
 	TableRefs 		"table references"
+	TableToTable	 	"rename table to table"
+	TableToTableList 	"rename table to table by list"
+	TransactionChar		"Transaction characteristic"
+	TransactionChars	"Transaction characteristic list"
 	TrimDirection		"Trim string direction"
 	TruncateTableStmt	"TRANSACTION TABLE statement"
 	UnionOpt		"Union Option(empty/ALL/DISTINCT)"
@@ -735,6 +907,7 @@ import (
 	UnlockTablesStmt	"Unlock tables statement"
 	UpdateStmt		"UPDATE statement"
 	Username		"Username"
+	UsingRoles		"USING role list or empty"
 	UsernameList		"UsernameList"
 	UserSpec		"Username and auth option"
 	UserSpecList		"Username and auth option list"
@@ -786,13 +959,12 @@ import (
 	ExplainSym		"EXPLAIN or DESCRIBE or DESC"
 	RegexpSym		"REGEXP or RLIKE"
 	IntoOpt			"INTO or EmptyString"
+	KillOrKillTiDB		"Kill or Kill TiDB"
 	ValueSym		"Value or Values"
 	TimeUnit		"Time unit"
 	DeallocateSym		"Deallocate or drop"
 	OuterOpt		"optional OUTER clause"
 	CrossOpt		"Cross join option"
-	TransactionChar		"Transaction characteristic"
-	TransactionChars	"Transaction characteristic list"
 	IsolationLevel		"Isolation level"
 	ShowIndexKwd		"Show index/indexs/key keyword"
 	FromOrIn		"From or In"
@@ -801,10 +973,10 @@ import (
 	NationalOpt		"National option"
 	CharsetKw		"charset or charater set"
 	CommaOpt		"optional comma"
-	LockType		"Table locks type"
 	logAnd			"logical and operator"
 	logOr			"logical or operator"
 	FieldsOrColumns 	"Fields or columns"
+	ConfigItemName		"Config item name"
 
 %type	<ident>
 	Identifier			"identifier or unreserved keyword"
@@ -1060,21 +1232,57 @@ Symbol:
  * See http://dev.mysql.com/doc/refman/5.7/en/rename-table.html
  *******************************************************************************************/
 RenameTableStmt:
-	 "RENAME" "TABLE" TableName "TO" TableName
+	 "RENAME" "TABLE" TableToTableList
 	 {
 		$$ = &ast.RenameTableStmt{
-			OldTable: $3.(*ast.TableName), 
-			NewTable: $5.(*ast.TableName),
-		}	
+			TableToTables: $3.([]*ast.TableToTable),
+		}
 	 }
 
+TableToTableList:
+	TableToTable
+	{
+		$$ = []*ast.TableToTable{$1.(*ast.TableToTable)}
+	}
+|	TableToTableList ',' TableToTable
+	{
+		$$ = append($1.([]*ast.TableToTable), $3.(*ast.TableToTable))
+	}
+
+TableToTable:
+	TableName "TO" TableName
+	{
+		$$ = &ast.TableToTable{
+			OldTable: $1.(*ast.TableName),
+			NewTable: $3.(*ast.TableName),
+		}
+	}
+
 /*******************************************************************************************/
 
 AnalyzeTableStmt:
-	"ANALYZE" "TABLE" TableNameList
+	"ANALYZE" "TABLE" TableNameList MaxNumBuckets
 	 {
-		$$ = &ast.AnalyzeTableStmt{TableNames: $3.([]*ast.TableName)}
+		$$ = &ast.AnalyzeTableStmt{TableNames: $3.([]*ast.TableName), MaxNumBuckets: $4.(uint64)}
 	 }
+|	"ANALYZE" "TABLE" TableName "INDEX" IndexNameList MaxNumBuckets
+	{
+		$$ = &ast.AnalyzeTableStmt{
+			TableNames:	[]*ast.TableName{$3.(*ast.TableName)},
+			IndexNames:	$5.([]model.CIStr),
+			IndexFlag:	true,
+			MaxNumBuckets:	$6.(uint64),
+		}
+	}
+
+MaxNumBuckets:
+	{
+		$$ = uint64(0)
+	}
+|	"WITH" LengthNum "BUCKETS"
+	{
+		$$ = $2
+	}
 
 /*******************************************************************************************/
 Assignment:
@@ -1109,9 +1317,49 @@ BeginTransactionStmt:
 	{
 		$$ = &ast.BeginStmt{}
 	}
-|	"START" "TRANSACTION" "WITH" "CONSISTENT" "SNAPSHOT"
+|	"START" "TRANSACTION" StartTransactionCharList
+	{
+		stmt := &ast.BeginStmt{}
+		var readWrite bool
+		for _, char := range $3.([]string) {
+			switch char {
+			case "READ ONLY":
+				stmt.ReadOnly = true
+			case "READ WRITE":
+				readWrite = true
+			case "WITH CONSISTENT SNAPSHOT":
+				stmt.WithConsistentSnapshot = true
+			}
+		}
+		if stmt.ReadOnly && readWrite {
+			yylex.Errorf("START TRANSACTION can not be both READ ONLY and READ WRITE")
+			return 1
+		}
+		$$ = stmt
+	}
+
+StartTransactionCharList:
+	StartTransactionChar
+	{
+		$$ = []string{$1.(string)}
+	}
+|	StartTransactionCharList ',' StartTransactionChar
 	{
-		$$ = &ast.BeginStmt{}
+		$$ = append($1.([]string), $3.(string))
+	}
+
+StartTransactionChar:
+	"WITH" "CONSISTENT" "SNAPSHOT"
+	{
+		$$ = "WITH CONSISTENT SNAPSHOT"
+	}
+|	"READ" "ONLY"
+	{
+		$$ = "READ ONLY"
+	}
+|	"READ" "WRITE"
+	{
+		$$ = "READ WRITE"
 	}
 
 BinlogStmt:
@@ -1129,15 +1377,21 @@ ColumnDef:
 ColumnName:
 	Identifier
 	{
-		$$ = &ast.ColumnName{Name: model.NewCIStr($1)}
+		name := &ast.ColumnName{Name: model.NewCIStr($1)}
+		name.SetOriginTextPosition(parser.startOffset(&yyS[yypt]))
+		$$ = name
 	}
 |	Identifier '.' IdentifierOrReservedKeyword
 	{
-		$$ = &ast.ColumnName{Table: model.NewCIStr($1), Name: model.NewCIStr($3)}
+		name := &ast.ColumnName{Table: model.NewCIStr($1), Name: model.NewCIStr($3)}
+		name.SetOriginTextPosition(parser.startOffset(&yyS[yypt-2]))
+		$$ = name
 	}
 |	Identifier '.' Identifier '.' IdentifierOrReservedKeyword
 	{
-		$$ = &ast.ColumnName{Schema: model.NewCIStr($1), Table: model.NewCIStr($3), Name: model.NewCIStr($5)}
+		name := &ast.ColumnName{Schema: model.NewCIStr($1), Table: model.NewCIStr($3), Name: model.NewCIStr($5)}
+		name.SetOriginTextPosition(parser.startOffset(&yyS[yypt-4]))
+		$$ = name
 	}
 
 ColumnNameList:
@@ -1160,10 +1414,51 @@ ColumnNameListOpt:
 		$$ = $1.([]*ast.ColumnName)
 	}
 
+ColumnNameListOptWithBrackets:
+	/* EMPTY */
+	{
+		$$ = []*ast.ColumnName{}
+	}
+|	'(' ColumnNameListOpt ')'
+	{
+		$$ = $2.([]*ast.ColumnName)
+	}
+
 CommitStmt:
-	"COMMIT"
+	"COMMIT" CompletionChainOpt CompletionReleaseOpt
+	{
+		if $2.(ast.CompletionType) == ast.CompletionTypeYes && $3.(ast.CompletionType) == ast.CompletionTypeYes {
+			yylex.Errorf("AND CHAIN and RELEASE can't be used together")
+			return 1
+		}
+		$$ = &ast.CommitStmt{Chain: $2.(ast.CompletionType), Release: $3.(ast.CompletionType)}
+	}
+
+/* See https://dev.mysql.com/doc/refman/5.7/en/commit.html */
+CompletionChainOpt:
+	{
+		$$ = ast.CompletionTypeUnspecified
+	}
+|	"AND" "CHAIN"
+	{
+		$$ = ast.CompletionTypeYes
+	}
+|	"AND" "NO" "CHAIN"
+	{
+		$$ = ast.CompletionTypeNo
+	}
+
+CompletionReleaseOpt:
+	{
+		$$ = ast.CompletionTypeUnspecified
+	}
+|	"RELEASE"
+	{
+		$$ = ast.CompletionTypeYes
+	}
+|	"NO" "RELEASE"
 	{
-		$$ = &ast.CommitStmt{}
+		$$ = ast.CompletionTypeNo
 	}
 
 PrimaryOpt:
@@ -1660,58 +1955,67 @@ DoStmt:
  *
  *******************************************************************/
 DeleteFromStmt:
-	"DELETE" LowPriorityOptional QuickOptional IgnoreOptional "FROM" TableName WhereClauseOptional OrderByOptional LimitClause
+	"DELETE" TableOptimizerHintsOpt LowPriorityOptional QuickOptional IgnoreOptional "FROM" TableName WhereClauseOptional OrderByOptional LimitClause
 	{
 		// Single Table
-		join := &ast.Join{Left: &ast.TableSource{Source: $6.(ast.ResultSetNode)}, Right: nil}
+		join := &ast.Join{Left: &ast.TableSource{Source: $7.(ast.ResultSetNode)}, Right: nil}
 		x := &ast.DeleteStmt{
 			TableRefs:	&ast.TableRefsClause{TableRefs: join},
-			LowPriority:	$2.(bool),
-			Quick:		$3.(bool),
-			Ignore:		$4.(bool),
+			LowPriority:	$3.(bool),
+			Quick:		$4.(bool),
+			Ignore:		$5.(bool),
 		}
-		if $7 != nil {
-			x.Where = $7.(ast.ExprNode)
+		if $2 != nil {
+			x.TableHints = $2.([]*ast.TableOptimizerHint)
 		}
 		if $8 != nil {
-			x.Order = $8.(*ast.OrderByClause)
+			x.Where = $8.(ast.ExprNode)
 		}
 		if $9 != nil {
-			x.Limit = $9.(*ast.Limit)
+			x.Order = $9.(*ast.OrderByClause)
+		}
+		if $10 != nil {
+			x.Limit = $10.(*ast.Limit)
 		}
 
 		$$ = x
 	}
-|	"DELETE" LowPriorityOptional QuickOptional IgnoreOptional TableNameList "FROM" TableRefs WhereClauseOptional
+|	"DELETE" TableOptimizerHintsOpt LowPriorityOptional QuickOptional IgnoreOptional TableNameList "FROM" TableRefs WhereClauseOptional
 	{
 		// Multiple Table
 		x := &ast.DeleteStmt{
-			LowPriority:	$2.(bool),
-			Quick:		$3.(bool),
-			Ignore:		$4.(bool),
+			LowPriority:	$3.(bool),
+			Quick:		$4.(bool),
+			Ignore:		$5.(bool),
 			IsMultiTable:	true,
 			BeforeFrom:	true,
-			Tables:		&ast.DeleteTableList{Tables: $5.([]*ast.TableName)},
-			TableRefs:	&ast.TableRefsClause{TableRefs: $7.(*ast.Join)},
+			Tables:		&ast.DeleteTableList{Tables: $6.([]*ast.TableName)},
+			TableRefs:	&ast.TableRefsClause{TableRefs: $8.(*ast.Join)},
 		}
-		if $8 != nil {
-			x.Where = $8.(ast.ExprNode)
+		if $2 != nil {
+			x.TableHints = $2.([]*ast.TableOptimizerHint)
+		}
+		if $9 != nil {
+			x.Where = $9.(ast.ExprNode)
 		}
 		$$ = x
 	}
-|	"DELETE" LowPriorityOptional QuickOptional IgnoreOptional "FROM" TableNameList "USING" TableRefs WhereClauseOptional
+|	"DELETE" TableOptimizerHintsOpt LowPriorityOptional QuickOptional IgnoreOptional "FROM" TableNameList "USING" TableRefs WhereClauseOptional
 	{
 		// Multiple Table
 		x := &ast.DeleteStmt{
-			LowPriority:	$2.(bool),
-			Quick:		$3.(bool),
-			Ignore:		$4.(bool),
+			LowPriority:	$3.(bool),
+			Quick:		$4.(bool),
+			Ignore:		$5.(bool),
 			IsMultiTable:	true,
-			Tables:		&ast.DeleteTableList{Tables: $6.([]*ast.TableName)},
-			TableRefs:	&ast.TableRefsClause{TableRefs: $8.(*ast.Join)},
+			Tables:		&ast.DeleteTableList{Tables: $7.([]*ast.TableName)},
+			TableRefs:	&ast.TableRefsClause{TableRefs: $9.(*ast.Join)},
 		}
-		if $9 != nil {
-			x.Where = $9.(ast.ExprNode)
+		if $2 != nil {
+			x.TableHints = $2.([]*ast.TableOptimizerHint)
+		}
+		if $10 != nil {
+			x.Where = $10.(ast.ExprNode)
 		}
 		$$ = x
 	}
@@ -1750,13 +2054,19 @@ DropViewStmt:
 DropUserStmt:
     "DROP" "USER" UsernameList
     {
-        $$ = &ast.DropUserStmt{IfExists: false, UserList: $3.([]string)}
+        $$ = &ast.DropUserStmt{IfExists: false, UserList: $3.([]*ast.UserIdentity)}
     }
 |   "DROP" "USER" "IF" "EXISTS" UsernameList
     {
-        $$ = &ast.DropUserStmt{IfExists: true, UserList: $5.([]string)}
+        $$ = &ast.DropUserStmt{IfExists: true, UserList: $5.([]*ast.UserIdentity)}
     }
 
+DropRoleStmt:
+	"DROP" "ROLE" IfExists RolenameList
+	{
+		$$ = &ast.DropRoleStmt{IfExists: $3.(bool), RoleList: $4.([]*ast.RoleIdentity)}
+	}
+
 TableOrTables:
 	"TABLE"
 |	"TABLES"
@@ -1782,6 +2092,7 @@ ExplainStmt:
 				Tp:	ast.ShowColumns,
 				Table:	$2.(*ast.TableName),
 			},
+			Format: ast.ExplainFormatROW,
 		}
 	}
 |	ExplainSym TableName ColumnName
@@ -1792,11 +2103,298 @@ ExplainStmt:
 				Table:	$2.(*ast.TableName),
 				Column:	$3.(*ast.ColumnName),
 			},
+			Format: ast.ExplainFormatROW,
 		}
 	}
 |	ExplainSym ExplainableStmt
 	{
-		$$ = &ast.ExplainStmt{Stmt: $2.(ast.StmtNode)}
+		parser.setInnerStmtText($2.(ast.StmtNode), &yyS[yypt])
+		$$ = &ast.ExplainStmt{
+			Stmt:	$2.(ast.StmtNode),
+			Format:	ast.ExplainFormatROW,
+		}
+	}
+|	ExplainSym "ANALYZE" ExplainableStmt
+	{
+		parser.setInnerStmtText($3.(ast.StmtNode), &yyS[yypt])
+		$$ = &ast.ExplainStmt{
+			Stmt:		$3.(ast.StmtNode),
+			Analyze:	true,
+			Format:		ast.ExplainFormatROW,
+		}
+	}
+|	ExplainSym "FORMAT" eq StringName ExplainableStmt
+	{
+		format := strings.ToLower($4.(string))
+		if !isValidExplainFormat(format) {
+			yylex.Errorf("Unknown EXPLAIN format name: '%s'", $4.(string))
+			return 1
+		}
+		parser.setInnerStmtText($5.(ast.StmtNode), &yyS[yypt])
+		$$ = &ast.ExplainStmt{
+			Stmt:	$5.(ast.StmtNode),
+			Format:	format,
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Create Binding Statement
+ *
+ *  Example:
+ *      CREATE GLOBAL BINDING FOR select Col1,Col2 from table USING select Col1,Col2 from table use index(Col1)
+ *******************************************************************/
+CreateBindingStmt:
+	"CREATE" GlobalScope "BINDING" "FOR" BindableStmt "USING" BindableStmt
+	{
+		$$ = &ast.CreateBindingStmt{
+			GlobalScope:	$2.(bool),
+			OriginSel:	$5.(ast.StmtNode),
+			HintedSel:	$7.(ast.StmtNode),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Drop Binding Statement
+ *
+ *  Example:
+ *      DROP GLOBAL BINDING FOR select Col1,Col2 from table
+ *******************************************************************/
+DropBindingStmt:
+	"DROP" GlobalScope "BINDING" "FOR" BindableStmt
+	{
+		$$ = &ast.DropBindingStmt{
+			GlobalScope:	$2.(bool),
+			OriginSel:	$5.(ast.StmtNode),
+		}
+	}
+
+BindableStmt:
+	SelectStmt
+|	UnionStmt
+
+GlobalScope:
+	{
+		$$ = false
+	}
+|	"GLOBAL"
+	{
+		$$ = true
+	}
+|	"SESSION"
+	{
+		$$ = false
+	}
+
+/*******************************************************************
+ *
+ *  Calibrate Resource Statement
+ *
+ *  Example:
+ *      CALIBRATE RESOURCE WORKLOAD TPCC
+ *      CALIBRATE RESOURCE START_TIME '2023-04-18 08:00:00' DURATION '20m'
+ *******************************************************************/
+CalibrateResourceStmt:
+	"CALIBRATE" "RESOURCE" CalibrateOption
+	{
+		$$ = $3.(*ast.CalibrateResourceStmt)
+	}
+
+CalibrateOption:
+	{
+		$$ = &ast.CalibrateResourceStmt{}
+	}
+|	"WORKLOAD" Identifier
+	{
+		$$ = &ast.CalibrateResourceStmt{WorkloadType: strings.ToLower($2)}
+	}
+|	DynamicCalibrateOptionList
+	{
+		$$ = &ast.CalibrateResourceStmt{DynamicCalibrateResourceOptionList: $1.([]*ast.DynamicCalibrateResourceOption)}
+	}
+
+DynamicCalibrateOptionList:
+	DynamicCalibrateOption
+	{
+		$$ = []*ast.DynamicCalibrateResourceOption{$1.(*ast.DynamicCalibrateResourceOption)}
+	}
+|	DynamicCalibrateOptionList DynamicCalibrateOption
+	{
+		$$ = append($1.([]*ast.DynamicCalibrateResourceOption), $2.(*ast.DynamicCalibrateResourceOption))
+	}
+|	DynamicCalibrateOptionList ',' DynamicCalibrateOption
+	{
+		$$ = append($1.([]*ast.DynamicCalibrateResourceOption), $3.(*ast.DynamicCalibrateResourceOption))
+	}
+
+DynamicCalibrateOption:
+	"START_TIME" EqOpt Expression
+	{
+		$$ = &ast.DynamicCalibrateResourceOption{Tp: ast.CalibrateStartTime, Ts: $3.(ast.ExprNode)}
+	}
+|	"END_TIME" EqOpt Expression
+	{
+		$$ = &ast.DynamicCalibrateResourceOption{Tp: ast.CalibrateEndTime, Ts: $3.(ast.ExprNode)}
+	}
+|	"DURATION" EqOpt stringLit
+	{
+		$$ = &ast.DynamicCalibrateResourceOption{Tp: ast.CalibrateDuration, StrValue: $3}
+	}
+
+AddQueryWatchStmt:
+	"QUERY" "WATCH" "ADD" QueryWatchOptionList
+	{
+		$$ = &ast.AddQueryWatchStmt{QueryWatchOptionList: $4.([]*ast.QueryWatchOption)}
+	}
+
+QueryWatchOptionList:
+	QueryWatchOption
+	{
+		$$ = []*ast.QueryWatchOption{$1.(*ast.QueryWatchOption)}
+	}
+|	QueryWatchOptionList QueryWatchOption
+	{
+		$$ = append($1.([]*ast.QueryWatchOption), $2.(*ast.QueryWatchOption))
+	}
+|	QueryWatchOptionList ',' QueryWatchOption
+	{
+		$$ = append($1.([]*ast.QueryWatchOption), $3.(*ast.QueryWatchOption))
+	}
+
+QueryWatchOption:
+	"RESOURCE" "GROUP" Identifier
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchResourceGroup, ResourceGroupName: model.NewCIStr($3)}
+	}
+|	"ACTION" EqOpt RunawayActionType
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchAction, Action: $3.(ast.RunawayActionType)}
+	}
+|	"SQL" "TEXT" RunawayWatchType "TO" stringLit
+	{
+		$$ = &ast.QueryWatchOption{
+			Tp:		ast.QueryWatchType,
+			WatchType:	$3.(ast.RunawayWatchType),
+			Pattern:	$5,
+			TypeSpecified:	true,
+		}
+	}
+|	"SQL" "DIGEST" stringLit
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchType, WatchType: ast.WatchSimilar, Pattern: $3}
+	}
+|	"PLAN" "DIGEST" stringLit
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchType, WatchType: ast.WatchPlan, Pattern: $3}
+	}
+
+RunawayActionType:
+	"DRYRUN"
+	{
+		$$ = ast.RunawayActionDryRun
+	}
+|	"COOLDOWN"
+	{
+		$$ = ast.RunawayActionCooldown
+	}
+|	"KILL"
+	{
+		$$ = ast.RunawayActionKill
+	}
+
+RunawayWatchType:
+	"EXACT"
+	{
+		$$ = ast.WatchExact
+	}
+|	"SIMILAR"
+	{
+		$$ = ast.WatchSimilar
+	}
+|	"PLAN"
+	{
+		$$ = ast.WatchPlan
+	}
+
+DropQueryWatchStmt:
+	"QUERY" "WATCH" "REMOVE" LengthNum
+	{
+		$$ = &ast.DropQueryWatchStmt{IntValue: int64($4.(uint64))}
+	}
+
+ShutdownStmt:
+	"SHUTDOWN"
+	{
+		$$ = &ast.ShutdownStmt{}
+	}
+
+RestartStmt:
+	"RESTART"
+	{
+		$$ = &ast.RestartStmt{}
+	}
+
+AlterInstanceStmt:
+	"ALTER" "INSTANCE" "RELOAD" "TLS"
+	{
+		$$ = &ast.AlterInstanceStmt{ReloadTLS: true}
+	}
+|	"ALTER" "INSTANCE" "RELOAD" "TLS" "NO" "ROLLBACK" "ON" "ERROR"
+	{
+		$$ = &ast.AlterInstanceStmt{ReloadTLS: true, NoRollbackOnError: true}
+	}
+
+HelpStmt:
+	"HELP" stringLit
+	{
+		$$ = &ast.HelpStmt{Topic: $2}
+	}
+
+ChangeStmt:
+	"CHANGE" Identifier "TO" "NODE_STATE" eq stringLit "FOR" "NODE_ID" stringLit
+	{
+		nodeType := strings.ToUpper($2)
+		if nodeType != ast.PumpType && nodeType != ast.DrainerType {
+			yylex.Errorf("Unknown binlog node type: '%s', only PUMP and DRAINER are supported", $2)
+			return 1
+		}
+		$$ = &ast.ChangeStmt{
+			NodeType:	nodeType,
+			State:		$6,
+			NodeID:		$9,
+		}
+	}
+
+TraceStmt:
+	"TRACE" Statement
+	{
+		if $2 == nil {
+			yylex.Errorf("TRACE requires a statement")
+			return 1
+		}
+		parser.setInnerStmtText($2.(ast.StmtNode), &yyS[yypt])
+		$$ = &ast.TraceStmt{
+			Stmt:	$2.(ast.StmtNode),
+			Format:	ast.TraceFormatRow,
+		}
+	}
+|	"TRACE" "FORMAT" eq StringName Statement
+	{
+		format := strings.ToLower($4.(string))
+		if !isValidTraceFormat(format) {
+			yylex.Errorf("Unknown TRACE format name: '%s'", $4.(string))
+			return 1
+		}
+		if $5 == nil {
+			yylex.Errorf("TRACE requires a statement")
+			return 1
+		}
+		parser.setInnerStmtText($5.(ast.StmtNode), &yyS[yypt])
+		$$ = &ast.TraceStmt{
+			Stmt:	$5.(ast.StmtNode),
+			Format:	format,
+		}
 	}
 
 LengthNum:
@@ -2075,6 +2673,7 @@ FieldList:
 	{
 		field := $1.(*ast.SelectField)
 		field.Offset = parser.startOffset(&yyS[yypt])
+		field.SetOriginTextPosition(field.Offset)
 		$$ = []*ast.SelectField{field}
 	}
 |	FieldList ',' Field
@@ -2088,6 +2687,7 @@ FieldList:
 		}
 		newField := $3.(*ast.SelectField)
 		newField.Offset = parser.startOffset(&yyS[yypt])
+		newField.SetOriginTextPosition(newField.Offset)
 		$$ = append(fl, newField)
 	}
 
@@ -2227,7 +2827,14 @@ UnReservedKeyword:
 | "MIN_ROWS" | "NATIONAL" | "ROW" | "ROW_FORMAT" | "QUARTER" | "GRANTS" | "TRIGGERS" | "DELAY_KEY_WRITE" | "ISOLATION"
 | "REPEATABLE" | "COMMITTED" | "UNCOMMITTED" | "ONLY" | "SERIALIZABLE" | "LEVEL" | "VARIABLES" | "SQL_CACHE" | "INDEXES" | "PROCESSLIST"
 | "SQL_NO_CACHE" | "DISABLE"  | "ENABLE" | "REVERSE" | "SPACE" | "PRIVILEGES" | "NO" | "BINLOG" | "FUNCTION" | "VIEW" | "MODIFY" | "EVENTS" | "PARTITIONS"
-| "TIMESTAMPDIFF"
+| "TIMESTAMPDIFF" | "SAVEPOINT" | "QUERY" | "TIDB" | "ERRORS" | "LOGS" | "TRACE" | "CANCEL" | "JOBS" | "NODE_ID" | "NODE_STATE" | "RECOVER" | "JOB" | "FLASHBACK" | "SHUTDOWN" | "REGIONS" | "SPLIT" | "ROLE" | "NONE" | "BUCKETS" | "CONFIG" | "PLUGINS" | "OPEN" | "CHAIN" | "BINDING" | "BINDINGS" | "REPAIR" | "IMPORT"
+| "CALIBRATE" | "RESOURCE" | "WORKLOAD" | "START_TIME" | "END_TIME" | "DURATION" | "UPGRADE" | "FAST" | "MEDIUM"
+| "EXTENDED" | "CHANGED" | "RESTART" | "HELP" | "STATS" | "PLAN" | "REPLAYER" | "DUMP"
+| "PROFILE" | "PROFILES" | "CPU" | "IPC" | "MEMORY" | "SWAPS" | "SOURCE" | "BLOCK" | "IO" | "CONTEXT" | "SWITCHES" | "PAGE" | "FAULTS"
+| "BACKUP" | "RESTORE" | "INSTANCE" | "RELOAD" | "TLS" | "ERROR"
+| "STATS_META" | "STATS_HISTOGRAMS" | "STATS_BUCKETS" | "STATS_HEALTHY"
+| "BATCH" | "DRY" | "RUN" | "BUILTINS"
+| "WATCH" | "REMOVE" | "SQL" | "DIGEST" | "EXACT" | "SIMILAR" | "DRYRUN" | "COOLDOWN"
 
 ReservedKeyword:
 "ADD" | "ALL" | "ALTER" | "ANALYZE" | "AND" | "AS" | "ASC" | "BETWEEN" | "BIGINT"
@@ -2236,14 +2843,14 @@ ReservedKeyword:
 | "CURRENT_TIMESTAMP" | "CURRENT_USER" | "DATABASE" | "DATABASES" | "DAY_HOUR" | "DAY_MICROSECOND"
 | "DAY_MINUTE" | "DAY_SECOND" | "DECIMAL" | "DEFAULT" | "DELETE" | "DESC" | "DESCRIBE"
 | "DISTINCT" | "DIV" | "DOUBLE" | "DROP" | "DUAL" | "ELSE" | "ENCLOSED" | "ESCAPED"
-| "EXISTS" | "EXPLAIN" | "FALSE" | "FLOAT" | "FOR" | "FORCE" | "FOREIGN" | "FROM"
+| "EXCEPT" | "EXISTS" | "EXPLAIN" | "FALSE" | "FLOAT" | "FOR" | "FORCE" | "FOREIGN" | "FROM"
 | "FULLTEXT" | "GRANT" | "GROUP" | "HAVING" | "HOUR_MICROSECOND" | "HOUR_MINUTE"
 | "HOUR_SECOND" | "IF" | "IGNORE" | "IN" | "INDEX" | "INFILE" | "INNER" | "INSERT" | "INT" | "INTO" | "INTEGER"
-| "INTERVAL" | "IS" | "JOIN" | "KEY" | "KEYS" | "LEADING" | "LEFT" | "LIKE" | "LIMIT" | "LINES" | "LOAD"
+| "INTERVAL" | "IS" | "JOIN" | "KEY" | "KEYS" | "KILL" | "LEADING" | "LEFT" | "LIKE" | "LIMIT" | "LINES" | "LOAD"
 | "LOCALTIME" | "LOCALTIMESTAMP" | "LOCK" | "LONGBLOB" | "LONGTEXT" | "MAXVALUE" | "MEDIUMBLOB" | "MEDIUMINT" | "MEDIUMTEXT"
 | "MINUTE_MICROSECOND" | "MINUTE_SECOND" | "MOD" | "NOT" | "NO_WRITE_TO_BINLOG" | "NULL" | "NUMERIC"
-| "ON" | "OPTION" | "OR" | "ORDER" | "OUTER" | "PARTITION" | "PRECISION" | "PRIMARY" | "PROCEDURE" | "RANGE" | "READ" 
-| "REAL" | "REFERENCES" | "REGEXP" | "RENAME" | "REPEAT" | "REPLACE" | "RESTRICT" | "RIGHT" | "RLIKE"
+| "ON" | "OPTIMIZE" | "OPTION" | "OR" | "ORDER" | "OUTER" | "PARTITION" | "PRECISION" | "PRIMARY" | "PROCEDURE" | "RANGE" | "READ" 
+| "REAL" | "REFERENCES" | "REGEXP" | "RELEASE" | "RENAME" | "REPEAT" | "REPLACE" | "RESTRICT" | "REVOKE" | "RIGHT" | "RLIKE"
 | "SCHEMA" | "SCHEMAS" | "SECOND_MICROSECOND" | "SELECT" | "SET" | "SHOW" | "SMALLINT"
 | "STARTING" | "TABLE" | "TERMINATED" | "THEN" | "TINYBLOB" | "TINYINT" | "TINYTEXT" | "TO"
 | "TRAILING" | "TRUE" | "UNION" | "UNIQUE" | "UNLOCK" | "UNSIGNED"
@@ -2462,7 +3069,10 @@ Operand:
 	}
 |	ColumnName
 	{
-		$$ = &ast.ColumnNameExpr{Name: $1.(*ast.ColumnName)}
+		name := $1.(*ast.ColumnName)
+		expr := &ast.ColumnNameExpr{Name: name}
+		expr.SetOriginTextPosition(name.OriginTextPosition())
+		$$ = expr
 	}
 |	'(' Expression ')'
 	{
@@ -2470,7 +3080,9 @@ Operand:
 		endOffset := parser.endOffset(&yyS[yypt])
 		expr := $2.(ast.ExprNode)
 		expr.SetText(parser.src[startOffset:endOffset])
-		$$ = &ast.ParenthesesExpr{Expr: expr}
+		paren := &ast.ParenthesesExpr{Expr: expr}
+		paren.SetOriginTextPosition(parser.startOffset(&yyS[yypt-2]))
+		$$ = paren
 	}
 |	"DEFAULT" %prec lowerThanLeftParen
 	{
@@ -2486,9 +3098,20 @@ Operand:
 	}
 |	"PLACEHOLDER"
 	{
-		$$ = &ast.ParamMarkerExpr{
+		marker := &ast.ParamMarkerExpr{
+			Offset: yyS[yypt].offset,
+		}
+		marker.SetOriginTextPosition(marker.Offset)
+		$$ = marker
+	}
+|	"NAMED_PLACEHOLDER"
+	{
+		marker := &ast.ParamMarkerExpr{
 			Offset: yyS[yypt].offset,
+			Name:	strings.TrimPrefix($1.(string), ":"),
 		}
+		marker.SetOriginTextPosition(marker.Offset)
+		$$ = marker
 	}
 |	"ROW" '(' ExpressionList ',' Expression ')'
 	{
@@ -4269,11 +4892,15 @@ LowPriorityOptional:
 TableName:
 	Identifier
 	{
-		$$ = &ast.TableName{Name:model.NewCIStr($1)}
+		name := &ast.TableName{Name:model.NewCIStr($1)}
+		name.SetOriginTextPosition(parser.startOffset(&yyS[yypt]))
+		$$ = name
 	}
 |	IdentifierOrReservedKeyword '.' IdentifierOrReservedKeyword
 	{
-		$$ = &ast.TableName{Schema:model.NewCIStr($1),	Name:model.NewCIStr($3)}
+		name := &ast.TableName{Schema:model.NewCIStr($1),	Name:model.NewCIStr($3)}
+		name.SetOriginTextPosition(parser.startOffset(&yyS[yypt-2]))
+		$$ = name
 	}
 
 TableNameList:
@@ -4287,6 +4914,15 @@ TableNameList:
 		$$ = append($1.([]*ast.TableName), $3.(*ast.TableName))
 	}
 
+TableOptimizerHintsOpt:
+	{
+		$$ = nil
+	}
+|	tableHints
+	{
+		$$ = $1
+	}
+
 QuickOptional:
 	%prec lowerThanQuick
 	{
@@ -4379,26 +5015,53 @@ DeallocateSym:
 
 
 RollbackStmt:
-	"ROLLBACK"
+	"ROLLBACK" CompletionChainOpt CompletionReleaseOpt
 	{
-		$$ = &ast.RollbackStmt{}
+		if $2.(ast.CompletionType) == ast.CompletionTypeYes && $3.(ast.CompletionType) == ast.CompletionTypeYes {
+			yylex.Errorf("AND CHAIN and RELEASE can't be used together")
+			return 1
+		}
+		$$ = &ast.RollbackStmt{Chain: $2.(ast.CompletionType), Release: $3.(ast.CompletionType)}
 	}
-
-SelectStmt:
-	"SELECT" SelectStmtOpts SelectStmtFieldList SelectStmtLimit SelectLockOpt
+|	"ROLLBACK" "TO" Identifier
 	{
-		st := &ast.SelectStmt {
-			Distinct:      $2.(bool),
-			Fields:        $3.(*ast.FieldList),
-			LockTp:	       $5.(ast.SelectLockType),
-		}
+		$$ = &ast.RollbackStmt{SavepointName: $3}
+	}
+|	"ROLLBACK" "TO" "SAVEPOINT" Identifier
+	{
+		$$ = &ast.RollbackStmt{SavepointName: $4}
+	}
+
+SavepointStmt:
+	"SAVEPOINT" Identifier
+	{
+		$$ = &ast.SavepointStmt{Name: $2}
+	}
+
+ReleaseSavepointStmt:
+	"RELEASE" "SAVEPOINT" Identifier
+	{
+		$$ = &ast.ReleaseSavepointStmt{Name: $3}
+	}
+
+SelectStmt:
+	"SELECT" TableOptimizerHintsOpt SelectStmtOpts SelectStmtFieldList SelectStmtLimit SelectLockOpt
+	{
+		st := &ast.SelectStmt {
+			Distinct:      $3.(bool),
+			Fields:        $4.(*ast.FieldList),
+			LockTp:	       $6.(ast.SelectLockType),
+		}
+		if $2 != nil {
+			st.TableHints = $2.([]*ast.TableOptimizerHint)
+		}
 		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
 		if lastField.Expr != nil && lastField.AsName.O == "" {
 			src := parser.src
 			var lastEnd int
-			if $4 != nil {
+			if $5 != nil {
 				lastEnd = yyS[yypt-1].offset-1
-			} else if $5 != ast.SelectLockNone {
+			} else if $6 != ast.SelectLockNone {
 				lastEnd = yyS[yypt].offset-1
 			} else {
 				lastEnd = len(src)
@@ -4408,40 +5071,46 @@ SelectStmt:
 			}
 			lastField.SetText(src[lastField.Offset:lastEnd])
 		}
-		if $4 != nil {
-			st.Limit = $4.(*ast.Limit)
+		if $5 != nil {
+			st.Limit = $5.(*ast.Limit)
 		}
 		$$ = st
 	}
-|	"SELECT" SelectStmtOpts SelectStmtFieldList FromDual WhereClauseOptional SelectStmtLimit SelectLockOpt
+|	"SELECT" TableOptimizerHintsOpt SelectStmtOpts SelectStmtFieldList FromDual WhereClauseOptional SelectStmtLimit SelectLockOpt
 	{
 		st := &ast.SelectStmt {
-			Distinct:      $2.(bool),
-			Fields:        $3.(*ast.FieldList),
-			LockTp:	       $7.(ast.SelectLockType),
+			Distinct:      $3.(bool),
+			Fields:        $4.(*ast.FieldList),
+			LockTp:	       $8.(ast.SelectLockType),
+		}
+		if $2 != nil {
+			st.TableHints = $2.([]*ast.TableOptimizerHint)
 		}
 		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
 		if lastField.Expr != nil && lastField.AsName.O == "" {
 			lastEnd := yyS[yypt-3].offset-1
 			lastField.SetText(parser.src[lastField.Offset:lastEnd])
 		}
-		if $5 != nil {
-			st.Where = $5.(ast.ExprNode)
-		}
 		if $6 != nil {
-			st.Limit = $6.(*ast.Limit)
+			st.Where = $6.(ast.ExprNode)
+		}
+		if $7 != nil {
+			st.Limit = $7.(*ast.Limit)
 		}
 		$$ = st
 	}
-|	"SELECT" SelectStmtOpts SelectStmtFieldList "FROM"
+|	"SELECT" TableOptimizerHintsOpt SelectStmtOpts SelectStmtFieldList "FROM"
 	TableRefsClause WhereClauseOptional SelectStmtGroup HavingClause OrderByOptional
 	SelectStmtLimit SelectLockOpt
 	{
 		st := &ast.SelectStmt{
-			Distinct:	$2.(bool),
-			Fields:		$3.(*ast.FieldList),
-			From:		$5.(*ast.TableRefsClause),
-			LockTp:		$11.(ast.SelectLockType),
+			Distinct:	$3.(bool),
+			Fields:		$4.(*ast.FieldList),
+			From:		$6.(*ast.TableRefsClause),
+			LockTp:		$12.(ast.SelectLockType),
+		}
+		if $2 != nil {
+			st.TableHints = $2.([]*ast.TableOptimizerHint)
 		}
 
 		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
@@ -4450,24 +5119,24 @@ SelectStmt:
 			lastField.SetText(parser.src[lastField.Offset:lastEnd])
 		}
 
-		if $6 != nil {
-			st.Where = $6.(ast.ExprNode)
-		}
-
 		if $7 != nil {
-			st.GroupBy = $7.(*ast.GroupByClause)
+			st.Where = $7.(ast.ExprNode)
 		}
 
 		if $8 != nil {
-			st.Having = $8.(*ast.HavingClause)
+			st.GroupBy = $8.(*ast.GroupByClause)
 		}
 
 		if $9 != nil {
-			st.OrderBy = $9.(*ast.OrderByClause)
+			st.Having = $9.(*ast.HavingClause)
 		}
 
 		if $10 != nil {
-			st.Limit = $10.(*ast.Limit)
+			st.OrderBy = $10.(*ast.OrderByClause)
+		}
+
+		if $11 != nil {
+			st.Limit = $11.(*ast.Limit)
 		}
 
 		$$ = st
@@ -4699,6 +5368,13 @@ LimitOption:
 			Offset: yyS[yypt].offset,
 		}
 	}
+|	"NAMED_PLACEHOLDER"
+	{
+		$$ = &ast.ParamMarkerExpr{
+			Offset: yyS[yypt].offset,
+			Name:	strings.TrimPrefix($1.(string), ":"),
+		}
+	}
 
 SelectStmtLimit:
 	{
@@ -4784,7 +5460,9 @@ SubSelect:
 		src := parser.src
 		// See the implementation of yyParse function
 		s.SetText(src[yyS[yypt-1].offset-1:yyS[yypt].offset-1])
-		$$ = &ast.SubqueryExpr{Query: s}
+		subquery := &ast.SubqueryExpr{Query: s}
+		subquery.SetOriginTextPosition(parser.startOffset(&yyS[yypt-2]))
+		$$ = subquery
 	}
 |	'(' UnionStmt ')'
 	{
@@ -4792,7 +5470,9 @@ SubSelect:
 		src := parser.src
 		// See the implementation of yyParse function
 		s.SetText(src[yyS[yypt-1].offset-1:yyS[yypt].offset-1])
-		$$ = &ast.SubqueryExpr{Query: s}
+		subquery := &ast.SubqueryExpr{Query: s}
+		subquery.SetOriginTextPosition(parser.startOffset(&yyS[yypt-2]))
+		$$ = subquery
 	}
 
 // See https://dev.mysql.com/doc/refman/5.7/en/innodb-locking-reads.html
@@ -4889,7 +5569,21 @@ UnionOpt:
 SetStmt:
 	"SET" VariableAssignmentList
 	{
-		$$ = &ast.SetStmt{Variables: $2.([]*ast.VariableAssignment)}
+		vars := $2.([]*ast.VariableAssignment)
+		if len(vars) == 1 && vars[0].Name == ast.SetNames {
+			stmt := &ast.SetNamesStmt{}
+			if vars[0].IsDefault {
+				stmt.IsDefault = true
+			} else {
+				stmt.Charset = vars[0].Value.GetDatum().GetString()
+			}
+			if vars[0].ExtendValue != nil {
+				stmt.Collate = vars[0].ExtendValue.GetDatum().GetString()
+			}
+			$$ = stmt
+		} else {
+			$$ = &ast.SetStmt{Variables: vars}
+		}
 	}
 |	"SET" "PASSWORD" eq PasswordOpt
 	{
@@ -4897,53 +5591,178 @@ SetStmt:
 	}
 |	"SET" "PASSWORD" "FOR" Username eq PasswordOpt
 	{
-		$$ = &ast.SetPwdStmt{User: $4.(string), Password: $6.(string)}
+		$$ = &ast.SetPwdStmt{User: $4.(*ast.UserIdentity), Password: $6.(string)}
+	}
+|	"SET" "TRANSACTION" TransactionChars
+	{
+		$$ = $3.(*ast.SetTransactionStmt)
 	}
 |	"SET" "GLOBAL" "TRANSACTION" TransactionChars
 	{
-		// Parsed but ignored
+		stmt := $4.(*ast.SetTransactionStmt)
+		stmt.Scope = ast.TransactionScopeGlobal
+		$$ = stmt
 	}
 |	"SET" "SESSION" "TRANSACTION" TransactionChars
 	{
-		// Parsed but ignored
+		stmt := $4.(*ast.SetTransactionStmt)
+		stmt.Scope = ast.TransactionScopeSession
+		$$ = stmt
+	}
+
+SetConfigStmt:
+	"SET" "CONFIG" Identifier ConfigItemName eq Expression
+	{
+		$$ = &ast.SetConfigStmt{Type: strings.ToLower($3), Name: $4, Value: $6.(ast.ExprNode)}
+	}
+|	"SET" "CONFIG" stringLit ConfigItemName eq Expression
+	{
+		$$ = &ast.SetConfigStmt{Instance: $3, Name: $4, Value: $6.(ast.ExprNode)}
+	}
+
+ConfigItemName:
+	Identifier
+	{
+		$$ = $1
+	}
+|	ConfigItemName '.' Identifier
+	{
+		$$ = $1 + "." + $3
+	}
+|	ConfigItemName '-' Identifier
+	{
+		$$ = $1 + "-" + $3
+	}
+
+SetRoleStmt:
+	"SET" "ROLE" SetRoleOpt
+	{
+		$$ = $3.(*ast.SetRoleStmt)
+	}
+
+SetRoleOpt:
+	"DEFAULT"
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleDefault}
+	}
+|	"NONE"
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleNone}
+	}
+|	"ALL"
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleAll}
+	}
+|	"ALL" "EXCEPT" RolenameList
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleAllExcept, RoleList: $3.([]*ast.RoleIdentity)}
+	}
+|	RolenameList
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleRegular, RoleList: $1.([]*ast.RoleIdentity)}
+	}
+
+SetDefaultRoleStmt:
+	"SET" "DEFAULT" "ROLE" SetDefaultRoleOpt "TO" UsernameList
+	{
+		stmt := $4.(*ast.SetDefaultRoleStmt)
+		stmt.UserList = $6.([]*ast.UserIdentity)
+		$$ = stmt
+	}
+
+SetDefaultRoleOpt:
+	"NONE"
+	{
+		$$ = &ast.SetDefaultRoleStmt{SetRoleOpt: ast.SetRoleNone}
+	}
+|	"ALL"
+	{
+		$$ = &ast.SetDefaultRoleStmt{SetRoleOpt: ast.SetRoleAll}
+	}
+|	RolenameList
+	{
+		$$ = &ast.SetDefaultRoleStmt{SetRoleOpt: ast.SetRoleRegular, RoleList: $1.([]*ast.RoleIdentity)}
 	}
 
 TransactionChars:
 	TransactionChar
+	{
+		$$ = $1
+	}
 |	TransactionChars ',' TransactionChar
+	{
+		stmt := $1.(*ast.SetTransactionStmt)
+		char := $3.(*ast.SetTransactionStmt)
+		if char.IsolationLevel != "" {
+			if stmt.IsolationLevel != "" {
+				yylex.Errorf("Multiple ISOLATION LEVEL clauses")
+				return 1
+			}
+			stmt.IsolationLevel = char.IsolationLevel
+		}
+		if char.AccessMode != "" {
+			if stmt.AccessMode != "" {
+				yylex.Errorf("Multiple READ ONLY/READ WRITE clauses")
+				return 1
+			}
+			stmt.AccessMode = char.AccessMode
+		}
+		$$ = stmt
+	}
 
 TransactionChar:
 	"ISOLATION" "LEVEL" IsolationLevel
+	{
+		$$ = &ast.SetTransactionStmt{IsolationLevel: $3}
+	}
 |	"READ" "WRITE"
+	{
+		$$ = &ast.SetTransactionStmt{AccessMode: ast.TransactionReadWrite}
+	}
 |	"READ" "ONLY"
+	{
+		$$ = &ast.SetTransactionStmt{AccessMode: ast.TransactionReadOnly}
+	}
 
 IsolationLevel:
 	"REPEATABLE" "READ"
+	{
+		$$ = ast.RepeatableRead
+	}
 |	"READ"	"COMMITTED"
+	{
+		$$ = ast.ReadCommitted
+	}
 |	"READ"	"UNCOMMITTED"
+	{
+		$$ = ast.ReadUncommitted
+	}
 |	"SERIALIZABLE"
+	{
+		$$ = ast.Serializable
+	}
 
 VariableAssignment:
 	Identifier eq Expression
 	{
-		$$ = &ast.VariableAssignment{Name: $1, Value: $3.(ast.ExprNode), IsSystem: true}
+		$$ = &ast.VariableAssignment{Name: $1, Value: $3.(ast.ExprNode), IsSystem: true, Syntax: ast.SysVarSyntaxKeyword, ImplicitScope: true}
 	}
 |	"GLOBAL" Identifier eq Expression
 	{
-		$$ = &ast.VariableAssignment{Name: $2, Value: $4.(ast.ExprNode), IsGlobal: true, IsSystem: true}
+		$$ = &ast.VariableAssignment{Name: $2, Value: $4.(ast.ExprNode), IsGlobal: true, IsSystem: true, Syntax: ast.SysVarSyntaxKeyword}
 	}
 |	"SESSION" Identifier eq Expression
 	{
-		$$ = &ast.VariableAssignment{Name: $2, Value: $4.(ast.ExprNode), IsSystem: true}
+		$$ = &ast.VariableAssignment{Name: $2, Value: $4.(ast.ExprNode), IsSystem: true, Syntax: ast.SysVarSyntaxKeyword}
 	}
 |	"LOCAL" Identifier eq Expression
 	{
-		$$ = &ast.VariableAssignment{Name: $2, Value: $4.(ast.ExprNode), IsSystem: true}
+		$$ = &ast.VariableAssignment{Name: $2, Value: $4.(ast.ExprNode), IsSystem: true, Syntax: ast.SysVarSyntaxKeyword}
 	}
 |	"SYS_VAR" eq Expression
 	{
 		v := strings.ToLower($1.(string))
-		var isGlobal bool
+		var isGlobal, implicitScope bool
 		if strings.HasPrefix(v, "@@global.") {
 			isGlobal = true
 			v = strings.TrimPrefix(v, "@@global.")
@@ -4952,9 +5771,10 @@ VariableAssignment:
 		} else if strings.HasPrefix(v, "@@local.") {
 			v = strings.TrimPrefix(v, "@@local.")
 		} else if strings.HasPrefix(v, "@@") {
+			implicitScope = true
 			v = strings.TrimPrefix(v, "@@")
 		}
-		$$ = &ast.VariableAssignment{Name: v, Value: $3.(ast.ExprNode), IsGlobal: isGlobal, IsSystem: true}
+		$$ = &ast.VariableAssignment{Name: v, Value: $3.(ast.ExprNode), IsGlobal: isGlobal, IsSystem: true, ImplicitScope: implicitScope}
 	}
 |	"USER_VAR" eq Expression
 	{
@@ -4983,6 +5803,13 @@ VariableAssignment:
 			ExtendValue: ast.NewValueExpr($4.(string)),
 		}
 	}
+|	"NAMES" "DEFAULT"
+	{
+		$$ = &ast.VariableAssignment{
+			Name: ast.SetNames,
+			IsDefault: true,
+		}
+	}
 |	CharsetKw CharsetName
 	{
 		$$ = &ast.VariableAssignment{
@@ -5007,11 +5834,11 @@ VariableAssignmentList:
 	}
 |	VariableAssignment
 	{
-		$$ = []*ast.VariableAssignment{$1.(*ast.VariableAssignment)}
+		$$ = []*ast.VariableAssignment{setDefaultIntent($1.(*ast.VariableAssignment))}
 	}
 |	VariableAssignmentList ',' VariableAssignment
 	{
-		$$ = append($1.([]*ast.VariableAssignment), $3.(*ast.VariableAssignment))
+		$$ = append($1.([]*ast.VariableAssignment), setDefaultIntent($3.(*ast.VariableAssignment)))
 	}
 
 Variable:
@@ -5044,21 +5871,63 @@ UserVariable:
 	}
 
 Username:
-	stringLit "AT" stringLit
+	stringLit
+	{
+		$$ = &ast.UserIdentity{Username: $1, Hostname: "%"}
+	}
+|	stringLit "AT" stringLit
 	{
-		$$ = $1 + "@" + $3
+		$$ = &ast.UserIdentity{Username: $1, Hostname: $3}
+	}
+|	"CURRENT_USER" OptionalBraces
+	{
+		$$ = &ast.UserIdentity{CurrentUser: true}
 	}
 
 UsernameList:
     Username
     {
-        $$ = []string{$1.(string)}
+        $$ = []*ast.UserIdentity{$1.(*ast.UserIdentity)}
     }
 |   UsernameList ',' Username
     {
-        $$ = append($1.([]string), $3.(string))
+        $$ = append($1.([]*ast.UserIdentity), $3.(*ast.UserIdentity))
     }
 
+Rolename:
+	stringLit
+	{
+		$$ = &ast.RoleIdentity{Username: $1, Hostname: "%"}
+	}
+|	stringLit "AT" stringLit
+	{
+		$$ = &ast.RoleIdentity{Username: $1, Hostname: $3}
+	}
+
+RolenameList:
+	Rolename
+	{
+		$$ = []*ast.RoleIdentity{$1.(*ast.RoleIdentity)}
+	}
+|	RolenameList ',' Rolename
+	{
+		$$ = append($1.([]*ast.RoleIdentity), $3.(*ast.RoleIdentity))
+	}
+
+UsingRoles:
+	{
+		$$ = []*ast.RoleIdentity(nil)
+	}
+|	"USING" RolenameList
+	{
+		$$ = $2
+	}
+
+OptionalBraces:
+	{}
+|	'(' ')'
+	{}
+
 PasswordOpt:
 	stringLit
 	{
@@ -5075,6 +5944,41 @@ AuthString:
 		$$ = $1
 	}
 
+/****************************Kill Statement*******************************/
+KillStmt:
+	KillOrKillTiDB LengthNum
+	{
+		$$ = &ast.KillStmt{
+			ConnectionID:	$2.(uint64),
+			TiDBExtension:	$1 == "TIDB",
+		}
+	}
+|	KillOrKillTiDB "CONNECTION" LengthNum
+	{
+		$$ = &ast.KillStmt{
+			ConnectionID:	$3.(uint64),
+			TiDBExtension:	$1 == "TIDB",
+		}
+	}
+|	KillOrKillTiDB "QUERY" LengthNum
+	{
+		$$ = &ast.KillStmt{
+			ConnectionID:	$3.(uint64),
+			Query:		true,
+			TiDBExtension:	$1 == "TIDB",
+		}
+	}
+
+KillOrKillTiDB:
+	"KILL"
+	{
+		$$ = "KILL"
+	}
+|	"KILL" "TIDB"
+	{
+		$$ = "TIDB"
+	}
+
 /****************************Admin Statement*******************************/
 AdminStmt:
 	"ADMIN" "SHOW" "DDL"
@@ -5088,10 +5992,55 @@ AdminStmt:
 			Tables: $4.([]*ast.TableName),
 		}
 	}
+|	"ADMIN" "CHECK" "INDEX" TableName Identifier
+	{
+		$$ = &ast.AdminStmt{
+			Tp:	ast.AdminCheckIndex,
+			Tables: []*ast.TableName{$4.(*ast.TableName)},
+			Index:	$5,
+		}
+	}
+|	"ADMIN" "SHOW" "DDL" "JOBS"
+	{
+		$$ = &ast.AdminStmt{Tp: ast.AdminShowDDLJobs}
+	}
+|	"ADMIN" "CANCEL" "DDL" "JOBS" NumList
+	{
+		$$ = &ast.AdminStmt{
+			Tp:	ast.AdminCancelDDLJobs,
+			JobIDs:	$5.([]int64),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Repair Table Statement
+ *
+ *  Example:
+ *      ADMIN REPAIR TABLE t CREATE TABLE t (a int)
+ *******************************************************************/
+RepairTableStmt:
+	"ADMIN" "REPAIR" "TABLE" TableName CreateTableStmt
+	{
+		$$ = &ast.RepairTableStmt{
+			Table:		$4.(*ast.TableName),
+			CreateStmt:	$5.(*ast.CreateTableStmt),
+		}
+	}
+
+NumList:
+	LengthNum
+	{
+		$$ = []int64{int64($1.(uint64))}
+	}
+|	NumList ',' LengthNum
+	{
+		$$ = append($1.([]int64), int64($3.(uint64)))
+	}
 
 /****************************Show Statement*******************************/
 ShowStmt:
-	"SHOW" ShowTargetFilterable ShowLikeOrWhereOpt
+	"SHOW" ShowTargetFilterable ShowLikeOrWhereOpt OrderByOptional SelectStmtLimit
 	{
 		stmt := $2.(*ast.ShowStmt)
 		if $3 != nil {
@@ -5101,8 +6050,44 @@ ShowStmt:
 				stmt.Where = $3.(ast.ExprNode)
 			}
 		}
+		if $4 != nil {
+			stmt.OrderBy = $4.(*ast.OrderByClause)
+		}
+		if $5 != nil {
+			stmt.Limit = $5.(*ast.Limit)
+		}
 		$$ = stmt
 	}
+|	"SHOW" "WARNINGS" SelectStmtLimit
+	{
+		stmt := &ast.ShowStmt{Tp: ast.ShowWarnings}
+		if $3 != nil {
+			stmt.Limit = $3.(*ast.Limit)
+		}
+		$$ = stmt
+	}
+|	"SHOW" "ERRORS" SelectStmtLimit
+	{
+		stmt := &ast.ShowStmt{Tp: ast.ShowErrors}
+		if $3 != nil {
+			stmt.Limit = $3.(*ast.Limit)
+		}
+		$$ = stmt
+	}
+|	"SHOW" "COUNT" '(' '*' ')' "WARNINGS"
+	{
+		$$ = &ast.ShowStmt{
+			Tp:		ast.ShowWarnings,
+			CountOnly:	true,
+		}
+	}
+|	"SHOW" "COUNT" '(' '*' ')' "ERRORS"
+	{
+		$$ = &ast.ShowStmt{
+			Tp:		ast.ShowErrors,
+			CountOnly:	true,
+		}
+	}
 |	"SHOW" "CREATE" "TABLE" TableName
 	{
 		$$ = &ast.ShowStmt{
@@ -5110,7 +6095,7 @@ ShowStmt:
 			Table:	$4.(*ast.TableName),
 		}
 	}
-|	"SHOW" "CREATE" "DATABASE" DBName 
+|	"SHOW" "CREATE" DatabaseSym DBName
 	{
 		$$ = &ast.ShowStmt{
 			Tp:	ast.ShowCreateDatabase,
@@ -5122,18 +6107,82 @@ ShowStmt:
 		// See https://dev.mysql.com/doc/refman/5.7/en/show-grants.html
 		$$ = &ast.ShowStmt{Tp: ast.ShowGrants}
 	}
-|	"SHOW" "GRANTS" "FOR" Username
+|	"SHOW" "GRANTS" "FOR" Username UsingRoles
 	{
 		// See https://dev.mysql.com/doc/refman/5.7/en/show-grants.html
 		$$ = &ast.ShowStmt{
 			Tp:	ast.ShowGrants,
-			User:	$4.(string),
+			User:	$4.(*ast.UserIdentity),
+			Roles:	$5.([]*ast.RoleIdentity),
 		}
 	}
-|	"SHOW" "PROCESSLIST"
+|	"SHOW" "ENGINES"
 	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/show-engines.html
+		$$ = &ast.ShowStmt{Tp: ast.ShowEngines}
+	}
+|	"SHOW" "PLUGINS"
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/show-plugins.html
+		$$ = &ast.ShowStmt{Tp: ast.ShowPlugins}
+	}
+|	"SHOW" "PRIVILEGES"
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/show-privileges.html
+		$$ = &ast.ShowStmt{Tp: ast.ShowPrivileges}
+	}
+|	"SHOW" "BUILTINS"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowBuiltins}
+	}
+|	"SHOW" "PROFILES"
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/show-profiles.html
+		$$ = &ast.ShowStmt{Tp: ast.ShowProfiles}
+	}
+|	"SHOW" "PROFILE" ShowProfileTypesOpt ShowProfileForQueryOpt SelectStmtLimit
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/show-profile.html
+		stmt := &ast.ShowStmt{
+			Tp:		ast.ShowProfile,
+			ProfileTypes:	$3.([]ast.ProfileType),
+		}
+		if $4 != nil {
+			queryID := $4.(uint64)
+			stmt.ProfileQueryID = &queryID
+		}
+		if $5 != nil {
+			stmt.Limit = $5.(*ast.Limit)
+		}
+		$$ = stmt
+	}
+|	"SHOW" "OPEN" "TABLES" ShowDatabaseNameOpt
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/show-open-tables.html
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowOpenTables,
+			DBName:	$4.(string),
+		}
+	}
+|	"SHOW" "OPEN" "TABLES" ShowDatabaseNameOpt "LIKE" PrimaryExpression
+	{
+		// OPEN TABLES only takes LIKE, WHERE is rejected here.
 		$$ = &ast.ShowStmt{
-			Tp: ast.ShowProcessList,
+			Tp:	ast.ShowOpenTables,
+			DBName:	$4.(string),
+			Pattern: &ast.PatternLikeExpr{
+				Pattern: $6.(ast.ExprNode),
+				Escape: '\\',
+			},
+		}
+	}
+|	"SHOW" OptFull "PROCESSLIST"
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/show-processlist.html
+		// PROCESSLIST is not filterable, LIKE and WHERE are rejected here.
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowProcessList,
+			Full:	$2.(bool),
 		}
 	}
 
@@ -5146,11 +6195,7 @@ FromOrIn:
 "FROM" | "IN"
 
 ShowTargetFilterable:
-	"ENGINES"
-	{
-		$$ = &ast.ShowStmt{Tp: ast.ShowEngines}
-	}
-|	"DATABASES"
+	"DATABASES"
 	{
 		$$ = &ast.ShowStmt{Tp: ast.ShowDatabases}
 	}
@@ -5158,7 +6203,7 @@ ShowTargetFilterable:
 	{
 		$$ = &ast.ShowStmt{Tp: ast.ShowDatabases}
 	}
-|	"CHARACTER" "SET"
+|	CharsetKw
 	{
 		$$ = &ast.ShowStmt{Tp: ast.ShowCharset}
 	}
@@ -5192,6 +6237,22 @@ ShowTargetFilterable:
         }
         $$ = show
     }
+|	"EXTENDED" ShowIndexKwd FromOrIn TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:		ast.ShowIndex,
+			Table:		$4.(*ast.TableName),
+			Extended:	true,
+		}
+	}
+|	"EXTENDED" ShowIndexKwd FromOrIn Identifier FromOrIn Identifier
+	{
+		$$ = &ast.ShowStmt{
+			Tp:		ast.ShowIndex,
+			Table:		&ast.TableName{Name: model.NewCIStr($4), Schema: model.NewCIStr($6)},
+			Extended:	true,
+		}
+	}
 |	OptFull "COLUMNS" ShowTableAliasOpt ShowDatabaseNameOpt
 	{
 		$$ = &ast.ShowStmt{
@@ -5211,22 +6272,38 @@ ShowTargetFilterable:
 			Full:	$1.(bool),
 		}
 	}
-|	"WARNINGS"
+|	"EXTENDED" OptFull FieldsOrColumns ShowTableAliasOpt ShowDatabaseNameOpt
 	{
-		$$ = &ast.ShowStmt{Tp: ast.ShowWarnings}
+		$$ = &ast.ShowStmt{
+			Tp:		ast.ShowColumns,
+			Table:		$4.(*ast.TableName),
+			DBName:		$5.(string),
+			Full:		$2.(bool),
+			Extended:	true,
+		}
 	}
-|	GlobalScope "VARIABLES"
+|	ShowScope "VARIABLES"
 	{
 		$$ = &ast.ShowStmt{
-			Tp: ast.ShowVariables,
-			GlobalScope: $1.(bool),
+			Tp:		ast.ShowVariables,
+			Scope:		$1.(ast.ShowScope),
+			GlobalScope:	$1.(ast.ShowScope) == ast.ShowScopeGlobal,
 		}
 	}
-|	GlobalScope "STATUS"
+|	ShowScope "STATUS"
 	{
 		$$ = &ast.ShowStmt{
-			Tp: ast.ShowStatus,
-			GlobalScope: $1.(bool),
+			Tp:		ast.ShowStatus,
+			Scope:		$1.(ast.ShowScope),
+			GlobalScope:	$1.(ast.ShowScope) == ast.ShowScopeGlobal,
+		}
+	}
+|	ShowScope "BINDINGS"
+	{
+		$$ = &ast.ShowStmt{
+			Tp:		ast.ShowBindings,
+			Scope:		$1.(ast.ShowScope),
+			GlobalScope:	$1.(ast.ShowScope) == ast.ShowScopeGlobal,
 		}
 	}
 |	"COLLATION"
@@ -5235,6 +6312,22 @@ ShowTargetFilterable:
 			Tp: 	ast.ShowCollation,
 		}
 	}
+|	"STATS_META"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowStatsMeta}
+	}
+|	"STATS_HISTOGRAMS"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowStatsHistograms}
+	}
+|	"STATS_BUCKETS"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowStatsBuckets}
+	}
+|	"STATS_HEALTHY"
+	{
+		$$ = &ast.ShowStmt{Tp: ast.ShowStatsHealthy}
+	}
 |	"TRIGGERS" ShowDatabaseNameOpt
 	{
 		$$ = &ast.ShowStmt{
@@ -5265,6 +6358,69 @@ ShowTargetFilterable:
         	DBName:	$2.(string),
        	}
     }
+ShowProfileTypesOpt:
+	{
+		$$ = []ast.ProfileType(nil)
+	}
+|	ShowProfileTypes
+
+ShowProfileTypes:
+	ShowProfileType
+	{
+		$$ = []ast.ProfileType{$1.(ast.ProfileType)}
+	}
+|	ShowProfileTypes ',' ShowProfileType
+	{
+		$$ = append($1.([]ast.ProfileType), $3.(ast.ProfileType))
+	}
+
+ShowProfileType:
+	"ALL"
+	{
+		$$ = ast.ProfileTypeAll
+	}
+|	"BLOCK" "IO"
+	{
+		$$ = ast.ProfileTypeBlockIO
+	}
+|	"CONTEXT" "SWITCHES"
+	{
+		$$ = ast.ProfileTypeContextSwitches
+	}
+|	"CPU"
+	{
+		$$ = ast.ProfileTypeCPU
+	}
+|	"IPC"
+	{
+		$$ = ast.ProfileTypeIPC
+	}
+|	"MEMORY"
+	{
+		$$ = ast.ProfileTypeMemory
+	}
+|	"PAGE" "FAULTS"
+	{
+		$$ = ast.ProfileTypePageFaults
+	}
+|	"SOURCE"
+	{
+		$$ = ast.ProfileTypeSource
+	}
+|	"SWAPS"
+	{
+		$$ = ast.ProfileTypeSwaps
+	}
+
+ShowProfileForQueryOpt:
+	{
+		$$ = nil
+	}
+|	"FOR" "QUERY" LengthNum
+	{
+		$$ = $3
+	}
+
 ShowLikeOrWhereOpt:
 	{
 		$$ = nil
@@ -5281,17 +6437,17 @@ ShowLikeOrWhereOpt:
 		$$ = $2.(ast.ExprNode)
 	}
 
-GlobalScope:
+ShowScope:
 	{
-		$$ = false
+		$$ = ast.ShowScopeUnspecified
 	}
 |	"GLOBAL"
 	{
-		$$ = true
+		$$ = ast.ShowScopeGlobal
 	}
 |	"SESSION"
 	{
-		$$ = false
+		$$ = ast.ShowScopeSession
 	}
 
 OptFull:
@@ -5334,6 +6490,76 @@ FlushStmt:
 		$$ = tmp
 	}
 
+/*******************************************************************
+ *
+ *  Optimize Table Statement
+ *  See https://dev.mysql.com/doc/refman/5.7/en/optimize-table.html
+ *******************************************************************/
+OptimizeTableStmt:
+	"OPTIMIZE" NoWriteToBinLogAliasOpt "TABLE" TableNameList
+	{
+		$$ = &ast.OptimizeTableStmt{
+			NoWriteToBinLog:	$2.(bool),
+			Tables:			$4.([]*ast.TableName),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Check Table Statement
+ *  See https://dev.mysql.com/doc/refman/5.7/en/check-table.html
+ *******************************************************************/
+CheckTableStmt:
+	"CHECK" "TABLE" TableNameList CheckTableOptionListOpt
+	{
+		$$ = &ast.CheckTableStmt{
+			Tables:		$3.([]*ast.TableName),
+			CheckOptions:	$4.([]string),
+		}
+	}
+
+CheckTableOptionListOpt:
+	{
+		$$ = []string(nil)
+	}
+|	CheckTableOptionList
+
+CheckTableOptionList:
+	CheckTableOption
+	{
+		$$ = []string{$1.(string)}
+	}
+|	CheckTableOptionList CheckTableOption
+	{
+		$$ = append($1.([]string), $2.(string))
+	}
+
+CheckTableOption:
+	"FOR" "UPGRADE"
+	{
+		$$ = "FOR UPGRADE"
+	}
+|	"QUICK"
+	{
+		$$ = "QUICK"
+	}
+|	"FAST"
+	{
+		$$ = "FAST"
+	}
+|	"MEDIUM"
+	{
+		$$ = "MEDIUM"
+	}
+|	"EXTENDED"
+	{
+		$$ = "EXTENDED"
+	}
+|	"CHANGED"
+	{
+		$$ = "CHANGED"
+	}
+
 FlushOption:
 	"PRIVILEGES"
 	{
@@ -5349,6 +6575,18 @@ FlushOption:
 			ReadLock: $3.(bool),
 		}
 	}
+|	"STATUS"
+	{
+		$$ = &ast.FlushStmt{
+			Tp: ast.FlushStatus,
+		}
+	}
+|	"LOGS"
+	{
+		$$ = &ast.FlushStmt{
+			Tp: ast.FlushLogs,
+		}
+	}
 
 NoWriteToBinLogAliasOpt:
 	{
@@ -5385,48 +6623,106 @@ Statement:
 	EmptyStmt
 |	AdminStmt
 |	AlterTableStmt
+|	AlterInstanceStmt
 |	AlterUserStmt
 |	AnalyzeTableStmt
 |	BeginTransactionStmt
 |	BinlogStmt
+|	BRIEStmt
+|	CalibrateResourceStmt
+|	AddQueryWatchStmt
+|	DropQueryWatchStmt
+|	ChangeStmt
 |	CommitStmt
+|	CreateBindingStmt
 |	DeallocateStmt
 |	DeleteFromStmt
 |	ExecuteStmt
 |	ExplainStmt
+|	PlanReplayerStmt
 |	CreateDatabaseStmt
 |	CreateIndexStmt
 |	CreateTableStmt
+|	CreateRoleStmt
 |	CreateUserStmt
 |	DoStmt
+|	DropBindingStmt
 |	DropDatabaseStmt
 |	DropIndexStmt
 |	DropTableStmt
 |	DropViewStmt
+|	DropRoleStmt
+|	DropStatsStmt
 |	DropUserStmt
+|	FlashBackTableStmt
 |	FlushStmt
+|	OptimizeTableStmt
+|	CheckTableStmt
+|	GrantRoleStmt
 |	GrantStmt
+|	ImportIntoStmt
 |	InsertIntoStmt
+|	KillStmt
 |	LoadDataStmt
+|	LoadStatsStmt
+|	NonTransactionalDMLStmt
 |	PreparedStmt
+|	RecoverTableStmt
+|	ReleaseSavepointStmt
 |	RollbackStmt
 |	RenameTableStmt
+|	RepairTableStmt
 |	ReplaceIntoStmt
+|	RevokeRoleStmt
+|	RevokeStmt
+|	SavepointStmt
 |	SelectStmt
 |	UnionStmt
 |	SetStmt
+|	SetConfigStmt
+|	SetRoleStmt
+|	SetDefaultRoleStmt
 |	ShowStmt
+|	ShutdownStmt
+|	RestartStmt
+|	HelpStmt
+|	SplitRegionStmt
+|	TraceStmt
 |	TruncateTableStmt
 |	UpdateStmt
 |	UseStmt
 |	SubSelect
 	{
-		// `(select 1)`; is a valid select statement
-		// TODO: This is used to fix issue #320. There may be a better solution.
-		$$ = $1.(*ast.SubqueryExpr).Query
+		// `(select 1)`; is a valid select statement
+		// TODO: This is used to fix issue #320. There may be a better solution.
+		$$ = $1.(*ast.SubqueryExpr).Query
+	}
+|	UnlockTablesStmt
+|	LockTablesStmt
+
+/*******************************************************************
+ *
+ *  Plan Replayer Statement
+ *
+ *  Example:
+ *      PLAN REPLAYER DUMP EXPLAIN [ANALYZE] SELECT * FROM t
+ *      PLAN REPLAYER LOAD '/tmp/replayer.zip'
+ *******************************************************************/
+PlanReplayerStmt:
+	"PLAN" "REPLAYER" "DUMP" "EXPLAIN" ExplainableStmt
+	{
+		parser.setInnerStmtText($5.(ast.StmtNode), &yyS[yypt])
+		$$ = &ast.PlanReplayerStmt{Stmt: $5.(ast.StmtNode)}
+	}
+|	"PLAN" "REPLAYER" "DUMP" "EXPLAIN" "ANALYZE" ExplainableStmt
+	{
+		parser.setInnerStmtText($6.(ast.StmtNode), &yyS[yypt])
+		$$ = &ast.PlanReplayerStmt{Stmt: $6.(ast.StmtNode), Analyze: true}
+	}
+|	"PLAN" "REPLAYER" "LOAD" stringLit
+	{
+		$$ = &ast.PlanReplayerStmt{Load: true, File: $4}
 	}
-|	UnlockTablesStmt
-|	LockTablesStmt
 
 ExplainableStmt:
 	SelectStmt
@@ -5441,20 +6737,26 @@ StatementList:
 	{
 		if $1 != nil {
 			s := $1.(ast.StmtNode)
+			s.SetOriginTextPosition(parser.startOffset(&yyS[yypt]))
 			if lexer, ok := yylex.(stmtTexter); ok {
 				s.SetText(lexer.stmtText())
 			}
 			parser.result = append(parser.result, s)
+		} else {
+			parser.result = append(parser.result, &ast.EmptyStmt{})
 		}
 	}
 |	StatementList ';' Statement
 	{
 		if $3 != nil {
 			s := $3.(ast.StmtNode)
+			s.SetOriginTextPosition(parser.startOffset(&yyS[yypt]))
 			if lexer, ok := yylex.(stmtTexter); ok {
 				s.SetText(lexer.stmtText())
 			}
 			parser.result = append(parser.result, s)
+		} else {
+			parser.result = append(parser.result, &ast.EmptyStmt{})
 		}
 	}
 
@@ -5602,6 +6904,75 @@ OptTable:
 	{}
 |	"TABLE"
 
+FlashBackTableStmt:
+	"FLASHBACK" "TABLE" TableName FlashBackToNewName
+	{
+		$$ = &ast.FlashBackTableStmt{
+			Table:		$3.(*ast.TableName),
+			NewName:	$4.(string),
+		}
+	}
+
+FlashBackToNewName:
+	{
+		$$ = ""
+	}
+|	"TO" Identifier
+	{
+		$$ = $2
+	}
+
+RecoverTableStmt:
+	"RECOVER" "TABLE" TableName
+	{
+		$$ = &ast.RecoverTableStmt{Table: $3.(*ast.TableName)}
+	}
+|	"RECOVER" "TABLE" "BY" "JOB" NUM
+	{
+		jobID, ok := $5.(int64)
+		if !ok || jobID <= 0 {
+			yylex.Errorf("Invalid job id: %v", $5)
+			return 1
+		}
+		$$ = &ast.RecoverTableStmt{JobID: jobID}
+	}
+
+SplitRegionStmt:
+	"SPLIT" "TABLE" TableName SplitOption
+	{
+		$$ = &ast.SplitRegionStmt{
+			Table:		$3.(*ast.TableName),
+			SplitOpt:	$4.(*ast.SplitOption),
+		}
+	}
+|	"SPLIT" "TABLE" TableName "INDEX" Identifier SplitOption
+	{
+		$$ = &ast.SplitRegionStmt{
+			Table:		$3.(*ast.TableName),
+			IndexName:	$5,
+			SplitOpt:	$6.(*ast.SplitOption),
+		}
+	}
+
+SplitOption:
+	"BETWEEN" '(' ExpressionList ')' "AND" '(' ExpressionList ')' "REGIONS" NUM
+	{
+		num, ok := $10.(int64)
+		if !ok || num <= 0 {
+			yylex.Errorf("Invalid number of regions: %v", $10)
+			return 1
+		}
+		$$ = &ast.SplitOption{
+			Lower:	$3.([]ast.ExprNode),
+			Upper:	$7.([]ast.ExprNode),
+			Num:	num,
+		}
+	}
+|	"BY" ExpressionListList
+	{
+		$$ = &ast.SplitOption{ValueLists: $2.([][]ast.ExprNode)}
+	}
+
 TruncateTableStmt:
 	"TRUNCATE" OptTable TableName
 	{
@@ -6068,39 +7439,45 @@ StringName:
  * See https://dev.mysql.com/doc/refman/5.7/en/update.html
  ***********************************************************************************/
 UpdateStmt:
-	"UPDATE" LowPriorityOptional IgnoreOptional TableRef "SET" AssignmentList WhereClauseOptional OrderByOptional LimitClause
+	"UPDATE" TableOptimizerHintsOpt LowPriorityOptional IgnoreOptional TableRef "SET" AssignmentList WhereClauseOptional OrderByOptional LimitClause
 	{
 		var refs *ast.Join
-		if x, ok := $4.(*ast.Join); ok {
+		if x, ok := $5.(*ast.Join); ok {
 			refs = x
 		} else {
-			refs = &ast.Join{Left: $4.(ast.ResultSetNode)}
+			refs = &ast.Join{Left: $5.(ast.ResultSetNode)}
 		}
 		st := &ast.UpdateStmt{
-			LowPriority:	$2.(bool),
+			LowPriority:	$3.(bool),
 			TableRefs:	&ast.TableRefsClause{TableRefs: refs},
-			List:		$6.([]*ast.Assignment),
+			List:		$7.([]*ast.Assignment),
 		}
-		if $7 != nil {
-			st.Where = $7.(ast.ExprNode)
+		if $2 != nil {
+			st.TableHints = $2.([]*ast.TableOptimizerHint)
 		}
 		if $8 != nil {
-			st.Order = $8.(*ast.OrderByClause)
+			st.Where = $8.(ast.ExprNode)
 		}
 		if $9 != nil {
-			st.Limit = $9.(*ast.Limit)
+			st.Order = $9.(*ast.OrderByClause)
+		}
+		if $10 != nil {
+			st.Limit = $10.(*ast.Limit)
 		}
 		$$ = st
 	}
-|	"UPDATE" LowPriorityOptional IgnoreOptional TableRefs "SET" AssignmentList WhereClauseOptional
+|	"UPDATE" TableOptimizerHintsOpt LowPriorityOptional IgnoreOptional TableRefs "SET" AssignmentList WhereClauseOptional
 	{
 		st := &ast.UpdateStmt{
-			LowPriority:	$2.(bool),
-			TableRefs:	&ast.TableRefsClause{TableRefs: $4.(*ast.Join)},
-			List:		$6.([]*ast.Assignment),
+			LowPriority:	$3.(bool),
+			TableRefs:	&ast.TableRefsClause{TableRefs: $5.(*ast.Join)},
+			List:		$7.([]*ast.Assignment),
 		}
-		if $7 != nil {
-			st.Where = $7.(ast.ExprNode)
+		if $2 != nil {
+			st.TableHints = $2.([]*ast.TableOptimizerHint)
+		}
+		if $8 != nil {
+			st.Where = $8.(ast.ExprNode)
 		}
 		$$ = st
 	}
@@ -6145,6 +7522,16 @@ CreateUserStmt:
 		}
 	}
 
+CreateRoleStmt:
+	"CREATE" "ROLE" IfNotExists RolenameList
+	{
+		// See https://dev.mysql.com/doc/refman/8.0/en/create-role.html
+		$$ = &ast.CreateRoleStmt{
+			IfNotExists:	$3.(bool),
+			RoleList:	$4.([]*ast.RoleIdentity),
+		}
+	}
+
 /* See http://dev.mysql.com/doc/refman/5.7/en/alter-user.html */
 AlterUserStmt:
 	"ALTER" "USER" IfExists UserSpecList
@@ -6170,7 +7557,7 @@ UserSpec:
 	Username AuthOption
 	{
 		userSpec := &ast.UserSpec{
-			User: $1.(string),
+			User: $1.(*ast.UserIdentity),
 		}
 		if $2 != nil {
 			userSpec.AuthOpt = $2.(*ast.AuthOption)
@@ -6205,6 +7592,27 @@ AuthOption:
 			HashString: $4.(string),
 		}
 	}
+|	"IDENTIFIED" "WITH" StringName
+	{
+		$$ = &ast.AuthOption{
+			AuthPlugin: $3.(string),
+		}
+	}
+|	"IDENTIFIED" "WITH" StringName "BY" AuthString
+	{
+		$$ = &ast.AuthOption{
+			AuthPlugin: $3.(string),
+			AuthString: $5.(string),
+			ByAuthString: true,
+		}
+	}
+|	"IDENTIFIED" "WITH" StringName "AS" HashString
+	{
+		$$ = &ast.AuthOption{
+			AuthPlugin: $3.(string),
+			HashString: $5.(string),
+		}
+	}
 
 HashString:
 	stringLit
@@ -6228,6 +7636,56 @@ GrantStmt:
 		}
 	 }
 
+GrantRoleStmt:
+	"GRANT" RolenameList "TO" UsernameList
+	{
+		$$ = &ast.GrantRoleStmt{
+			Roles:	$2.([]*ast.RoleIdentity),
+			Users:	$4.([]*ast.UserIdentity),
+		}
+	}
+
+/**************************************RevokeStmt*******************************************
+ * See https://dev.mysql.com/doc/refman/5.7/en/revoke.html
+ *******************************************************************************************/
+RevokeStmt:
+	"REVOKE" PrivElemList "ON" ObjectType PrivLevel "FROM" UserSpecList
+	{
+		$$ = &ast.RevokeStmt{
+			Privs: $2.([]*ast.PrivElem),
+			ObjectType: $4.(ast.ObjectTypeType),
+			Level: $5.(*ast.GrantLevel),
+			Users: $7.([]*ast.UserSpec),
+		}
+	}
+|	"REVOKE" PrivElemList "FROM" UserSpecList
+	{
+		// Without ON, only REVOKE ALL [PRIVILEGES], GRANT OPTION FROM user is allowed,
+		// which revokes all the privileges at all levels.
+		privs := $2.([]*ast.PrivElem)
+		if len(privs) != 2 || privs[0].Priv != mysql.AllPriv || privs[1].Priv != mysql.GrantPriv ||
+			len(privs[0].Cols) > 0 || len(privs[1].Cols) > 0 {
+			yylex.Errorf("REVOKE without ON only accepts ALL PRIVILEGES, GRANT OPTION")
+			return 1
+		}
+		$$ = &ast.RevokeStmt{
+			Privs:		privs,
+			ObjectType:	ast.ObjectTypeNone,
+			Level:		&ast.GrantLevel{Level: ast.GrantLevelGlobal},
+			Users:		$4.([]*ast.UserSpec),
+			AllLevels:	true,
+		}
+	}
+
+RevokeRoleStmt:
+	"REVOKE" RolenameList "FROM" UsernameList
+	{
+		$$ = &ast.RevokeRoleStmt{
+			Roles:	$2.([]*ast.RoleIdentity),
+			Users:	$4.([]*ast.UserIdentity),
+		}
+	}
+
 WithGrantOptionOpt:
 	{
 		$$ = false
@@ -6369,11 +7827,12 @@ PrivLevel:
  * See https://dev.mysql.com/doc/refman/5.7/en/load-data.html
  *******************************************************************************************/
 LoadDataStmt:
-	"LOAD" "DATA" LocalOpt "INFILE" stringLit "INTO" "TABLE" TableName Fields Lines
+	"LOAD" "DATA" LocalOpt "INFILE" stringLit "INTO" "TABLE" TableName Fields Lines ColumnNameListOptWithBrackets
 	{
 		x := &ast.LoadDataStmt{
 			Path:       $5,
 			Table:      $8.(*ast.TableName),
+			Columns:    $11.([]*ast.ColumnName),
 		}
 		if $3 != nil {
 			x.IsLocal = true
@@ -6387,6 +7846,266 @@ LoadDataStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Load Stats Statement
+ *
+ *  Example:
+ *      LOAD STATS '/tmp/stats.json'
+ *******************************************************************/
+LoadStatsStmt:
+	"LOAD" "STATS" stringLit
+	{
+		$$ = &ast.LoadStatsStmt{Path: $3}
+	}
+
+/*******************************************************************
+ *
+ *  Drop Stats Statement
+ *
+ *  Example:
+ *      DROP STATS t1, t2
+ *      DROP STATS t PARTITION p0, p1
+ *      DROP STATS t GLOBAL
+ *******************************************************************/
+DropStatsStmt:
+	"DROP" "STATS" TableNameList
+	{
+		$$ = &ast.DropStatsStmt{Tables: $3.([]*ast.TableName)}
+	}
+|	"DROP" "STATS" TableName "PARTITION" PartitionNameList
+	{
+		$$ = &ast.DropStatsStmt{
+			Tables:		[]*ast.TableName{$3.(*ast.TableName)},
+			PartitionNames:	$5.([]string),
+		}
+	}
+|	"DROP" "STATS" TableName "GLOBAL"
+	{
+		$$ = &ast.DropStatsStmt{
+			Tables:		[]*ast.TableName{$3.(*ast.TableName)},
+			IsGlobalStats:	true,
+		}
+	}
+
+PartitionNameList:
+	Identifier
+	{
+		$$ = []string{$1}
+	}
+|	PartitionNameList ',' Identifier
+	{
+		$$ = append($1.([]string), $3)
+	}
+
+/*******************************************************************
+ *
+ *  Import Into Statement
+ *
+ *  Example:
+ *      IMPORT INTO t (a, @b) FROM '/path/to/file.csv' FORMAT 'csv' WITH thread = 8, detached
+ *******************************************************************/
+ImportIntoStmt:
+	"IMPORT" "INTO" TableName ColumnNameOrUserVarListOptWithBrackets "FROM" stringLit ImportFormatOpt ImportOptionListOpt
+	{
+		$$ = &ast.ImportIntoStmt{
+			Table:			$3.(*ast.TableName),
+			ColumnsAndUserVars:	$4.([]*ast.ColumnNameOrUserVar),
+			Path:			$6,
+			Format:			$7.(string),
+			Options:		$8.([]*ast.LoadDataOpt),
+		}
+	}
+
+ColumnNameOrUserVarListOptWithBrackets:
+	{
+		$$ = []*ast.ColumnNameOrUserVar{}
+	}
+|	'(' ColumnNameOrUserVarList ')'
+	{
+		$$ = $2.([]*ast.ColumnNameOrUserVar)
+	}
+
+ColumnNameOrUserVarList:
+	ColumnNameOrUserVar
+	{
+		$$ = []*ast.ColumnNameOrUserVar{$1.(*ast.ColumnNameOrUserVar)}
+	}
+|	ColumnNameOrUserVarList ',' ColumnNameOrUserVar
+	{
+		$$ = append($1.([]*ast.ColumnNameOrUserVar), $3.(*ast.ColumnNameOrUserVar))
+	}
+
+ColumnNameOrUserVar:
+	ColumnName
+	{
+		$$ = &ast.ColumnNameOrUserVar{ColumnName: $1.(*ast.ColumnName)}
+	}
+|	UserVariable
+	{
+		$$ = &ast.ColumnNameOrUserVar{UserVar: $1.(*ast.VariableExpr)}
+	}
+
+ImportFormatOpt:
+	{
+		$$ = ""
+	}
+|	"FORMAT" stringLit
+	{
+		$$ = $2
+	}
+
+ImportOptionListOpt:
+	{
+		$$ = []*ast.LoadDataOpt{}
+	}
+|	"WITH" ImportOptionList
+	{
+		$$ = $2.([]*ast.LoadDataOpt)
+	}
+
+ImportOptionList:
+	ImportOption
+	{
+		$$ = []*ast.LoadDataOpt{$1.(*ast.LoadDataOpt)}
+	}
+|	ImportOptionList ',' ImportOption
+	{
+		$$ = append($1.([]*ast.LoadDataOpt), $3.(*ast.LoadDataOpt))
+	}
+
+ImportOption:
+	Identifier
+	{
+		$$ = &ast.LoadDataOpt{Name: strings.ToLower($1)}
+	}
+|	Identifier eq SignedLiteral
+	{
+		$$ = &ast.LoadDataOpt{Name: strings.ToLower($1), Value: $3.(ast.ExprNode)}
+	}
+
+/*******************************************************************
+ *
+ *  Non-transactional DML Statement
+ *
+ *  Example:
+ *      BATCH ON id LIMIT 1000 DELETE FROM t WHERE v < 10
+ *      BATCH LIMIT 1000 DRY RUN QUERY UPDATE t SET v = v + 1
+ *******************************************************************/
+NonTransactionalDMLStmt:
+	"BATCH" OptShardColumn "LIMIT" LengthNum DryRunOptions ShardableStmt
+	{
+		$$ = &ast.NonTransactionalDMLStmt{
+			DMLStmt:	$6.(ast.DMLNode),
+			ShardColumn:	$2.(*ast.ColumnName),
+			Limit:		$4.(uint64),
+			DryRun:		$5.(int),
+		}
+	}
+
+OptShardColumn:
+	{
+		$$ = (*ast.ColumnName)(nil)
+	}
+|	"ON" ColumnName
+	{
+		$$ = $2.(*ast.ColumnName)
+	}
+
+DryRunOptions:
+	{
+		$$ = ast.NoDryRun
+	}
+|	"DRY" "RUN"
+	{
+		$$ = ast.DryRunSplitDml
+	}
+|	"DRY" "RUN" "QUERY"
+	{
+		$$ = ast.DryRunQuery
+	}
+
+ShardableStmt:
+	DeleteFromStmt
+|	UpdateStmt
+|	InsertIntoStmt
+|	ReplaceIntoStmt
+
+/*******************************************************************
+ *
+ *  Backup and Restore Statement
+ *
+ *  Example:
+ *      BACKUP DATABASE * TO 's3://bucket/path' WITH rate_limit = 120
+ *      RESTORE TABLE t1, db.t2 FROM 'local:///tmp/backup'
+ *******************************************************************/
+BRIEStmt:
+	"BACKUP" BRIETables "TO" stringLit BRIEOptionListOpt
+	{
+		stmt := $2.(*ast.BRIEStmt)
+		stmt.Kind = ast.BRIEKindBackup
+		stmt.Storage = $4
+		stmt.Options = $5.([]*ast.BRIEOption)
+		$$ = stmt
+	}
+|	"RESTORE" BRIETables "FROM" stringLit BRIEOptionListOpt
+	{
+		stmt := $2.(*ast.BRIEStmt)
+		stmt.Kind = ast.BRIEKindRestore
+		stmt.Storage = $4
+		stmt.Options = $5.([]*ast.BRIEOption)
+		$$ = stmt
+	}
+
+BRIETables:
+	DatabaseSym '*'
+	{
+		$$ = &ast.BRIEStmt{}
+	}
+|	DatabaseSym DBNameList
+	{
+		$$ = &ast.BRIEStmt{Schemas: $2.([]string)}
+	}
+|	"TABLE" TableNameList
+	{
+		$$ = &ast.BRIEStmt{Tables: $2.([]*ast.TableName)}
+	}
+
+DBNameList:
+	DBName
+	{
+		$$ = []string{$1.(string)}
+	}
+|	DBNameList ',' DBName
+	{
+		$$ = append($1.([]string), $3.(string))
+	}
+
+BRIEOptionListOpt:
+	{
+		$$ = []*ast.BRIEOption{}
+	}
+|	"WITH" BRIEOptionList
+	{
+		$$ = $2.([]*ast.BRIEOption)
+	}
+
+BRIEOptionList:
+	BRIEOption
+	{
+		$$ = []*ast.BRIEOption{$1.(*ast.BRIEOption)}
+	}
+|	BRIEOptionList ',' BRIEOption
+	{
+		$$ = append($1.([]*ast.BRIEOption), $3.(*ast.BRIEOption))
+	}
+
+BRIEOption:
+	Identifier eq SignedLiteral
+	{
+		$$ = &ast.BRIEOption{Name: strings.ToLower($1), Value: $3.(ast.ExprNode)}
+	}
+
 LocalOpt:
 	{
 		$$ = nil 
@@ -6487,27 +8206,56 @@ LinesTerminated:
 /*********************************************************************
  * Lock/Unlock Tables
  * See http://dev.mysql.com/doc/refman/5.7/en/lock-tables.html
- * All the statement leaves empty. This is used to prevent mysqldump error.
+ * The statements are parsed but ignored. This is used to prevent mysqldump error.
  *********************************************************************/
 
 UnlockTablesStmt:
 	"UNLOCK" "TABLES"
-	{}
+	{
+		$$ = &ast.UnlockTablesStmt{}
+	}
 
 LockTablesStmt:
 	"LOCK" "TABLES" TableLockList
-	{}
+	{
+		$$ = &ast.LockTablesStmt{TableLocks: $3.([]ast.TableLock)}
+	}
 
 TableLock:
 	TableName LockType
+	{
+		$$ = ast.TableLock{
+			Table:	$1.(*ast.TableName),
+			Type:	$2.(ast.TableLockType),
+		}
+	}
 
 LockType:
 	"READ"
+	{
+		$$ = ast.TableLockRead
+	}
 |	"READ" "LOCAL"
+	{
+		$$ = ast.TableLockReadLocal
+	}
 |	"WRITE"
+	{
+		$$ = ast.TableLockWrite
+	}
+|	"LOW_PRIORITY" "WRITE"
+	{
+		$$ = ast.TableLockLowPriorityWrite
+	}
 
 TableLockList:
 	TableLock
+	{
+		$$ = []ast.TableLock{$1.(ast.TableLock)}
+	}
 |	TableLockList ',' TableLock
+	{
+		$$ = append($1.([]ast.TableLock), $3.(ast.TableLock))
+	}
 
 %%
@@ -21,6 +21,8 @@ import (
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/model"
+	"github.com/pingcap/tidb/mysql"
 	"github.com/pingcap/tidb/util/testleak"
 )
 
@@ -45,14 +47,14 @@ func (s *testParserSuite) TestSimple(c *C) {
 		"current_timestamp", "current_user", "database", "databases", "day_hour", "day_microsecond",
 		"day_minute", "day_second", "decimal", "default", "delete", "desc", "describe",
 		"distinct", "div", "double", "drop", "dual", "else", "enclosed", "escaped",
-		"exists", "explain", "false", "float", "for", "force", "foreign", "from",
+		"except", "exists", "explain", "false", "float", "for", "force", "foreign", "from",
 		"fulltext", "grant", "group", "having", "hour_microsecond", "hour_minute",
 		"hour_second", "if", "ignore", "in", "index", "infile", "inner", "insert", "int", "into", "integer",
-		"interval", "is", "join", "key", "keys", "leading", "left", "like", "limit", "lines", "load",
+		"interval", "is", "join", "key", "keys", "kill", "leading", "left", "like", "limit", "lines", "load",
 		"localtime", "localtimestamp", "lock", "longblob", "longtext", "mediumblob", "maxvalue", "mediumint", "mediumtext",
 		"minute_microsecond", "minute_second", "mod", "not", "no_write_to_binlog", "null", "numeric",
-		"on", "option", "or", "order", "outer", "partition", "precision", "primary", "procedure", "range", "read", "real",
-		"references", "regexp", "rename", "repeat", "replace", "restrict", "right", "rlike",
+		"on", "optimize", "option", "or", "order", "outer", "partition", "precision", "primary", "procedure", "range", "read", "real",
+		"references", "regexp", "release", "rename", "repeat", "replace", "restrict", "revoke", "right", "rlike",
 		"schema", "schemas", "second_microsecond", "select", "set", "show", "smallint",
 		"starting", "table", "terminated", "then", "tinyblob", "tinyint", "tinytext", "to",
 		"trailing", "true", "union", "unique", "unlock", "unsigned",
@@ -89,7 +91,16 @@ func (s *testParserSuite) TestSimple(c *C) {
 		"compact", "redundant", "sql_no_cache sql_no_cache", "sql_cache sql_cache", "action", "round",
 		"enable", "disable", "reverse", "space", "privileges", "get_lock", "release_lock", "sleep", "no", "greatest", "least",
 		"binlog", "hex", "unhex", "function", "indexes", "from_unixtime", "processlist", "events", "less", "than", "timediff",
-		"ln", "log", "log2", "log10", "timestampdiff",
+		"ln", "log", "log2", "log10", "timestampdiff", "savepoint", "query", "tidb", "errors", "logs", "trace",
+		"cancel", "jobs", "node_id", "node_state",
+		"recover", "job", "flashback", "shutdown", "regions", "split", "role", "none", "buckets", "config", "plugins", "open", "chain", "binding", "bindings", "repair", "import",
+		"calibrate", "resource", "workload", "start_time", "end_time", "duration", "upgrade", "fast", "medium",
+		"extended", "changed", "restart", "help", "stats", "plan", "replayer", "dump",
+		"profile", "profiles", "cpu", "ipc", "memory", "swaps", "source", "block", "io", "context", "switches", "page", "faults",
+		"backup", "restore", "instance", "reload", "tls", "error",
+		"stats_meta", "stats_histograms", "stats_buckets", "stats_healthy",
+		"batch", "dry", "run", "builtins",
+		"watch", "remove", "sql", "digest", "exact", "similar", "dryrun", "cooldown",
 	}
 	for _, kw := range unreservedKws {
 		src := fmt.Sprintf("SELECT %s FROM tbl;", kw)
@@ -184,6 +195,47 @@ func (s *testParserSuite) RunTest(c *C, table []testCase) {
 	}
 }
 
+func (s *testParserSuite) TestEmptyStmt(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt(";", "", "")
+	c.Assert(err, IsNil)
+	_, ok := stmt.(*ast.EmptyStmt)
+	c.Assert(ok, IsTrue)
+
+	stmts, err := parser.Parse("SELECT 1;;SELECT 2;", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmts, HasLen, 3)
+	_, ok = stmts[1].(*ast.EmptyStmt)
+	c.Assert(ok, IsTrue)
+	_, ok = stmts[2].(*ast.SelectStmt)
+	c.Assert(ok, IsTrue)
+
+	// The trailing empty statements are dropped, but a script of semicolons has one EmptyStmt.
+	stmt, err = parser.ParseOneStmt(";;", "", "")
+	c.Assert(err, IsNil)
+	_, ok = stmt.(*ast.EmptyStmt)
+	c.Assert(ok, IsTrue)
+	stmts, err = parser.Parse("SELECT 1;;", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmts, HasLen, 1)
+	_, ok = stmts[0].(*ast.SelectStmt)
+	c.Assert(ok, IsTrue)
+	stmts, err = parser.Parse(";SELECT 1; ;", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmts, HasLen, 2)
+
+	// The empty statement after the last semicolon is dropped.
+	for _, src := range []string{"", " ", "SELECT 1;"} {
+		stmts, err = parser.Parse(src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", src))
+		for _, stmt := range stmts {
+			_, ok = stmt.(*ast.EmptyStmt)
+			c.Assert(ok, IsFalse, Commentf("source %s", src))
+		}
+	}
+}
+
 func (s *testParserSuite) TestDMLStmt(c *C) {
 	defer testleak.AfterTest(c)()
 	table := []testCase{
@@ -238,9 +290,21 @@ func (s *testParserSuite) TestDMLStmt(c *C) {
 			FROM stuff)`, true},
 		{"BEGIN", true},
 		{"START TRANSACTION", true},
+		{"START TRANSACTION WITH CONSISTENT SNAPSHOT", true},
+		{"START TRANSACTION READ ONLY", true},
+		{"START TRANSACTION READ WRITE, WITH CONSISTENT SNAPSHOT", true},
+		{"START TRANSACTION READ ONLY, READ WRITE", false},
+		{"START TRANSACTION READ WRITE, READ ONLY", false},
+		{"BEGIN READ ONLY", false},
 		// 45
 		{"COMMIT", true},
 		{"ROLLBACK", true},
+		{"ROLLBACK TO sp", true},
+		{"ROLLBACK TO SAVEPOINT `sp`", true},
+		{"SAVEPOINT sp", true},
+		{"SAVEPOINT", false},
+		{"RELEASE SAVEPOINT sp", true},
+		{"RELEASE sp", false},
 		{`BEGIN;
 			INSERT INTO foo VALUES (42, 3.14);
 			INSERT INTO foo VALUES (-1, 2.78);
@@ -260,6 +324,9 @@ func (s *testParserSuite) TestDMLStmt(c *C) {
 		// do statement
 		{"DO 1", true},
 		{"DO 1 from t", false},
+		{"DO SLEEP(1)", true},
+		{"DO GET_LOCK('lock', 10), RELEASE_LOCK('lock')", true},
+		{"DO", false},
 
 		// load data
 		{"load data infile '/tmp/t.csv' into table t", true},
@@ -280,6 +347,10 @@ func (s *testParserSuite) TestDMLStmt(c *C) {
 		{"load data local infile '/tmp/t.csv' into table t lines starting by 'ab' terminated by 'xy'", true},
 		{"load data local infile '/tmp/t.csv' into table t fields terminated by 'ab' lines terminated by 'xy'", true},
 		{"load data local infile '/tmp/t.csv' into table t terminated by 'xy' fields terminated by 'ab'", false},
+		{"load data infile '/tmp/t.csv' into table t (a,b)", true},
+		{"load data local infile '/tmp/t.csv' into table t fields terminated by ',' lines terminated by '\\n' (a,b)", true},
+		{"load data infile '/tmp/t.csv' into table t ()", true},
+		{"load data infile '/tmp/t.csv' into table t (a,b) fields terminated by ','", false},
 
 		// select for update
 		{"SELECT * from t for update", true},
@@ -294,6 +365,12 @@ func (s *testParserSuite) TestDMLStmt(c *C) {
 		// for admin
 		{"admin show ddl;", true},
 		{"admin check table t1, t2;", true},
+		{"admin check index tb idx;", true},
+		{"admin check index test.tb idx;", true},
+		{"admin show ddl jobs;", true},
+		{"admin cancel ddl jobs 1", true},
+		{"admin cancel ddl jobs 1, 2, 3", true},
+		{"admin cancel ddl jobs", false},
 
 		// for on duplicate key update
 		{"INSERT INTO t (a,b,c) VALUES (1,2,3),(4,5,6) ON DUPLICATE KEY UPDATE c=VALUES(a)+VALUES(b);", true},
@@ -371,9 +448,13 @@ func (s *testParserSuite) TestDBAStmt(c *C) {
 		{`SHOW KEYS IN t;`, true},
 		{`SHOW INDEXES IN t where true;`, true},
 		{`SHOW KEYS FROM t FROM test where true;`, true},
+		{`SHOW EXTENDED INDEX FROM t;`, true},
+		{`SHOW EXTENDED KEYS FROM t FROM test;`, true},
 		{`SHOW EVENTS FROM test_db WHERE definer = 'current_user'`, true},
 		// for show character set
 		{"show character set;", true},
+		{"show charset like 'utf8%'", true},
+		{"show character set where Maxlen > 1", true},
 		// for show collation
 		{"show collation", true},
 		{"show collation like 'utf8%'", true},
@@ -381,9 +462,31 @@ func (s *testParserSuite) TestDBAStmt(c *C) {
 		// for show full columns
 		{"show columns in t;", true},
 		{"show full columns in t;", true},
+		{"show extended columns from t", true},
+		{"show extended full fields from t from test like 'a%'", true},
+		{"show full extended columns from t", false},
+		{"show extended tables", false},
 		// for show create table
 		{"show create table test.t", true},
 		{"show create table t", true},
+		{"show create database test", true},
+		{"show create schema `test`", true},
+		{"show full create table t", false},
+		// for show warnings and errors
+		{"show warnings", true},
+		{"show warnings limit 10", true},
+		{"show warnings limit 1, 10", true},
+		{"show errors", true},
+		{"show errors limit 10 offset 2", true},
+		{"show count(*) warnings", true},
+		{"show count(*) errors", true},
+		{"show count(*) warnings limit 1", false},
+		{"show count(a) warnings", false},
+		// for show limit
+		{"show tables like 't%' limit 50, 50", true},
+		{"show full tables from test where Table_type = 'BASE TABLE' limit 10", true},
+		{"show databases limit 1", true},
+		{"show create table t limit 1", false},
 
 		// set
 		// user defined
@@ -411,6 +514,9 @@ func (s *testParserSuite) TestDBAStmt(c *C) {
 		// set password
 		{"SET PASSWORD = 'password';", true},
 		{"SET PASSWORD FOR 'root'@'localhost' = 'password';", true},
+		{"SET PASSWORD = PASSWORD('password');", true},
+		{"SET PASSWORD FOR 'root'@'localhost' = PASSWORD('password');", true},
+		{"SET PASSWORD FOR 'root' = 'password';", true},
 		// SET TRANSACTION Syntax
 		{"SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ", true},
 		{"SET GLOBAL TRANSACTION ISOLATION LEVEL REPEATABLE READ", true},
@@ -419,10 +525,18 @@ func (s *testParserSuite) TestDBAStmt(c *C) {
 		{"SET SESSION TRANSACTION ISOLATION LEVEL READ COMMITTED", true},
 		{"SET SESSION TRANSACTION ISOLATION LEVEL READ UNCOMMITTED", true},
 		{"SET SESSION TRANSACTION ISOLATION LEVEL SERIALIZABLE", true},
+		{"SET TRANSACTION ISOLATION LEVEL SERIALIZABLE", true},
+		{"SET TRANSACTION READ ONLY, ISOLATION LEVEL READ COMMITTED", true},
+		{"SET TRANSACTION ISOLATION LEVEL READ COMMITTED, ISOLATION LEVEL SERIALIZABLE", false},
+		{"SET TRANSACTION READ ONLY, READ WRITE", false},
+		{"SET transaction = 1", true},
 		// for set names
 		{"set names utf8", true},
 		{"set names utf8 collate utf8_unicode_ci", true},
 		{"set names binary", true},
+		{"set names default", true},
+		{"set names default, @a = 1", true},
+		{"set character set default", false},
 		// for set names and set vars
 		{"set names utf8, @@session.sql_mode=1;", true},
 		{"set @@session.sql_mode=1, names utf8, charset utf8;", true},
@@ -438,6 +552,9 @@ func (s *testParserSuite) TestDBAStmt(c *C) {
 		{"flush tables tbl1, tbl2, tbl3", true},
 		{"flush tables tbl1, tbl2, tbl3 with read lock", true},
 		{"flush privileges", true},
+		{"flush status", true},
+		{"flush no_write_to_binlog logs", true},
+		{"flush status tables", false},
 	}
 	s.RunTest(c, table)
 }
@@ -462,6 +579,18 @@ func (s *testParserSuite) TestFlushPrivileges(c *C) {
 	c.Assert(flushPrivilege.Tp, Equals, ast.FlushPrivileges)
 }
 
+func (s *testParserSuite) TestFlushStatusAndLogs(c *C) {
+	parser := New()
+	stmt, err := parser.Parse("flush status", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt[0].(*ast.FlushStmt).Tp, Equals, ast.FlushStatus)
+	stmt, err = parser.Parse("flush local logs", "", "")
+	c.Assert(err, IsNil)
+	flushLogs := stmt[0].(*ast.FlushStmt)
+	c.Assert(flushLogs.Tp, Equals, ast.FlushLogs)
+	c.Assert(flushLogs.NoWriteToBinLog, IsTrue)
+}
+
 func (s *testParserSuite) TestExpression(c *C) {
 	defer testleak.AfterTest(c)()
 	table := []testCase{
@@ -1183,6 +1312,8 @@ func (s *testParserSuite) TestDDL(c *C) {
 		// for rename table statement
 		{"RENAME TABLE t TO t1", true},
 		{"RENAME TABLE d.t TO d1.t1", true},
+		{"RENAME TABLE t TO t1, d.t2 TO t3", true},
+		{"RENAME TABLE t TO t1,", false},
 
 		// for truncate statement
 		{"TRUNCATE TABLE t1", true},
@@ -1238,6 +1369,11 @@ func (s *testParserSuite) TestPrivilege(c *C) {
 		{`CREATE USER 'root'@'localhost' IDENTIFIED BY 'new-password'`, true},
 		{`CREATE USER 'root'@'localhost' IDENTIFIED BY PASSWORD 'hashstring'`, true},
 		{`CREATE USER 'root'@'localhost' IDENTIFIED BY 'new-password', 'root'@'127.0.0.1' IDENTIFIED BY PASSWORD 'hashstring'`, true},
+		{`CREATE USER 'root'@'localhost' IDENTIFIED WITH mysql_native_password`, true},
+		{`CREATE USER 'root'@'localhost' IDENTIFIED WITH mysql_native_password BY 'new-password'`, true},
+		{`CREATE USER 'root'@'localhost' IDENTIFIED WITH 'mysql_native_password' AS 'hashstring'`, true},
+		{`CREATE USER 'root'@'localhost' IDENTIFIED WITH mysql_native_password BY PASSWORD 'hashstring'`, false},
+		{`ALTER USER 'root'@'localhost' IDENTIFIED WITH mysql_native_password BY 'new-password'`, true},
 		{`ALTER USER IF EXISTS 'root'@'localhost' IDENTIFIED BY 'new-password'`, true},
 		{`ALTER USER 'root'@'localhost' IDENTIFIED BY 'new-password'`, true},
 		{`ALTER USER 'root'@'localhost' IDENTIFIED BY PASSWORD 'hashstring'`, true},
@@ -1259,8 +1395,111 @@ func (s *testParserSuite) TestPrivilege(c *C) {
 		{"GRANT SELECT, INSERT ON mydb.mytbl TO 'someuser'@'somehost';", true},
 		{"GRANT SELECT (col1), INSERT (col1,col2) ON mydb.mytbl TO 'someuser'@'somehost';", true},
 		{"grant all privileges on zabbix.* to 'zabbix'@'localhost' identified by 'password';", true},
+
+		// for revoke statement
+		{"REVOKE ALL ON db1.* FROM 'jeffrey'@'localhost';", true},
+		{"REVOKE SELECT ON db2.invoice FROM 'jeffrey'@'localhost';", true},
+		{"REVOKE ALL ON *.* FROM 'someuser'@'somehost';", true},
+		{"REVOKE SELECT, INSERT ON *.* FROM 'someuser'@'somehost';", true},
+		{"REVOKE ALL ON mydb.mytbl FROM 'someuser'@'somehost';", true},
+		{"REVOKE SELECT (col1), INSERT (col1,col2) ON mydb.mytbl FROM 'someuser'@'somehost';", true},
+		{"REVOKE ALL PRIVILEGES, GRANT OPTION ON *.* FROM 'someuser'@'somehost', 'other'@'%';", true},
+		{"REVOKE ALL ON *.* TO 'someuser'@'somehost';", false},
+		{"REVOKE ALL PRIVILEGES, GRANT OPTION FROM 'someuser'@'somehost', 'other'@'%';", true},
+		{"REVOKE ALL, GRANT OPTION FROM 'someuser'@'somehost';", true},
+		{"REVOKE SELECT FROM 'someuser'@'somehost';", false},
+		{"REVOKE ALL PRIVILEGES FROM 'someuser'@'somehost';", false},
 	}
 	s.RunTest(c, table)
+
+	parser := New()
+	stmt, err := parser.ParseOneStmt("CREATE USER 'root'@'%' IDENTIFIED WITH mysql_native_password BY 'pw'", "", "")
+	c.Assert(err, IsNil)
+	auth := stmt.(*ast.CreateUserStmt).Specs[0].AuthOpt
+	c.Assert(auth.AuthPlugin, Equals, "mysql_native_password")
+	c.Assert(auth.ByAuthString, IsTrue)
+	c.Assert(auth.AuthString, Equals, "pw")
+	stmt, err = parser.ParseOneStmt("CREATE USER 'root'@'%' IDENTIFIED WITH 'mysql_native_password' AS 'hash'", "", "")
+	c.Assert(err, IsNil)
+	auth = stmt.(*ast.CreateUserStmt).Specs[0].AuthOpt
+	c.Assert(auth.AuthPlugin, Equals, "mysql_native_password")
+	c.Assert(auth.ByAuthString, IsFalse)
+	c.Assert(auth.HashString, Equals, "hash")
+	stmt, err = parser.ParseOneStmt("CREATE USER 'root'@'%' IDENTIFIED BY 'pw'", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.CreateUserStmt).Specs[0].AuthOpt.AuthPlugin, Equals, "")
+
+	stmt, err = parser.ParseOneStmt("CREATE USER IF NOT EXISTS 'u'@'%'", "", "")
+	c.Assert(err, IsNil)
+	createUser := stmt.(*ast.CreateUserStmt)
+	c.Assert(createUser.IfNotExists, IsTrue)
+	c.Assert(createUser.Specs[0].AuthOpt, IsNil)
+	stmt, err = parser.ParseOneStmt("ALTER USER IF EXISTS USER() IDENTIFIED BY 'pw'", "", "")
+	c.Assert(err, IsNil)
+	alterUser := stmt.(*ast.AlterUserStmt)
+	c.Assert(alterUser.IfExists, IsTrue)
+	c.Assert(alterUser.CurrentAuth.AuthString, Equals, "pw")
+	c.Assert(alterUser.Specs, HasLen, 0)
+	stmt, err = parser.ParseOneStmt("DROP USER 'u'@'%', 'v'@'localhost'", "", "")
+	c.Assert(err, IsNil)
+	dropUser := stmt.(*ast.DropUserStmt)
+	c.Assert(dropUser.IfExists, IsFalse)
+	c.Assert(dropUser.UserList, DeepEquals, []*ast.UserIdentity{
+		{Username: "u", Hostname: "%"},
+		{Username: "v", Hostname: "localhost"},
+	})
+
+	// The host part defaults to '%' and the user name may be empty.
+	stmt, err = parser.ParseOneStmt("DROP USER 'u', ''@'localhost', CURRENT_USER()", "", "")
+	c.Assert(err, IsNil)
+	dropUser = stmt.(*ast.DropUserStmt)
+	c.Assert(dropUser.UserList, DeepEquals, []*ast.UserIdentity{
+		{Username: "u", Hostname: "%"},
+		{Username: "", Hostname: "localhost"},
+		{CurrentUser: true},
+	})
+
+	stmt, err = parser.ParseOneStmt("GRANT ALL PRIVILEGES ON db.* TO 'u'@'%' WITH GRANT OPTION", "", "")
+	c.Assert(err, IsNil)
+	grant := stmt.(*ast.GrantStmt)
+	c.Assert(grant.Privs, HasLen, 1)
+	c.Assert(grant.Privs[0].Priv, Equals, mysql.AllPriv)
+	c.Assert(grant.Privs[0].Cols, HasLen, 0)
+	c.Assert(grant.Level.Level, Equals, ast.GrantLevelDB)
+	c.Assert(grant.Level.DBName, Equals, "db")
+	c.Assert(grant.Users[0].User, DeepEquals, &ast.UserIdentity{Username: "u", Hostname: "%"})
+	c.Assert(grant.WithGrant, IsTrue)
+	stmt, err = parser.ParseOneStmt("GRANT SELECT (c1, c2), INSERT ON db.t TO 'u'@'%'", "", "")
+	c.Assert(err, IsNil)
+	grant = stmt.(*ast.GrantStmt)
+	c.Assert(grant.Privs, HasLen, 2)
+	c.Assert(grant.Privs[0].Priv, Equals, mysql.SelectPriv)
+	c.Assert(grant.Privs[0].Cols, HasLen, 2)
+	c.Assert(grant.Privs[0].Cols[1].Name.O, Equals, "c2")
+	c.Assert(grant.Privs[1].Priv, Equals, mysql.InsertPriv)
+	c.Assert(grant.Privs[1].Cols, HasLen, 0)
+	c.Assert(grant.Level.Level, Equals, ast.GrantLevelTable)
+	c.Assert(grant.WithGrant, IsFalse)
+
+	stmt, err = parser.ParseOneStmt("REVOKE ALL PRIVILEGES, GRANT OPTION ON *.* FROM 'u'@'%'", "", "")
+	c.Assert(err, IsNil)
+	revoke := stmt.(*ast.RevokeStmt)
+	c.Assert(revoke.Privs, HasLen, 2)
+	c.Assert(revoke.Privs[0].Priv, Equals, mysql.AllPriv)
+	c.Assert(revoke.Privs[1].Priv, Equals, mysql.GrantPriv)
+	c.Assert(revoke.Level.Level, Equals, ast.GrantLevelGlobal)
+	c.Assert(revoke.AllLevels, IsFalse)
+	c.Assert(revoke.Users[0].User, DeepEquals, &ast.UserIdentity{Username: "u", Hostname: "%"})
+
+	stmt, err = parser.ParseOneStmt("REVOKE ALL PRIVILEGES, GRANT OPTION FROM 'u'@'%'", "", "")
+	c.Assert(err, IsNil)
+	revoke = stmt.(*ast.RevokeStmt)
+	c.Assert(revoke.Privs, HasLen, 2)
+	c.Assert(revoke.Privs[0].Priv, Equals, mysql.AllPriv)
+	c.Assert(revoke.Privs[1].Priv, Equals, mysql.GrantPriv)
+	c.Assert(revoke.Level.Level, Equals, ast.GrantLevelGlobal)
+	c.Assert(revoke.AllLevels, IsTrue)
+	c.Assert(revoke.Users[0].User, DeepEquals, &ast.UserIdentity{Username: "u", Hostname: "%"})
 }
 
 func (s *testParserSuite) TestComment(c *C) {
@@ -1345,6 +1584,32 @@ func (s *testParserSuite) TestMysqlDump(c *C) {
 	s.RunTest(c, table)
 }
 
+func (s *testParserSuite) TestLockTables(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("LOCK TABLES t READ, db.u WRITE, v READ LOCAL, w LOW_PRIORITY WRITE", "", "")
+	c.Assert(err, IsNil)
+	lock := stmt.(*ast.LockTablesStmt)
+	c.Assert(lock.TableLocks, HasLen, 4)
+	c.Assert(lock.TableLocks[0].Table.Name.O, Equals, "t")
+	c.Assert(lock.TableLocks[0].Type, Equals, ast.TableLockRead)
+	c.Assert(lock.TableLocks[1].Table.Schema.O, Equals, "db")
+	c.Assert(lock.TableLocks[1].Table.Name.O, Equals, "u")
+	c.Assert(lock.TableLocks[1].Type, Equals, ast.TableLockWrite)
+	c.Assert(lock.TableLocks[2].Type, Equals, ast.TableLockReadLocal)
+	c.Assert(lock.TableLocks[3].Type, Equals, ast.TableLockLowPriorityWrite)
+
+	stmt, err = parser.ParseOneStmt("unlock tables", "", "")
+	c.Assert(err, IsNil)
+	_, ok := stmt.(*ast.UnlockTablesStmt)
+	c.Assert(ok, IsTrue)
+
+	_, err = parser.ParseOneStmt("lock tables t", "", "")
+	c.Assert(err, NotNil)
+	_, err = parser.ParseOneStmt("lock tables t low_priority read", "", "")
+	c.Assert(err, NotNil)
+}
+
 func (s *testParserSuite) TestIndexHint(c *C) {
 	defer testleak.AfterTest(c)()
 	table := []testCase{
@@ -1360,6 +1625,81 @@ func (s *testParserSuite) TestIndexHint(c *C) {
 	s.RunTest(c, table)
 }
 
+func (s *testParserSuite) TestOptimizerHints(c *C) {
+	defer testleak.AfterTest(c)()
+	table := []testCase{
+		{`select /*+ TIDB_SMJ(t1, t2) */ * from t1, t2`, true},
+		{`select /*+ TIDB_SMJ(t1) TIDB_INLJ(t2), TIDB_HJ() */ * from t1, t2`, true},
+		{`select /*+ */ 1`, true},
+		{`update /*+ TIDB_INLJ(t1) */ t1, t2 set t1.a = t2.a`, true},
+		{`delete /*+ TIDB_HJ(t1) */ t1 from t1, t2`, true},
+		// Hints which are not table level hints are ignored as a plain comment.
+		{`select /*+ TIDB_SMJ(t1 */ * from t1`, true},
+		{`select /*+ MAX_EXECUTION_TIME(1000) */ 1`, true},
+		{`select /*+ read_from_storage(tiflash[t]) */ 1`, true},
+		{`select /*+ this is a comment */ 1`, true},
+		{`update /*+ TIDB_INLJ(t1), */ t1 set a = 1`, true},
+		// "/*+ */" is a plain comment elsewhere.
+		{`insert /*+ TIDB_SMJ(t1) */ into t1 values (1)`, true},
+		{`select * /*+ TIDB_SMJ(t1 */ from t1`, true},
+	}
+	s.RunTest(c, table)
+
+	parser := New()
+	stmt, err := parser.ParseOneStmt("select /*+ TIDB_SMJ(t1, T2) tidb_inlj(t3) */ * from t1, t2, t3", "", "")
+	c.Assert(err, IsNil)
+	hints := stmt.(*ast.SelectStmt).TableHints
+	c.Assert(hints, HasLen, 2)
+	c.Assert(hints[0].HintName.L, Equals, "tidb_smj")
+	c.Assert(hints[0].Tables, HasLen, 2)
+	c.Assert(hints[0].Tables[0].L, Equals, "t1")
+	c.Assert(hints[0].Tables[1].O, Equals, "T2")
+	c.Assert(hints[1].HintName.L, Equals, "tidb_inlj")
+
+	stmt, err = parser.ParseOneStmt("update /*+ TIDB_HJ(t1) */ t1 set a = 1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.UpdateStmt).TableHints, HasLen, 1)
+	stmt, err = parser.ParseOneStmt("delete /*+ TIDB_HJ(t1) */ from t1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.DeleteStmt).TableHints, HasLen, 1)
+	stmt, err = parser.ParseOneStmt("select /* comment */ * from t1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.SelectStmt).TableHints, HasLen, 0)
+
+	// Table names in hints may be keywords or quoted.
+	stmt, err = parser.ParseOneStmt("select /*+ TIDB_HJ(status, `select`) */ * from status", "", "")
+	c.Assert(err, IsNil)
+	hints = stmt.(*ast.SelectStmt).TableHints
+	c.Assert(hints, HasLen, 1)
+	c.Assert(hints[0].Tables[1].O, Equals, "select")
+
+	for _, src := range []string{
+		"select /*+ MAX_EXECUTION_TIME(1000) */ 1",
+		"select /*+ read_from_storage(tiflash[t]) */ 1",
+		"select /*+ this is a comment */ 1",
+		"select /*+ TIDB_SMJ(t1 */ 1",
+	} {
+		stmt, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", src))
+		c.Assert(stmt.(*ast.SelectStmt).TableHints, HasLen, 0, Commentf("source %s", src))
+	}
+
+	// Only the hints which are not table level hints are skipped.
+	for _, src := range []string{
+		"select /*+ TIDB_INLJ(t1) MAX_EXECUTION_TIME(10) */ * from t1, t2",
+		"select /*+ MAX_EXECUTION_TIME(10), TIDB_INLJ(t1) */ * from t1, t2",
+		"select /*+ read_from_storage(tiflash[t2]) TIDB_INLJ(t1) TIDB_SMJ(t1,) */ * from t1, t2",
+		"select /*+ no hint TIDB_INLJ(t1) */ * from t1, t2",
+	} {
+		stmt, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", src))
+		hints = stmt.(*ast.SelectStmt).TableHints
+		c.Assert(hints, HasLen, 1, Commentf("source %s", src))
+		c.Assert(hints[0].HintName.L, Equals, "tidb_inlj", Commentf("source %s", src))
+		c.Assert(hints[0].Tables, DeepEquals, []model.CIStr{model.NewCIStr("t1")}, Commentf("source %s", src))
+	}
+}
+
 func (s *testParserSuite) TestEscape(c *C) {
 	defer testleak.AfterTest(c)()
 	table := []testCase{
@@ -1412,8 +1752,1504 @@ func (s *testParserSuite) TestExplain(c *C) {
 		{"explain replace into foo values (1 || 2)", true},
 		{"explain update t set id = id + 1 order by id desc;", true},
 		{"explain select c1 from t1 union (select c2 from t2) limit 1, 1", true},
+		{"explain analyze select c1 from t1", true},
+		{"explain analyze insert into t values (1)", true},
+		{"explain analyze t", false},
+		{"explain analyze show tables", false},
+		{"explain format = 'row' select c1 from t1", true},
+		{"explain format = json select c1 from t1", true},
+		{"explain format = \"DOT\" select c1 from t1", true},
+		{"explain format = traditional update t set id = 1", true},
+		{"explain format = 'xml' select c1 from t1", false},
+		{"explain format = json t", false},
+	}
+	s.RunTest(c, table)
+
+	parser := New()
+	stmt, err := parser.ParseOneStmt("explain select c1 from t1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ExplainStmt).Analyze, IsFalse)
+	stmt, err = parser.ParseOneStmt("explain analyze select c1 from t1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ExplainStmt).Analyze, IsTrue)
+	c.Assert(stmt.(*ast.ExplainStmt).Format, Equals, ast.ExplainFormatROW)
+	stmt, err = parser.ParseOneStmt("explain format = 'JSON' select c1 from t1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ExplainStmt).Format, Equals, ast.ExplainFormatJSON)
+}
+
+func (s *testParserSuite) TestBeginTransaction(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("begin", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.BeginStmt).ReadOnly, IsFalse)
+	c.Assert(stmt.(*ast.BeginStmt).WithConsistentSnapshot, IsFalse)
+	stmt, err = parser.ParseOneStmt("start transaction read only, with consistent snapshot", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.BeginStmt).ReadOnly, IsTrue)
+	c.Assert(stmt.(*ast.BeginStmt).WithConsistentSnapshot, IsTrue)
+	stmt, err = parser.ParseOneStmt("start transaction read write", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.BeginStmt).ReadOnly, IsFalse)
+	c.Assert(stmt.(*ast.BeginStmt).WithConsistentSnapshot, IsFalse)
+}
+
+func (s *testParserSuite) TestSetTransaction(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SET TRANSACTION ISOLATION LEVEL SERIALIZABLE", "", "")
+	c.Assert(err, IsNil)
+	st := stmt.(*ast.SetTransactionStmt)
+	c.Assert(st.Scope, Equals, ast.TransactionScopeNext)
+	c.Assert(st.IsolationLevel, Equals, ast.Serializable)
+	c.Assert(st.AccessMode, Equals, "")
+	stmt, err = parser.ParseOneStmt("SET SESSION TRANSACTION READ ONLY", "", "")
+	c.Assert(err, IsNil)
+	st = stmt.(*ast.SetTransactionStmt)
+	c.Assert(st.Scope, Equals, ast.TransactionScopeSession)
+	c.Assert(st.IsolationLevel, Equals, "")
+	c.Assert(st.AccessMode, Equals, ast.TransactionReadOnly)
+	stmt, err = parser.ParseOneStmt("SET GLOBAL TRANSACTION ISOLATION LEVEL READ COMMITTED, READ WRITE", "", "")
+	c.Assert(err, IsNil)
+	st = stmt.(*ast.SetTransactionStmt)
+	c.Assert(st.Scope, Equals, ast.TransactionScopeGlobal)
+	c.Assert(st.IsolationLevel, Equals, ast.ReadCommitted)
+	c.Assert(st.AccessMode, Equals, ast.TransactionReadWrite)
+}
+
+func (s *testParserSuite) TestKill(c *C) {
+	defer testleak.AfterTest(c)()
+	table := []testCase{
+		{"kill 23123", true},
+		{"kill connection 23123", true},
+		{"kill query 23123", true},
+		{"kill tidb 23123", true},
+		{"kill tidb connection 23123", true},
+		{"kill tidb query 23123", true},
+		{"kill", false},
+		{"kill query", false},
+		{"kill 'abc'", false},
 	}
 	s.RunTest(c, table)
+
+	parser := New()
+	stmt, err := parser.ParseOneStmt("kill 23123", "", "")
+	c.Assert(err, IsNil)
+	kill := stmt.(*ast.KillStmt)
+	c.Assert(kill.ConnectionID, Equals, uint64(23123))
+	c.Assert(kill.Query, IsFalse)
+	c.Assert(kill.TiDBExtension, IsFalse)
+	stmt, err = parser.ParseOneStmt("kill tidb query 23123", "", "")
+	c.Assert(err, IsNil)
+	kill = stmt.(*ast.KillStmt)
+	c.Assert(kill.ConnectionID, Equals, uint64(23123))
+	c.Assert(kill.Query, IsTrue)
+	c.Assert(kill.TiDBExtension, IsTrue)
+}
+
+func (s *testParserSuite) TestShowCreate(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("show create table test.t", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowCreateTable))
+	c.Assert(show.Table.Schema.L, Equals, "test")
+	c.Assert(show.Table.Name.L, Equals, "t")
+	c.Assert(show.Full, IsFalse)
+	stmt, err = parser.ParseOneStmt("show create schema test", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowCreateDatabase))
+	c.Assert(show.DBName, Equals, "test")
+	c.Assert(show.Table, IsNil)
+}
+
+func (s *testParserSuite) TestShowWarnings(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("show warnings limit 1, 10", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowWarnings))
+	c.Assert(show.CountOnly, IsFalse)
+	c.Assert(show.Limit, NotNil)
+	c.Assert(show.Limit.Offset.GetValue(), Equals, uint64(1))
+	c.Assert(show.Limit.Count.GetValue(), Equals, uint64(10))
+	stmt, err = parser.ParseOneStmt("show count(*) errors", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowErrors))
+	c.Assert(show.CountOnly, IsTrue)
+	c.Assert(show.Limit, IsNil)
+}
+
+func (s *testParserSuite) TestOriginTextPosition(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	src := "select a, (b + ?) from db.t;  update t1 set c = 1 where t1.d = 2"
+	stmts, err := parser.Parse(src, "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmts, HasLen, 2)
+	c.Assert(stmts[0].OriginTextPosition(), Equals, 0)
+	c.Assert(stmts[1].OriginTextPosition(), Equals, strings.Index(src, "update"))
+
+	sel := stmts[0].(*ast.SelectStmt)
+	c.Assert(sel.Fields.Fields[0].OriginTextPosition(), Equals, strings.Index(src, "a,"))
+	c.Assert(sel.Fields.Fields[0].Expr.OriginTextPosition(), Equals, strings.Index(src, "a,"))
+	paren := sel.Fields.Fields[1].Expr.(*ast.ParenthesesExpr)
+	c.Assert(paren.OriginTextPosition(), Equals, strings.Index(src, "("))
+	marker := paren.Expr.(*ast.BinaryOperationExpr).R.(*ast.ParamMarkerExpr)
+	c.Assert(marker.OriginTextPosition(), Equals, strings.Index(src, "?"))
+	tbl := sel.From.TableRefs.Left.(*ast.TableSource).Source.(*ast.TableName)
+	c.Assert(tbl.OriginTextPosition(), Equals, strings.Index(src, "db.t"))
+
+	upd := stmts[1].(*ast.UpdateStmt)
+	c.Assert(upd.List[0].Column.OriginTextPosition(), Equals, strings.Index(src, "c ="))
+	where := upd.Where.(*ast.BinaryOperationExpr)
+	c.Assert(where.L.(*ast.ColumnNameExpr).OriginTextPosition(), Equals, strings.Index(src, "t1.d"))
+	// The position of the other expressions is unknown.
+	c.Assert(where.OriginTextPosition(), Equals, -1)
+	c.Assert(where.R.OriginTextPosition(), Equals, -1)
+	c.Assert((&ast.ValueExpr{}).OriginTextPosition(), Equals, -1)
+
+	// Copies of a node keep the position.
+	copied := *tbl
+	c.Assert(copied.OriginTextPosition(), Equals, tbl.OriginTextPosition())
+}
+
+func (s *testParserSuite) TestInnerStmtText(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("EXPLAIN SELECT 1+1", "", "")
+	c.Assert(err, IsNil)
+	es := stmt.(*ast.ExplainStmt)
+	c.Assert(es.Text(), Equals, "EXPLAIN SELECT 1+1")
+	c.Assert(es.Stmt.Text(), Equals, "SELECT 1+1")
+	c.Assert(es.Stmt.OriginTextPosition(), Equals, len("EXPLAIN "))
+
+	src := "explain format = 'dot'  select a from t where b = 1 ; trace update t set a = 1  "
+	stmts, err := parser.Parse(src, "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmts, HasLen, 2)
+	c.Assert(stmts[0].(*ast.ExplainStmt).Stmt.Text(), Equals, "select a from t where b = 1")
+	c.Assert(stmts[1].(*ast.TraceStmt).Stmt.Text(), Equals, "update t set a = 1")
+
+	stmt, err = parser.ParseOneStmt("explain analyze insert into t values (1);", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ExplainStmt).Stmt.Text(), Equals, "insert into t values (1)")
+}
+
+func (s *testParserSuite) TestStmtText(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src   string
+		texts []string
+	}{
+		{"BEGIN; SET @x=1; COMMIT;", []string{"BEGIN", "SET @x=1", "COMMIT"}},
+		{"select 1;\n  select 2 ;  ", []string{"select 1", "select 2"}},
+		// Empty statements have no text, and their ';' is not part of the next statement.
+		{"select 1;;select 2", []string{"select 1", "", "select 2"}},
+		{"select 1; ; select 2", []string{"select 1", "", "select 2"}},
+		{";; select 1", []string{"", "", "select 1"}},
+		// Semicolons in strings, quoted identifiers and comments don't end a statement.
+		{"select 'a;b', \"c;d\"; select `e;f` from t", []string{"select 'a;b', \"c;d\"", "select `e;f` from t"}},
+		{"select 1 /* a;b */; select 2 -- c;d\n; select 3 # e;f\n", []string{"select 1 /* a;b */", "select 2 -- c;d", "select 3 # e;f"}},
+	}
+	for _, t := range table {
+		stmts, err := parser.Parse(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		texts := make([]string, 0, len(stmts))
+		for _, stmt := range stmts {
+			texts = append(texts, stmt.Text())
+		}
+		c.Assert(texts, DeepEquals, t.texts, Commentf("source %s", t.src))
+	}
+}
+
+func (s *testParserSuite) TestRecoverTable(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("recover table db.t", "", "")
+	c.Assert(err, IsNil)
+	recover := stmt.(*ast.RecoverTableStmt)
+	c.Assert(recover.Table.Schema.O, Equals, "db")
+	c.Assert(recover.Table.Name.O, Equals, "t")
+	c.Assert(recover.JobID, Equals, int64(0))
+
+	stmt, err = parser.ParseOneStmt("RECOVER TABLE BY JOB 42", "", "")
+	c.Assert(err, IsNil)
+	recover = stmt.(*ast.RecoverTableStmt)
+	c.Assert(recover.Table, IsNil)
+	c.Assert(recover.JobID, Equals, int64(42))
+
+	_, err = parser.ParseOneStmt("recover table by job 0", "", "")
+	c.Assert(err, NotNil)
+	_, err = parser.ParseOneStmt("recover table by job", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestFlashBackTable(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("flashback table db.t", "", "")
+	c.Assert(err, IsNil)
+	flashBack := stmt.(*ast.FlashBackTableStmt)
+	c.Assert(flashBack.Table.Schema.O, Equals, "db")
+	c.Assert(flashBack.Table.Name.O, Equals, "t")
+	c.Assert(flashBack.NewName, Equals, "")
+
+	stmt, err = parser.ParseOneStmt("FLASHBACK TABLE t TO `t 1`", "", "")
+	c.Assert(err, IsNil)
+	flashBack = stmt.(*ast.FlashBackTableStmt)
+	c.Assert(flashBack.Table.Name.O, Equals, "t")
+	c.Assert(flashBack.NewName, Equals, "t 1")
+
+	_, err = parser.ParseOneStmt("flashback table t to", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestShutdown(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SHUTDOWN", "", "")
+	c.Assert(err, IsNil)
+	_, ok := stmt.(*ast.ShutdownStmt)
+	c.Assert(ok, IsTrue)
+	_, err = parser.ParseOneStmt("shutdown now", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestRestart(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("RESTART", "", "")
+	c.Assert(err, IsNil)
+	_, ok := stmt.(*ast.RestartStmt)
+	c.Assert(ok, IsTrue)
+	_, err = parser.ParseOneStmt("restart now", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestAlterInstance(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("ALTER INSTANCE RELOAD TLS", "", "")
+	c.Assert(err, IsNil)
+	alterInstance := stmt.(*ast.AlterInstanceStmt)
+	c.Assert(alterInstance.ReloadTLS, IsTrue)
+	c.Assert(alterInstance.NoRollbackOnError, IsFalse)
+
+	stmt, err = parser.ParseOneStmt("alter instance reload tls no rollback on error", "", "")
+	c.Assert(err, IsNil)
+	alterInstance = stmt.(*ast.AlterInstanceStmt)
+	c.Assert(alterInstance.ReloadTLS, IsTrue)
+	c.Assert(alterInstance.NoRollbackOnError, IsTrue)
+
+	for _, src := range []string{"alter instance", "alter instance reload", "alter instance reload tls no rollback"} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestHelp(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	for _, topic := range []string{"contents", "SELECT", "data types"} {
+		stmt, err := parser.ParseOneStmt("HELP '"+topic+"'", "", "")
+		c.Assert(err, IsNil)
+		c.Assert(stmt.(*ast.HelpStmt).Topic, Equals, topic)
+	}
+	_, err := parser.ParseOneStmt("help", "", "")
+	c.Assert(err, NotNil)
+	_, err = parser.ParseOneStmt("help contents", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestAnalyze(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("analyze table t1, db.t2", "", "")
+	c.Assert(err, IsNil)
+	analyze := stmt.(*ast.AnalyzeTableStmt)
+	c.Assert(analyze.TableNames, HasLen, 2)
+	c.Assert(analyze.IndexFlag, IsFalse)
+	c.Assert(analyze.MaxNumBuckets, Equals, uint64(0))
+
+	stmt, err = parser.ParseOneStmt("ANALYZE TABLE t INDEX idx1, idx2 WITH 64 BUCKETS", "", "")
+	c.Assert(err, IsNil)
+	analyze = stmt.(*ast.AnalyzeTableStmt)
+	c.Assert(analyze.TableNames, HasLen, 1)
+	c.Assert(analyze.IndexFlag, IsTrue)
+	c.Assert(analyze.IndexNames, HasLen, 2)
+	c.Assert(analyze.IndexNames[1].L, Equals, "idx2")
+	c.Assert(analyze.MaxNumBuckets, Equals, uint64(64))
+
+	stmt, err = parser.ParseOneStmt("analyze table t index", "", "")
+	c.Assert(err, IsNil)
+	analyze = stmt.(*ast.AnalyzeTableStmt)
+	c.Assert(analyze.IndexFlag, IsTrue)
+	c.Assert(analyze.IndexNames, HasLen, 0)
+
+	for _, src := range []string{"analyze table t1, t2 index idx", "analyze table t with buckets", "analyze table t with 4"} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestRenameTable(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("rename table a to b, d.c to d.e", "", "")
+	c.Assert(err, IsNil)
+	rename := stmt.(*ast.RenameTableStmt)
+	c.Assert(rename.TableToTables, HasLen, 2)
+	c.Assert(rename.TableToTables[0].OldTable.Name.O, Equals, "a")
+	c.Assert(rename.TableToTables[0].NewTable.Name.O, Equals, "b")
+	c.Assert(rename.TableToTables[1].OldTable.Schema.O, Equals, "d")
+	c.Assert(rename.TableToTables[1].OldTable.Name.O, Equals, "c")
+	c.Assert(rename.TableToTables[1].NewTable.Name.O, Equals, "e")
+}
+
+func (s *testParserSuite) TestCalibrateResource(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("calibrate resource workload TPCC", "", "")
+	c.Assert(err, IsNil)
+	calibrate := stmt.(*ast.CalibrateResourceStmt)
+	c.Assert(calibrate.WorkloadType, Equals, "tpcc")
+	c.Assert(calibrate.DynamicCalibrateResourceOptionList, HasLen, 0)
+
+	stmt, err = parser.ParseOneStmt("calibrate resource start_time '2023-04-18 08:00:00' end_time = '2023-04-18 08:20:00'", "", "")
+	c.Assert(err, IsNil)
+	calibrate = stmt.(*ast.CalibrateResourceStmt)
+	c.Assert(calibrate.WorkloadType, Equals, "")
+	opts := calibrate.DynamicCalibrateResourceOptionList
+	c.Assert(opts, HasLen, 2)
+	c.Assert(opts[0].Tp, Equals, ast.CalibrateStartTime)
+	c.Assert(opts[0].Ts.GetValue(), Equals, "2023-04-18 08:00:00")
+	c.Assert(opts[1].Tp, Equals, ast.CalibrateEndTime)
+
+	stmt, err = parser.ParseOneStmt("calibrate resource start_time date_sub(now(), interval 20 minute), duration '20m'", "", "")
+	c.Assert(err, IsNil)
+	opts = stmt.(*ast.CalibrateResourceStmt).DynamicCalibrateResourceOptionList
+	c.Assert(opts, HasLen, 2)
+	_, ok := opts[0].Ts.(*ast.FuncCallExpr)
+	c.Assert(ok, IsTrue)
+	c.Assert(opts[1].Tp, Equals, ast.CalibrateDuration)
+	c.Assert(opts[1].StrValue, Equals, "20m")
+
+	for _, src := range []string{"calibrate resource workload tpcc start_time '2023-04-18 08:00:00'", "calibrate resource duration 20", "calibrate workload tpcc"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestQueryWatch(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("QUERY WATCH ADD RESOURCE GROUP rg1 ACTION = KILL SQL TEXT SIMILAR TO 'select * from t'", "", "")
+	c.Assert(err, IsNil)
+	opts := stmt.(*ast.AddQueryWatchStmt).QueryWatchOptionList
+	c.Assert(opts, HasLen, 3)
+	c.Assert(opts[0].Tp, Equals, ast.QueryWatchResourceGroup)
+	c.Assert(opts[0].ResourceGroupName.L, Equals, "rg1")
+	c.Assert(opts[1].Tp, Equals, ast.QueryWatchAction)
+	c.Assert(opts[1].Action, Equals, ast.RunawayActionKill)
+	c.Assert(opts[2].Tp, Equals, ast.QueryWatchType)
+	c.Assert(opts[2].WatchType, Equals, ast.WatchSimilar)
+	c.Assert(opts[2].Pattern, Equals, "select * from t")
+	c.Assert(opts[2].TypeSpecified, IsTrue)
+
+	stmt, err = parser.ParseOneStmt("query watch add plan digest 'd1', action cooldown", "", "")
+	c.Assert(err, IsNil)
+	opts = stmt.(*ast.AddQueryWatchStmt).QueryWatchOptionList
+	c.Assert(opts, HasLen, 2)
+	c.Assert(opts[0].WatchType, Equals, ast.WatchPlan)
+	c.Assert(opts[0].Pattern, Equals, "d1")
+	c.Assert(opts[0].TypeSpecified, IsFalse)
+	c.Assert(opts[1].Action, Equals, ast.RunawayActionCooldown)
+
+	stmt, err = parser.ParseOneStmt("query watch add sql digest 'd2' action dryrun", "", "")
+	c.Assert(err, IsNil)
+	opts = stmt.(*ast.AddQueryWatchStmt).QueryWatchOptionList
+	c.Assert(opts[0].WatchType, Equals, ast.WatchSimilar)
+	c.Assert(opts[1].Action, Equals, ast.RunawayActionDryRun)
+
+	stmt, err = parser.ParseOneStmt("query watch remove 12", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.DropQueryWatchStmt).IntValue, Equals, int64(12))
+
+	for _, src := range []string{"query watch add", "query watch add action stop", "query watch add sql text 'select 1'", "query watch remove", "query watch remove a"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestOptimizeTable(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src             string
+		noWriteToBinLog bool
+	}{
+		{"optimize table t1, d.t2", false},
+		{"OPTIMIZE NO_WRITE_TO_BINLOG TABLE t1, d.t2", true},
+		{"optimize local table t1, d.t2", true},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		optimize := stmt.(*ast.OptimizeTableStmt)
+		c.Assert(optimize.NoWriteToBinLog, Equals, t.noWriteToBinLog, Commentf("source %s", t.src))
+		c.Assert(optimize.Tables, HasLen, 2)
+		c.Assert(optimize.Tables[0].Name.O, Equals, "t1")
+		c.Assert(optimize.Tables[1].Schema.O, Equals, "d")
+		c.Assert(optimize.Tables[1].Name.O, Equals, "t2")
+	}
+
+	for _, src := range []string{"optimize t1", "optimize table", "optimize local no_write_to_binlog table t1"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestCheckTable(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src     string
+		options []string
+	}{
+		{"check table t1, d.t2", nil},
+		{"CHECK TABLE t1, d.t2 FOR UPGRADE", []string{"FOR UPGRADE"}},
+		{"check table t1, d.t2 quick fast medium extended changed", []string{"QUICK", "FAST", "MEDIUM", "EXTENDED", "CHANGED"}},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		check := stmt.(*ast.CheckTableStmt)
+		c.Assert(check.CheckOptions, DeepEquals, t.options, Commentf("source %s", t.src))
+		c.Assert(check.Tables, HasLen, 2)
+		c.Assert(check.Tables[0].Name.O, Equals, "t1")
+		c.Assert(check.Tables[1].Schema.O, Equals, "d")
+		c.Assert(check.Tables[1].Name.O, Equals, "t2")
+	}
+
+	for _, src := range []string{"check t1", "check table", "check table t1 for", "check table t1 slow"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestPlanReplayer(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("PLAN REPLAYER DUMP EXPLAIN SELECT a FROM t", "", "")
+	c.Assert(err, IsNil)
+	replayer := stmt.(*ast.PlanReplayerStmt)
+	c.Assert(replayer.Load, IsFalse)
+	c.Assert(replayer.Analyze, IsFalse)
+	c.Assert(replayer.Stmt.Text(), Equals, "SELECT a FROM t")
+
+	stmt, err = parser.ParseOneStmt("plan replayer dump explain analyze update t set a = 1", "", "")
+	c.Assert(err, IsNil)
+	replayer = stmt.(*ast.PlanReplayerStmt)
+	c.Assert(replayer.Analyze, IsTrue)
+	_, ok := replayer.Stmt.(*ast.UpdateStmt)
+	c.Assert(ok, IsTrue)
+
+	stmt, err = parser.ParseOneStmt("plan replayer load '/tmp/replayer.zip'", "", "")
+	c.Assert(err, IsNil)
+	replayer = stmt.(*ast.PlanReplayerStmt)
+	c.Assert(replayer.Load, IsTrue)
+	c.Assert(replayer.File, Equals, "/tmp/replayer.zip")
+	c.Assert(replayer.Stmt, IsNil)
+
+	for _, src := range []string{
+		"plan replayer dump select 1",
+		"plan replayer dump explain",
+		"plan replayer load",
+		"plan replayer load 'a.zip' select 1",
+		"plan replayer dump explain select 1 load 'a.zip'",
+	} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestLoadStats(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("LOAD STATS '/tmp/stats.json'", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.LoadStatsStmt).Path, Equals, "/tmp/stats.json")
+
+	for _, src := range []string{"load stats", "load stats /tmp/stats.json", "load stats '/tmp/a' into table t"} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestBRIE(c *C) {
+	defer testleak.AfterTest(c)()
+	table := []testCase{
+		{"backup database * to 's3://bucket/path'", true},
+		{"backup database a, b to 'local:///tmp/backup' with rate_limit = 120, concurrency = 4", true},
+		{"backup table t1, db.t2 to 'local:///tmp/backup'", true},
+		{"restore schema * from 'local:///tmp/backup' with online = 1", true},
+		{"restore table t from 'local:///tmp/backup'", true},
+		{"backup database * from 'local:///tmp/backup'", false},
+		{"restore database * to 'local:///tmp/backup'", false},
+		{"backup table * to 'local:///tmp/backup'", false},
+		{"backup database * to 'local:///tmp/backup' with online", false},
+		// BACKUP and RESTORE are not reserved.
+		{"create table backup (restore int)", true},
+	}
+	s.RunTest(c, table)
+
+	parser := New()
+	stmt, err := parser.ParseOneStmt("BACKUP DATABASE a, B TO 's3://bucket/path' WITH RATE_LIMIT = 120", "", "")
+	c.Assert(err, IsNil)
+	brie := stmt.(*ast.BRIEStmt)
+	c.Assert(brie.Kind, Equals, ast.BRIEKindBackup)
+	c.Assert(brie.Schemas, DeepEquals, []string{"a", "B"})
+	c.Assert(brie.Tables, HasLen, 0)
+	c.Assert(brie.Storage, Equals, "s3://bucket/path")
+	c.Assert(brie.Options, HasLen, 1)
+	c.Assert(brie.Options[0].Name, Equals, "rate_limit")
+	c.Assert(brie.Options[0].Value.GetValue(), Equals, int64(120))
+
+	stmt, err = parser.ParseOneStmt("restore table t1, db.t2 from 'local:///tmp/backup'", "", "")
+	c.Assert(err, IsNil)
+	brie = stmt.(*ast.BRIEStmt)
+	c.Assert(brie.Kind, Equals, ast.BRIEKindRestore)
+	c.Assert(brie.Schemas, HasLen, 0)
+	c.Assert(brie.Tables, HasLen, 2)
+	c.Assert(brie.Tables[1].Schema.L, Equals, "db")
+	c.Assert(brie.Tables[1].Name.L, Equals, "t2")
+}
+
+func (s *testParserSuite) TestDropStats(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("DROP STATS t1, db.t2", "", "")
+	c.Assert(err, IsNil)
+	dropStats := stmt.(*ast.DropStatsStmt)
+	c.Assert(dropStats.Tables, HasLen, 2)
+	c.Assert(dropStats.Tables[0].Name.L, Equals, "t1")
+	c.Assert(dropStats.Tables[1].Schema.L, Equals, "db")
+	c.Assert(dropStats.PartitionNames, HasLen, 0)
+	c.Assert(dropStats.IsGlobalStats, IsFalse)
+
+	stmt, err = parser.ParseOneStmt("drop stats t partition p0, p1", "", "")
+	c.Assert(err, IsNil)
+	dropStats = stmt.(*ast.DropStatsStmt)
+	c.Assert(dropStats.Tables, HasLen, 1)
+	c.Assert(dropStats.PartitionNames, DeepEquals, []string{"p0", "p1"})
+	c.Assert(dropStats.IsGlobalStats, IsFalse)
+
+	stmt, err = parser.ParseOneStmt("drop stats t global", "", "")
+	c.Assert(err, IsNil)
+	dropStats = stmt.(*ast.DropStatsStmt)
+	c.Assert(dropStats.Tables, HasLen, 1)
+	c.Assert(dropStats.PartitionNames, HasLen, 0)
+	c.Assert(dropStats.IsGlobalStats, IsTrue)
+
+	for _, src := range []string{"drop stats", "drop stats t1, t2 partition p0", "drop stats t partition", "drop stats t1, t2 global"} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestNonTransactionalDML(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("BATCH ON a LIMIT 100 DELETE FROM t WHERE b > 1", "", "")
+	c.Assert(err, IsNil)
+	batch := stmt.(*ast.NonTransactionalDMLStmt)
+	c.Assert(batch.ShardColumn.Name.L, Equals, "a")
+	c.Assert(batch.Limit, Equals, uint64(100))
+	c.Assert(batch.DryRun, Equals, ast.NoDryRun)
+	_, ok := batch.DMLStmt.(*ast.DeleteStmt)
+	c.Assert(ok, IsTrue)
+
+	stmt, err = parser.ParseOneStmt("batch limit 10 dry run update t set b = 1", "", "")
+	c.Assert(err, IsNil)
+	batch = stmt.(*ast.NonTransactionalDMLStmt)
+	c.Assert(batch.ShardColumn, IsNil)
+	c.Assert(batch.DryRun, Equals, ast.DryRunSplitDml)
+	_, ok = batch.DMLStmt.(*ast.UpdateStmt)
+	c.Assert(ok, IsTrue)
+
+	stmt, err = parser.ParseOneStmt("batch on t.a limit 10 dry run query insert into t2 select * from t", "", "")
+	c.Assert(err, IsNil)
+	batch = stmt.(*ast.NonTransactionalDMLStmt)
+	c.Assert(batch.ShardColumn.Table.L, Equals, "t")
+	c.Assert(batch.DryRun, Equals, ast.DryRunQuery)
+	_, ok = batch.DMLStmt.(*ast.InsertStmt)
+	c.Assert(ok, IsTrue)
+
+	stmt, err = parser.ParseOneStmt("batch limit 10 replace into t2 select * from t", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.NonTransactionalDMLStmt).DMLStmt.(*ast.InsertStmt).IsReplace, IsTrue)
+
+	for _, src := range []string{"batch delete from t", "batch limit 10 select * from t", "batch limit 10 dry query delete from t", "batch on a limit delete from t"} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestImportInto(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("import into d.t (a, @b, c) from 's3://bucket/*.csv' format 'csv' with Thread = 8, skip_rows = 1, detached", "", "")
+	c.Assert(err, IsNil)
+	imp := stmt.(*ast.ImportIntoStmt)
+	c.Assert(imp.Table.Schema.O, Equals, "d")
+	c.Assert(imp.Table.Name.O, Equals, "t")
+	c.Assert(imp.ColumnsAndUserVars, HasLen, 3)
+	c.Assert(imp.ColumnsAndUserVars[0].ColumnName.Name.O, Equals, "a")
+	c.Assert(imp.ColumnsAndUserVars[0].UserVar, IsNil)
+	c.Assert(imp.ColumnsAndUserVars[1].ColumnName, IsNil)
+	c.Assert(imp.ColumnsAndUserVars[1].UserVar.Name, Equals, "b")
+	c.Assert(imp.ColumnsAndUserVars[2].ColumnName.Name.O, Equals, "c")
+	c.Assert(imp.Path, Equals, "s3://bucket/*.csv")
+	c.Assert(imp.Format, Equals, "csv")
+	c.Assert(imp.Options, HasLen, 3)
+	c.Assert(imp.Options[0].Name, Equals, "thread")
+	c.Assert(imp.Options[0].Value.GetValue(), Equals, int64(8))
+	c.Assert(imp.Options[2].Name, Equals, "detached")
+	c.Assert(imp.Options[2].Value, IsNil)
+
+	stmt, err = parser.ParseOneStmt("import into t from '/tmp/t.csv'", "", "")
+	c.Assert(err, IsNil)
+	imp = stmt.(*ast.ImportIntoStmt)
+	c.Assert(imp.ColumnsAndUserVars, HasLen, 0)
+	c.Assert(imp.Format, Equals, "")
+	c.Assert(imp.Options, HasLen, 0)
+
+	for _, src := range []string{"import into t", "import into t from '/tmp/t.csv' with", "import into t () from '/tmp/t.csv'", "import into t from '/tmp/t.csv' with thread = a"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestRepairTable(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("admin repair table d.t create table t (a int primary key, b varchar(10), index idx(b)) charset = utf8", "", "")
+	c.Assert(err, IsNil)
+	repair := stmt.(*ast.RepairTableStmt)
+	c.Assert(repair.Table.Schema.O, Equals, "d")
+	c.Assert(repair.Table.Name.O, Equals, "t")
+	c.Assert(repair.CreateStmt.Table.Name.O, Equals, "t")
+	c.Assert(repair.CreateStmt.Cols, HasLen, 2)
+	c.Assert(repair.CreateStmt.Constraints, HasLen, 1)
+	c.Assert(repair.CreateStmt.Options, HasLen, 1)
+
+	for _, src := range []string{"admin repair table t", "admin repair table t create table t", "admin repair table t select 1"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestBinding(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("create global binding for select * from t where a = 1 using select * from t use index(a) where a = 1", "", "")
+	c.Assert(err, IsNil)
+	create := stmt.(*ast.CreateBindingStmt)
+	c.Assert(create.GlobalScope, IsTrue)
+	_, ok := create.OriginSel.(*ast.SelectStmt)
+	c.Assert(ok, IsTrue)
+	hinted := create.HintedSel.(*ast.SelectStmt)
+	c.Assert(hinted.From.TableRefs.Left.(*ast.TableSource).Source.(*ast.TableName).IndexHints, HasLen, 1)
+
+	stmt, err = parser.ParseOneStmt("drop binding for select 1 union select 2", "", "")
+	c.Assert(err, IsNil)
+	drop := stmt.(*ast.DropBindingStmt)
+	c.Assert(drop.GlobalScope, IsFalse)
+	_, ok = drop.OriginSel.(*ast.UnionStmt)
+	c.Assert(ok, IsTrue)
+
+	stmt, err = parser.ParseOneStmt("create session binding for select 1 using select 1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.CreateBindingStmt).GlobalScope, IsFalse)
+
+	for _, src := range []string{"create binding for select 1", "create binding for delete from t using delete from t", "drop binding for select 1 using select 1"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestSetConfig(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src      string
+		tp       string
+		instance string
+		name     string
+	}{
+		{"SET CONFIG TiKV split.qps-threshold = 1000", "tikv", "", "split.qps-threshold"},
+		{"set config pd log-level='info'", "pd", "", "log-level"},
+		{"set config '127.0.0.1:20180' raftstore.raft-log-gc-threshold = 10", "", "127.0.0.1:20180", "raftstore.raft-log-gc-threshold"},
+		{"set config 'tikv' split.qps-threshold = 1000", "", "tikv", "split.qps-threshold"},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		setConfig := stmt.(*ast.SetConfigStmt)
+		c.Assert(setConfig.Type, Equals, t.tp)
+		c.Assert(setConfig.Instance, Equals, t.instance)
+		c.Assert(setConfig.Name, Equals, t.name)
+		c.Assert(setConfig.Value, NotNil)
+		c.Assert(setConfig.Validate(), IsNil)
+	}
+
+	// CONFIG is still usable as a variable name.
+	stmt, err := parser.ParseOneStmt("set config = 1", "", "")
+	c.Assert(err, IsNil)
+	_, ok := stmt.(*ast.SetStmt)
+	c.Assert(ok, IsTrue)
+	for _, src := range []string{"set config tikv", "set config tikv = 1", "set config tikv a."} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestSetRole(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src   string
+		tp    ast.SetRoleType
+		roles []string
+	}{
+		{"SET ROLE DEFAULT", ast.SetRoleDefault, nil},
+		{"SET ROLE NONE", ast.SetRoleNone, nil},
+		{"SET ROLE ALL", ast.SetRoleAll, nil},
+		{"SET ROLE ALL EXCEPT 'r1', 'r2'@'localhost'", ast.SetRoleAllExcept, []string{"'r1'@'%'", "'r2'@'localhost'"}},
+		{"set role 'r1'@'%', 'r2'", ast.SetRoleRegular, []string{"'r1'@'%'", "'r2'@'%'"}},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		setRole := stmt.(*ast.SetRoleStmt)
+		c.Assert(setRole.SetRoleOpt, Equals, t.tp)
+		c.Assert(setRole.RoleList, HasLen, len(t.roles))
+		for i, role := range setRole.RoleList {
+			c.Assert(role.String(), Equals, t.roles[i])
+		}
+	}
+
+	// SET ROLE doesn't take the role name as a variable.
+	stmt, err := parser.ParseOneStmt("set role = 1", "", "")
+	c.Assert(err, IsNil)
+	_, ok := stmt.(*ast.SetStmt)
+	c.Assert(ok, IsTrue)
+	for _, src := range []string{"set role", "set role all except", "set role none, 'r1'"} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestSetDefaultRole(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src   string
+		tp    ast.SetRoleType
+		roles []string
+		users []string
+	}{
+		{"SET DEFAULT ROLE NONE TO 'u1'", ast.SetRoleNone, nil, []string{"'u1'@'%'"}},
+		{"SET DEFAULT ROLE ALL TO 'u1'@'localhost', 'u2'", ast.SetRoleAll, nil, []string{"'u1'@'localhost'", "'u2'@'%'"}},
+		{"set default role 'r1', 'r2'@'localhost' to 'u1'", ast.SetRoleRegular, []string{"'r1'@'%'", "'r2'@'localhost'"}, []string{"'u1'@'%'"}},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		setDefaultRole := stmt.(*ast.SetDefaultRoleStmt)
+		c.Assert(setDefaultRole.SetRoleOpt, Equals, t.tp)
+		c.Assert(setDefaultRole.RoleList, HasLen, len(t.roles))
+		for i, role := range setDefaultRole.RoleList {
+			c.Assert(role.String(), Equals, t.roles[i])
+		}
+		c.Assert(setDefaultRole.UserList, HasLen, len(t.users))
+		for i, user := range setDefaultRole.UserList {
+			c.Assert(user.String(), Equals, t.users[i])
+		}
+	}
+
+	for _, src := range []string{"set default role all", "set default role 'r1' to", "set default role default to 'u1'", "set default role all except 'r1' to 'u1'"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestRoleStmts(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("CREATE ROLE IF NOT EXISTS 'r1', 'r2'@'localhost'", "", "")
+	c.Assert(err, IsNil)
+	createRole := stmt.(*ast.CreateRoleStmt)
+	c.Assert(createRole.IfNotExists, IsTrue)
+	c.Assert(createRole.RoleList, HasLen, 2)
+	c.Assert(createRole.RoleList[1].String(), Equals, "'r2'@'localhost'")
+
+	stmt, err = parser.ParseOneStmt("drop role 'r1'", "", "")
+	c.Assert(err, IsNil)
+	dropRole := stmt.(*ast.DropRoleStmt)
+	c.Assert(dropRole.IfExists, IsFalse)
+	c.Assert(dropRole.RoleList, HasLen, 1)
+
+	stmt, err = parser.ParseOneStmt("GRANT 'r1', 'r2' TO 'u1'@'localhost', 'u2'", "", "")
+	c.Assert(err, IsNil)
+	grantRole := stmt.(*ast.GrantRoleStmt)
+	c.Assert(grantRole.Roles, HasLen, 2)
+	c.Assert(grantRole.Users, HasLen, 2)
+	c.Assert(grantRole.Users[0].String(), Equals, "'u1'@'localhost'")
+
+	stmt, err = parser.ParseOneStmt("revoke 'r1' from 'u1', 'u2', 'u3'", "", "")
+	c.Assert(err, IsNil)
+	revokeRole := stmt.(*ast.RevokeRoleStmt)
+	c.Assert(revokeRole.Roles, HasLen, 1)
+	c.Assert(revokeRole.Users, HasLen, 3)
+
+	for _, src := range []string{"create role", "drop role if exists", "grant 'r1' to", "revoke 'r1' to 'u1'"} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestSplitRegion(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SPLIT TABLE t BETWEEN (0) AND (1000000) REGIONS 16", "", "")
+	c.Assert(err, IsNil)
+	split := stmt.(*ast.SplitRegionStmt)
+	c.Assert(split.Table.Name.L, Equals, "t")
+	c.Assert(split.IndexName, Equals, "")
+	c.Assert(split.SplitOpt.Lower, HasLen, 1)
+	c.Assert(split.SplitOpt.Upper, HasLen, 1)
+	c.Assert(split.SplitOpt.Num, Equals, int64(16))
+	c.Assert(split.SplitOpt.ValueLists, HasLen, 0)
+
+	stmt, err = parser.ParseOneStmt("split table db.t index idx by (1, 'a'), (2, 'b'), (3, 'c')", "", "")
+	c.Assert(err, IsNil)
+	split = stmt.(*ast.SplitRegionStmt)
+	c.Assert(split.Table.Schema.L, Equals, "db")
+	c.Assert(split.IndexName, Equals, "idx")
+	c.Assert(split.SplitOpt.ValueLists, HasLen, 3)
+	c.Assert(split.SplitOpt.ValueLists[1], HasLen, 2)
+
+	for _, sql := range []string{
+		"split table t between (0) and (100) regions 0",
+		"split table t between (0) and (100)",
+		"split table t by",
+		"split table t",
+	} {
+		_, err = parser.ParseOneStmt(sql, "", "")
+		c.Assert(err, NotNil, Commentf("sql: %s", sql))
+	}
+}
+
+func (s *testParserSuite) TestChange(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("CHANGE PUMP TO NODE_STATE='paused' FOR NODE_ID 'x'", "", "")
+	c.Assert(err, IsNil)
+	change := stmt.(*ast.ChangeStmt)
+	c.Assert(change.NodeType, Equals, ast.PumpType)
+	c.Assert(change.State, Equals, "paused")
+	c.Assert(change.NodeID, Equals, "x")
+
+	stmt, err = parser.ParseOneStmt("change drainer to node_state = 'online' for node_id 'y'", "", "")
+	c.Assert(err, IsNil)
+	change = stmt.(*ast.ChangeStmt)
+	c.Assert(change.NodeType, Equals, ast.DrainerType)
+	c.Assert(change.State, Equals, "online")
+	c.Assert(change.NodeID, Equals, "y")
+
+	_, err = parser.ParseOneStmt("change master to node_state = 'online' for node_id 'y'", "", "")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, ".*Unknown binlog node type: 'master'.*")
+	_, err = parser.ParseOneStmt("change pump to node_state = 'online'", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestShowGrants(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("show grants", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowGrants))
+	c.Assert(show.User, IsNil)
+	c.Assert(show.Roles, IsNil)
+	stmt, err = parser.ParseOneStmt("show grants for 'u'@'localhost'", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.User, DeepEquals, &ast.UserIdentity{Username: "u", Hostname: "localhost"})
+	c.Assert(show.Roles, IsNil)
+	stmt, err = parser.ParseOneStmt("show grants for current_user using 'r1', 'r2'@'h'", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.User.CurrentUser, IsTrue)
+	c.Assert(show.Roles, DeepEquals, []*ast.RoleIdentity{
+		{Username: "r1", Hostname: "%"},
+		{Username: "r2", Hostname: "h"},
+	})
+	_, err = parser.ParseOneStmt("show grants using 'r1'", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestShowExtended(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src      string
+		tp       int
+		full     bool
+		extended bool
+	}{
+		{"show columns from t", ast.ShowColumns, false, false},
+		{"show full columns from t", ast.ShowColumns, true, false},
+		{"show extended columns from t", ast.ShowColumns, false, true},
+		{"show extended full fields from t", ast.ShowColumns, true, true},
+		{"show index from t", ast.ShowIndex, false, false},
+		{"show extended indexes in t from test", ast.ShowIndex, false, true},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		show := stmt.(*ast.ShowStmt)
+		c.Assert(show.Tp, Equals, ast.ShowStmtType(t.tp))
+		c.Assert(show.Full, Equals, t.full, Commentf("source %s", t.src))
+		c.Assert(show.Extended, Equals, t.extended, Commentf("source %s", t.src))
+	}
+}
+
+func (s *testParserSuite) TestShowFilter(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SHOW TABLE STATUS WHERE Rows > 10 ORDER BY Name DESC LIMIT 5", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Where, NotNil)
+	c.Assert(show.OrderBy.Items, HasLen, 1)
+	c.Assert(show.OrderBy.Items[0].Desc, IsTrue)
+	c.Assert(show.Limit, NotNil)
+	c.Assert(show.Filterable(), IsTrue)
+
+	stmt, err = parser.ParseOneStmt("show index from t order by Key_name, Seq_in_index", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.Where, IsNil)
+	c.Assert(show.OrderBy.Items, HasLen, 2)
+
+	stmt, err = parser.ParseOneStmt("show create table t", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ShowStmt).Filterable(), IsFalse)
+
+	for _, src := range []string{
+		"show create table t where 1",
+		"show create database d order by 1",
+		"show grants order by 1",
+		"show tables order by 1 where 1",
+	} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestShowBuiltins(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SHOW BUILTINS", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowBuiltins))
+	c.Assert(show.Filterable(), IsFalse)
+
+	for _, src := range []string{"show builtins like 'a%'", "show builtins where 1", "show builtins from test"} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestShowCollationAndCharset(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SHOW COLLATION LIKE 'utf8%'", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowCollation))
+	c.Assert(show.Pattern, NotNil)
+	c.Assert(show.Pattern.Pattern.GetValue(), Equals, "utf8%")
+	c.Assert(show.Where, IsNil)
+
+	for _, src := range []string{"show character set where Charset = 'utf8'", "SHOW CHARSET WHERE Charset = 'utf8'"} {
+		stmt, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", src))
+		show = stmt.(*ast.ShowStmt)
+		c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowCharset))
+		c.Assert(show.Pattern, IsNil)
+		c.Assert(show.Where, NotNil)
+	}
+}
+
+func (s *testParserSuite) TestShowEnginesAndPlugins(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src string
+		tp  int
+	}{
+		{"SHOW ENGINES", ast.ShowEngines},
+		{"show plugins", ast.ShowPlugins},
+		{"show privileges", ast.ShowPrivileges},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		c.Assert(stmt.(*ast.ShowStmt).Tp, Equals, ast.ShowStmtType(t.tp))
+	}
+
+	// None of the targets takes a filter.
+	for _, src := range []string{"show engines like 'InnoDB'", "show engines where Engine = 'InnoDB'", "show plugins like 'a%'", "show privileges like 'Select'"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestShowProfile(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SHOW PROFILES", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ShowStmt).Tp, Equals, ast.ShowStmtType(ast.ShowProfiles))
+
+	stmt, err = parser.ParseOneStmt("show profile", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowProfile))
+	c.Assert(show.ProfileTypes, IsNil)
+	c.Assert(show.ProfileQueryID, IsNil)
+	c.Assert(show.Limit, IsNil)
+
+	stmt, err = parser.ParseOneStmt("show profile all, block io, context switches, cpu, ipc, memory, page faults, source, swaps for query 7 limit 2 offset 1", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.ProfileTypes, DeepEquals, []ast.ProfileType{ast.ProfileTypeAll, ast.ProfileTypeBlockIO, ast.ProfileTypeContextSwitches,
+		ast.ProfileTypeCPU, ast.ProfileTypeIPC, ast.ProfileTypeMemory, ast.ProfileTypePageFaults, ast.ProfileTypeSource, ast.ProfileTypeSwaps})
+	c.Assert(*show.ProfileQueryID, Equals, uint64(7))
+	c.Assert(show.Limit, NotNil)
+
+	for _, src := range []string{"show profiles like 'a%'", "show profile block", "show profile cpu memory", "show profile for query", "show profile for query 1 where a = 1"} {
+		_, err = parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestShowStats(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src string
+		tp  ast.ShowStmtType
+	}{
+		{"SHOW STATS_META", ast.ShowStatsMeta},
+		{"show stats_histograms", ast.ShowStatsHistograms},
+		{"show stats_buckets", ast.ShowStatsBuckets},
+		{"show stats_healthy", ast.ShowStatsHealthy},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		show := stmt.(*ast.ShowStmt)
+		c.Assert(show.Tp, Equals, t.tp)
+		c.Assert(show.Where, IsNil)
+
+		stmt, err = parser.ParseOneStmt(t.src+" where Db_name = 'test'", "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		show = stmt.(*ast.ShowStmt)
+		c.Assert(show.Tp, Equals, t.tp)
+		c.Assert(show.Where, NotNil)
+
+		stmt, err = parser.ParseOneStmt(t.src+" like 't%'", "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		c.Assert(stmt.(*ast.ShowStmt).Pattern, NotNil)
+	}
+
+	for _, src := range []string{"show stats", "show stats_meta from test", "show stats_buckets t"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestShowOpenTables(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SHOW OPEN TABLES FROM db1 LIKE 't%'", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowOpenTables))
+	c.Assert(show.DBName, Equals, "db1")
+	c.Assert(show.Pattern, NotNil)
+
+	stmt, err = parser.ParseOneStmt("show open tables", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowOpenTables))
+	c.Assert(show.DBName, Equals, "")
+	c.Assert(show.Pattern, IsNil)
+
+	// Like MySQL, OPEN TABLES doesn't take WHERE.
+	_, err = parser.ParseOneStmt("show open tables where `Table` = 't'", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestShowScope(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src         string
+		scope       ast.ShowScope
+		globalScope bool
+	}{
+		{"show variables", ast.ShowScopeUnspecified, false},
+		{"show session variables", ast.ShowScopeSession, false},
+		{"show global variables", ast.ShowScopeGlobal, true},
+		{"show status like 'a%'", ast.ShowScopeUnspecified, false},
+		{"show session status", ast.ShowScopeSession, false},
+		{"show global status", ast.ShowScopeGlobal, true},
+		{"show bindings", ast.ShowScopeUnspecified, false},
+		{"show session bindings like 'select%'", ast.ShowScopeSession, false},
+		{"show global bindings where Status = 'using'", ast.ShowScopeGlobal, true},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		show := stmt.(*ast.ShowStmt)
+		c.Assert(show.Scope, Equals, t.scope, Commentf("source %s", t.src))
+		c.Assert(show.GlobalScope, Equals, t.globalScope, Commentf("source %s", t.src))
+	}
+}
+
+func (s *testParserSuite) TestShowTableStatus(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SHOW TABLE STATUS FROM db1 LIKE 't%'", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowTableStatus))
+	c.Assert(show.DBName, Equals, "db1")
+	c.Assert(show.Pattern, NotNil)
+	c.Assert(show.Pattern.Pattern.GetValue(), Equals, "t%")
+
+	stmt, err = parser.ParseOneStmt("show table status in db1 where Name = 't'", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.DBName, Equals, "db1")
+	c.Assert(show.Pattern, IsNil)
+	c.Assert(show.Where, NotNil)
+
+	stmt, err = parser.ParseOneStmt("show table status", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ShowStmt).DBName, Equals, "")
+}
+
+func (s *testParserSuite) TestShowStatus(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("SHOW GLOBAL STATUS LIKE 'Threads%'", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowStatus))
+	c.Assert(show.GlobalScope, IsTrue)
+	c.Assert(show.Pattern, NotNil)
+	c.Assert(show.Pattern.Pattern.GetValue(), Equals, "Threads%")
+	c.Assert(show.Where, IsNil)
+
+	stmt, err = parser.ParseOneStmt("show status where Variable_name = 'Uptime'", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowStatus))
+	c.Assert(show.GlobalScope, IsFalse)
+	c.Assert(show.Pattern, IsNil)
+	c.Assert(show.Where, NotNil)
+}
+
+func (s *testParserSuite) TestBinlog(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	payload := "\nBxSFVw8JAAAA8QAAAPUAAAAAAAQANS41LjQ0LU1hcmlhREItbG9nAAAA+/=\n"
+	stmt, err := parser.ParseOneStmt("BINLOG '"+payload+"'", "", "")
+	c.Assert(err, IsNil)
+	// The payload is kept verbatim, it is not decoded by the parser.
+	c.Assert(stmt.(*ast.BinlogStmt).Str, Equals, payload)
+	_, err = parser.ParseOneStmt("binlog", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestShowProcessList(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("show processlist", "", "")
+	c.Assert(err, IsNil)
+	show := stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowProcessList))
+	c.Assert(show.Full, IsFalse)
+	stmt, err = parser.ParseOneStmt("show full processlist", "", "")
+	c.Assert(err, IsNil)
+	show = stmt.(*ast.ShowStmt)
+	c.Assert(show.Tp, Equals, ast.ShowStmtType(ast.ShowProcessList))
+	c.Assert(show.Full, IsTrue)
+	_, err = parser.ParseOneStmt("show full processlist where id = 1", "", "")
+	c.Assert(err, NotNil)
+	_, err = parser.ParseOneStmt("show processlist like 'a%'", "", "")
+	c.Assert(err, NotNil)
+}
+
+func (s *testParserSuite) TestSavepoint(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("savepoint `a``b`", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.SavepointStmt).Name, Equals, "a`b")
+	stmt, err = parser.ParseOneStmt("release savepoint sp", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ReleaseSavepointStmt).Name, Equals, "sp")
+	stmt, err = parser.ParseOneStmt("rollback to savepoint sp", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.RollbackStmt).SavepointName, Equals, "sp")
+	stmt, err = parser.ParseOneStmt("rollback", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.RollbackStmt).SavepointName, Equals, "")
+}
+
+func (s *testParserSuite) TestCompletionType(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	table := []struct {
+		src     string
+		chain   ast.CompletionType
+		release ast.CompletionType
+	}{
+		{"COMMIT", ast.CompletionTypeUnspecified, ast.CompletionTypeUnspecified},
+		{"COMMIT AND CHAIN", ast.CompletionTypeYes, ast.CompletionTypeUnspecified},
+		{"commit and no chain release", ast.CompletionTypeNo, ast.CompletionTypeYes},
+		{"commit no release", ast.CompletionTypeUnspecified, ast.CompletionTypeNo},
+		{"ROLLBACK AND NO CHAIN NO RELEASE", ast.CompletionTypeNo, ast.CompletionTypeNo},
+		{"rollback release", ast.CompletionTypeUnspecified, ast.CompletionTypeYes},
+	}
+	for _, t := range table {
+		stmt, err := parser.ParseOneStmt(t.src, "", "")
+		c.Assert(err, IsNil, Commentf("source %s", t.src))
+		switch x := stmt.(type) {
+		case *ast.CommitStmt:
+			c.Assert(x.Chain, Equals, t.chain, Commentf("source %s", t.src))
+			c.Assert(x.Release, Equals, t.release, Commentf("source %s", t.src))
+		case *ast.RollbackStmt:
+			c.Assert(x.Chain, Equals, t.chain, Commentf("source %s", t.src))
+			c.Assert(x.Release, Equals, t.release, Commentf("source %s", t.src))
+		default:
+			c.Fatalf("unexpected statement %T for %s", stmt, t.src)
+		}
+	}
+
+	// Like MySQL, a transaction can't be chained and released at the same time.
+	for _, src := range []string{"commit and chain release", "rollback and chain release", "rollback and no release", "commit chain"} {
+		_, err := parser.ParseOneStmt(src, "", "")
+		c.Assert(err, NotNil, Commentf("source %s", src))
+	}
+}
+
+func (s *testParserSuite) TestNamedParamMarker(c *C) {
+	defer testleak.AfterTest(c)()
+	table := []testCase{
+		{"select :a, :b from t where c = :a limit :n", true},
+		{"select * from t where a = :a and b = ?", true},
+		{"set @a := 1", true},
+		{"select : a", false},
+	}
+	s.RunTest(c, table)
+
+	parser := New()
+	stmt, err := parser.ParseOneStmt("select ?, :user_id", "", "")
+	c.Assert(err, IsNil)
+	fields := stmt.(*ast.SelectStmt).Fields.Fields
+	c.Assert(fields[0].Expr.(*ast.ParamMarkerExpr).Name, Equals, "")
+	c.Assert(fields[1].Expr.(*ast.ParamMarkerExpr).Name, Equals, "user_id")
+}
+
+func (s *testParserSuite) TestLoadData(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("load data infile '/tmp/t.csv' into table t", "", "")
+	c.Assert(err, IsNil)
+	ld := stmt.(*ast.LoadDataStmt)
+	c.Assert(ld.IsLocal, IsFalse)
+	c.Assert(ld.Path, Equals, "/tmp/t.csv")
+	c.Assert(ld.Table.Name.O, Equals, "t")
+	c.Assert(ld.Columns, HasLen, 0)
+	c.Assert(ld.FieldsInfo.Terminated, Equals, "\t")
+	c.Assert(ld.LinesInfo.Starting, Equals, "")
+	c.Assert(ld.LinesInfo.Terminated, Equals, "\n")
+
+	stmt, err = parser.ParseOneStmt("load data local infile '/tmp/t.csv' into table t fields terminated by ',' lines starting by 'xx' (a, b)", "", "")
+	c.Assert(err, IsNil)
+	ld = stmt.(*ast.LoadDataStmt)
+	c.Assert(ld.IsLocal, IsTrue)
+	c.Assert(ld.FieldsInfo.Terminated, Equals, ",")
+	c.Assert(ld.LinesInfo.Starting, Equals, "xx")
+	c.Assert(ld.LinesInfo.Terminated, Equals, "\n")
+	c.Assert(ld.Columns, HasLen, 2)
+	c.Assert(ld.Columns[0].Name.O, Equals, "a")
+	c.Assert(ld.Columns[1].Name.O, Equals, "b")
+}
+
+func (s *testParserSuite) TestAdmin(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("admin check index test.t idx", "", "")
+	c.Assert(err, IsNil)
+	admin := stmt.(*ast.AdminStmt)
+	c.Assert(admin.Tp, Equals, ast.AdminStmtType(ast.AdminCheckIndex))
+	c.Assert(admin.Tables, HasLen, 1)
+	c.Assert(admin.Tables[0].Schema.O, Equals, "test")
+	c.Assert(admin.Tables[0].Name.O, Equals, "t")
+	c.Assert(admin.Index, Equals, "idx")
+
+	stmt, err = parser.ParseOneStmt("admin cancel ddl jobs 3, 5", "", "")
+	c.Assert(err, IsNil)
+	admin = stmt.(*ast.AdminStmt)
+	c.Assert(admin.Tp, Equals, ast.AdminStmtType(ast.AdminCancelDDLJobs))
+	c.Assert(admin.JobIDs, DeepEquals, []int64{3, 5})
+}
+
+func (s *testParserSuite) TestPrepare(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("prepare s from 'select ?'", "", "")
+	c.Assert(err, IsNil)
+	prepare := stmt.(*ast.PrepareStmt)
+	c.Assert(prepare.Name, Equals, "s")
+	c.Assert(prepare.SQLText, Equals, "select ?")
+	c.Assert(prepare.SQLVar, IsNil)
+
+	stmt, err = parser.ParseOneStmt("prepare s from @sql", "", "")
+	c.Assert(err, IsNil)
+	prepare = stmt.(*ast.PrepareStmt)
+	c.Assert(prepare.SQLText, Equals, "")
+	c.Assert(prepare.SQLVar, NotNil)
+	c.Assert(prepare.SQLVar.Name, Equals, "sql")
+	c.Assert(prepare.SQLVar.IsSystem, IsFalse)
+}
+
+func (s *testParserSuite) TestDeallocate(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("deallocate prepare s", "", "")
+	c.Assert(err, IsNil)
+	dealloc := stmt.(*ast.DeallocateStmt)
+	c.Assert(dealloc.Name, Equals, "s")
+	c.Assert(dealloc.UsingID, IsFalse)
+}
+
+func (s *testParserSuite) TestSetPwd(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("set password = 'pwd'", "", "")
+	c.Assert(err, IsNil)
+	setPwd := stmt.(*ast.SetPwdStmt)
+	c.Assert(setPwd.User, IsNil)
+	c.Assert(setPwd.Password, Equals, "pwd")
+
+	stmt, err = parser.ParseOneStmt("set password for 'u'@'%' = password('pwd')", "", "")
+	c.Assert(err, IsNil)
+	setPwd = stmt.(*ast.SetPwdStmt)
+	c.Assert(setPwd.User, DeepEquals, &ast.UserIdentity{Username: "u", Hostname: "%"})
+	c.Assert(setPwd.Password, Equals, "pwd")
+
+	stmt, err = parser.ParseOneStmt("set password for current_user = 'pwd'", "", "")
+	c.Assert(err, IsNil)
+	setPwd = stmt.(*ast.SetPwdStmt)
+	c.Assert(setPwd.User.CurrentUser, IsTrue)
+}
+
+func (s *testParserSuite) TestSetDefault(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("set @@global.autocommit = default, sql_mode = 'default', @a = default(b)", "", "")
+	c.Assert(err, IsNil)
+	vars := stmt.(*ast.SetStmt).Variables
+	c.Assert(vars, HasLen, 3)
+	c.Assert(vars[0].IsDefault, IsTrue)
+	c.Assert(vars[0].Value, IsNil)
+	c.Assert(vars[1].IsDefault, IsFalse)
+	c.Assert(vars[1].Value.GetDatum().GetString(), Equals, "default")
+	c.Assert(vars[2].IsDefault, IsFalse)
+	c.Assert(vars[2].Value, FitsTypeOf, &ast.DefaultExpr{})
+}
+
+func (s *testParserSuite) TestSetNames(c *C) {
+	defer testleak.AfterTest(c)()
+	parser := New()
+	stmt, err := parser.ParseOneStmt("set names utf8mb4 collate utf8mb4_bin", "", "")
+	c.Assert(err, IsNil)
+	setNames := stmt.(*ast.SetNamesStmt)
+	c.Assert(setNames.Charset, Equals, "utf8mb4")
+	c.Assert(setNames.Collate, Equals, "utf8mb4_bin")
+	c.Assert(setNames.IsDefault, IsFalse)
+
+	stmt, err = parser.ParseOneStmt("set character set gbk", "", "")
+	c.Assert(err, IsNil)
+	setNames = stmt.(*ast.SetNamesStmt)
+	c.Assert(setNames.Charset, Equals, "gbk")
+	c.Assert(setNames.Collate, Equals, "")
+
+	stmt, err = parser.ParseOneStmt("set names default", "", "")
+	c.Assert(err, IsNil)
+	setNames = stmt.(*ast.SetNamesStmt)
+	c.Assert(setNames.Charset, Equals, "")
+	c.Assert(setNames.IsDefault, IsTrue)
+
+	// SET NAMES mixed with other assignments is still a SetStmt.
+	stmt, err = parser.ParseOneStmt("set names utf8, @a = 1", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt, FitsTypeOf, &ast.SetStmt{})
+}
+
+func (s *testParserSuite) TestTrace(c *C) {
+	defer testleak.AfterTest(c)()
+	table := []testCase{
+		{"trace select c1 from t1", true},
+		{"trace insert into t values (1)", true},
+		{"trace create table t (a int)", true},
+		{"trace format = 'json' select 1", true},
+		{"trace format = row update t set a = 1", true},
+		{"trace format = 'dot' select 1", false},
+		{"trace", false},
+	}
+	s.RunTest(c, table)
+
+	parser := New()
+	stmt, err := parser.ParseOneStmt("trace select 1", "", "")
+	c.Assert(err, IsNil)
+	trace := stmt.(*ast.TraceStmt)
+	c.Assert(trace.Format, Equals, ast.TraceFormatRow)
+	c.Assert(trace.Stmt, FitsTypeOf, &ast.SelectStmt{})
+	stmt, err = parser.ParseOneStmt("trace format='json' select 1", "", "")
+	c.Assert(err, IsNil)
+	trace = stmt.(*ast.TraceStmt)
+	c.Assert(trace.Format, Equals, ast.TraceFormatJSON)
+	c.Assert(trace.Stmt, FitsTypeOf, &ast.SelectStmt{})
 }
 
 func (s *testParserSuite) TestTimestampDiffUnit(c *C) {
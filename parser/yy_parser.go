@@ -95,6 +95,20 @@ func (parser *Parser) Parse(sql, charset, collation string) ([]ast.StmtNode, err
 	if len(l.Errors()) != 0 {
 		return nil, errors.Trace(l.Errors()[0])
 	}
+	// The empty statement after the last ';' is not a statement of its own, neither are
+	// the other trailing empty ones, so "SELECT 1;;" has one statement. A script of
+	// semicolons only, like ";" or ";;", has one EmptyStmt.
+	if n := len(parser.result); n > 0 {
+		if _, ok := parser.result[n-1].(*ast.EmptyStmt); ok {
+			parser.result = parser.result[:n-1]
+		}
+	}
+	for n := len(parser.result); n > 1; n-- {
+		if _, ok := parser.result[n-1].(*ast.EmptyStmt); !ok {
+			break
+		}
+		parser.result = parser.result[:n-1]
+	}
 	for _, stmt := range parser.result {
 		ast.SetFlag(stmt)
 	}
@@ -115,6 +129,36 @@ func (parser *Parser) ParseOneStmt(sql, charset, collation string) (ast.StmtNode
 	return stmts[0], nil
 }
 
+// setDefaultIntent marks the assignment as IsDefault and clears its Value
+// if the value is the DEFAULT keyword.
+func setDefaultIntent(assign *ast.VariableAssignment) *ast.VariableAssignment {
+	if dft, ok := assign.Value.(*ast.DefaultExpr); ok && dft.Name == nil {
+		assign.Value = nil
+		assign.IsDefault = true
+	}
+	return assign
+}
+
+// isValidExplainFormat checks whether format is one of ast.ExplainFormats.
+func isValidExplainFormat(format string) bool {
+	for _, f := range ast.ExplainFormats {
+		if format == f {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidTraceFormat checks whether format is one of ast.TraceFormats.
+func isValidTraceFormat(format string) bool {
+	for _, f := range ast.TraceFormats {
+		if format == f {
+			return true
+		}
+	}
+	return false
+}
+
 // The select statement is not at the end of the whole statement, if the last
 // field text was set from its offset to the end of the src string, update
 // the last field text.
@@ -125,6 +169,19 @@ func (parser *Parser) setLastSelectFieldText(st *ast.SelectStmt, lastEnd int) {
 	}
 }
 
+// setInnerStmtText sets the text and position of a statement nested in EXPLAIN or TRACE.
+// The text spans from the start of the statement to the lookahead token, which
+// ends the outer statement.
+func (parser *Parser) setInnerStmtText(stmt ast.StmtNode, start *yySymType) {
+	startOffset := parser.startOffset(start)
+	endOffset := parser.endOffset(&parser.yylval)
+	if endOffset < startOffset {
+		endOffset = startOffset
+	}
+	stmt.SetText(parser.src[startOffset:endOffset])
+	stmt.SetOriginTextPosition(startOffset)
+}
+
 func (parser *Parser) startOffset(v *yySymType) int {
 	return v.offset
 }
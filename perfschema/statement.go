@@ -310,7 +310,9 @@ func (ps *perfSchema) registerStatements() {
 	ps.RegisterStatement("sql", "explain", (*ast.ExplainStmt)(nil))
 	ps.RegisterStatement("sql", "grant", (*ast.GrantStmt)(nil))
 	ps.RegisterStatement("sql", "insert", (*ast.InsertStmt)(nil))
+	ps.RegisterStatement("sql", "lock_tables", (*ast.LockTablesStmt)(nil))
 	ps.RegisterStatement("sql", "prepare", (*ast.PrepareStmt)(nil))
+	ps.RegisterStatement("sql", "revoke", (*ast.RevokeStmt)(nil))
 	ps.RegisterStatement("sql", "rollback", (*ast.RollbackStmt)(nil))
 	ps.RegisterStatement("sql", "select", (*ast.SelectStmt)(nil))
 	ps.RegisterStatement("sql", "set", (*ast.SetStmt)(nil))
@@ -318,6 +320,7 @@ func (ps *perfSchema) registerStatements() {
 	ps.RegisterStatement("sql", "show", (*ast.ShowStmt)(nil))
 	ps.RegisterStatement("sql", "truncate", (*ast.TruncateTableStmt)(nil))
 	ps.RegisterStatement("sql", "union", (*ast.UnionStmt)(nil))
+	ps.RegisterStatement("sql", "unlock_tables", (*ast.UnlockTablesStmt)(nil))
 	ps.RegisterStatement("sql", "update", (*ast.UpdateStmt)(nil))
 	ps.RegisterStatement("sql", "use", (*ast.UseStmt)(nil))
 	ps.RegisterStatement("sql", "analyze", (*ast.AnalyzeTableStmt)(nil))
@@ -1112,6 +1112,19 @@ func (p *Analyze) convert2PhysicalPlan(prop *requiredProperty) (*physicalPlanInf
 	return info, nil
 }
 
+// convert2PhysicalPlan implements the LogicalPlan convert2PhysicalPlan interface.
+// The rows of Show are in no particular order, so a required order can't be matched without a sort.
+func (p *Show) convert2PhysicalPlan(prop *requiredProperty) (*physicalPlanInfo, error) {
+	info, err := p.baseLogicalPlan.convert2PhysicalPlan(prop)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(prop.props) > 0 {
+		info = &physicalPlanInfo{p: info.p, cost: math.MaxFloat64}
+	}
+	return info, nil
+}
+
 // addCachePlan will add a Cache plan above the plan whose father's IsCorrelated() is true but its own IsCorrelated() is false.
 func addCachePlan(p PhysicalPlan, allocator *idAllocator) []*expression.CorrelatedColumn {
 	if len(p.Children()) == 0 {
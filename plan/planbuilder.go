@@ -36,6 +36,7 @@ var (
 	ErrUnknownColumn        = terror.ClassOptimizerPlan.New(CodeUnknownColumn, "Unknown column '%s' in '%s'")
 	ErrWrongArguments       = terror.ClassOptimizerPlan.New(CodeWrongArguments, "Incorrect arguments to EXECUTE")
 	ErrAmbiguous            = terror.ClassOptimizerPlan.New(CodeAmbiguous, "Column '%s' in field list is ambiguous")
+	ErrKeyDoesNotExist      = terror.ClassOptimizerPlan.New(CodeKeyDoesNotExist, "Key '%s' doesn't exist in table '%s'")
 )
 
 // Error codes.
@@ -44,14 +45,16 @@ const (
 	SystemInternalError terror.ErrCode = 2
 	CodeAmbiguous       terror.ErrCode = 1052
 	CodeUnknownColumn   terror.ErrCode = 1054
+	CodeKeyDoesNotExist terror.ErrCode = 1176
 	CodeWrongArguments  terror.ErrCode = 1210
 )
 
 func init() {
 	tableMySQLErrCodes := map[terror.ErrCode]uint16{
-		CodeUnknownColumn:  mysql.ErrBadField,
-		CodeAmbiguous:      mysql.ErrNonUniq,
-		CodeWrongArguments: mysql.ErrWrongArguments,
+		CodeUnknownColumn:   mysql.ErrBadField,
+		CodeAmbiguous:       mysql.ErrNonUniq,
+		CodeWrongArguments:  mysql.ErrWrongArguments,
+		CodeKeyDoesNotExist: mysql.ErrKeyDoesNotExits,
 	}
 	terror.ErrClassToMySQLCodes[terror.ClassOptimizerPlan] = tableMySQLErrCodes
 }
@@ -87,7 +90,7 @@ func (b *planBuilder) build(node ast.Node) Plan {
 	case *ast.AdminStmt:
 		return b.buildAdmin(x)
 	case *ast.DeallocateStmt:
-		return &Deallocate{Name: x.Name}
+		return &Deallocate{Name: x.Name, ExecID: x.ID, UsingID: x.UsingID}
 	case *ast.DeleteStmt:
 		return b.buildDelete(x)
 	case *ast.ExecuteStmt:
@@ -112,21 +115,84 @@ func (b *planBuilder) build(node ast.Node) Plan {
 		return b.buildDo(x)
 	case *ast.SetStmt:
 		return b.buildSet(x)
+	case *ast.SetNamesStmt:
+		return b.buildSetNames(x)
 	case *ast.AnalyzeTableStmt:
 		return b.buildAnalyze(x)
+	case *ast.CommitStmt:
+		if x.Chain == ast.CompletionTypeYes || x.Release == ast.CompletionTypeYes {
+			b.err = ErrUnsupportedType.Gen("COMMIT AND CHAIN and COMMIT RELEASE are not supported")
+			return nil
+		}
+		return b.buildSimple(x)
+	case *ast.RollbackStmt:
+		if x.Chain == ast.CompletionTypeYes || x.Release == ast.CompletionTypeYes {
+			b.err = ErrUnsupportedType.Gen("ROLLBACK AND CHAIN and ROLLBACK RELEASE are not supported")
+			return nil
+		}
+		return b.buildSimple(x)
+	case *ast.OptimizeTableStmt, *ast.CheckTableStmt:
+		return b.buildTableMaintenance(node.(ast.StmtNode))
 	case *ast.BinlogStmt, *ast.FlushStmt, *ast.UseStmt,
-		*ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.CreateUserStmt, *ast.SetPwdStmt,
-		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt:
+		*ast.BeginStmt, *ast.CreateUserStmt, *ast.SetPwdStmt,
+		*ast.GrantStmt, *ast.RevokeStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.SetTransactionStmt,
+		*ast.EmptyStmt:
 		return b.buildSimple(node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(x)
+	case *ast.LockTablesStmt, *ast.UnlockTablesStmt:
+		b.err = ErrUnsupportedType.Gen("LOCK TABLES and UNLOCK TABLES are not supported")
+		return nil
+	case *ast.SplitRegionStmt:
+		b.err = ErrUnsupportedType.Gen("SPLIT TABLE is not supported")
+		return nil
+	case *ast.SetConfigStmt:
+		b.err = ErrUnsupportedType.Gen("SET CONFIG is not supported")
+		return nil
+	case *ast.CalibrateResourceStmt:
+		b.err = ErrUnsupportedType.Gen("CALIBRATE RESOURCE is not supported")
+		return nil
+	case *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		b.err = ErrUnsupportedType.Gen("QUERY WATCH is not supported")
+		return nil
+	case *ast.ImportIntoStmt:
+		b.err = ErrUnsupportedType.Gen("IMPORT INTO is not supported")
+		return nil
+	case *ast.NonTransactionalDMLStmt:
+		b.err = ErrUnsupportedType.Gen("Non-transactional DML is not supported")
+		return nil
+	case *ast.PlanReplayerStmt:
+		b.err = ErrUnsupportedType.Gen("PLAN REPLAYER is not supported")
+		return nil
+	case *ast.AlterInstanceStmt:
+		b.err = ErrUnsupportedType.Gen("ALTER INSTANCE is not supported")
+		return nil
+	case *ast.LoadStatsStmt:
+		b.err = ErrUnsupportedType.Gen("LOAD STATS is not supported")
+		return nil
+	case *ast.DropStatsStmt:
+		b.err = ErrUnsupportedType.Gen("DROP STATS is not supported")
+		return nil
+	case *ast.BRIEStmt:
+		b.err = ErrUnsupportedType.Gen("%s is not supported", x.Kind)
+		return nil
+	case *ast.RepairTableStmt:
+		b.err = ErrUnsupportedType.Gen("ADMIN REPAIR TABLE is not supported")
+		return nil
+	case *ast.CreateBindingStmt, *ast.DropBindingStmt:
+		b.err = ErrUnsupportedType.Gen("SQL binding is not supported")
+		return nil
+	case *ast.CreateRoleStmt, *ast.DropRoleStmt, *ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt,
+		*ast.SetDefaultRoleStmt:
+		b.err = ErrUnsupportedType.Gen("Roles are not supported")
+		return nil
 	}
 	b.err = ErrUnsupportedType.Gen("Unsupported type %T", node)
 	return nil
 }
 
 func (b *planBuilder) buildExecute(v *ast.ExecuteStmt) Plan {
-	vars := make([]expression.Expression, 0, len(v.UsingVars))
+	vars := make([]expression.Expression, 0, v.ArgsCount())
 	for _, expr := range v.UsingVars {
 		newExpr, _, err := b.rewrite(expr, nil, nil, true)
 		if err != nil {
@@ -175,13 +241,13 @@ func (b *planBuilder) buildSet(v *ast.SetStmt) Plan {
 			IsGlobal: vars.IsGlobal,
 			IsSystem: vars.IsSystem,
 		}
-		if _, ok := vars.Value.(*ast.DefaultExpr); !ok {
+		if vars.IsDefault {
+			assign.IsDefault = true
+		} else {
 			assign.Expr, _, b.err = b.rewrite(vars.Value, nil, nil, true)
 			if b.err != nil {
 				return nil
 			}
-		} else {
-			assign.IsDefault = true
 		}
 		if vars.ExtendValue != nil {
 			assign.ExtendValue = &expression.Constant{
@@ -196,6 +262,32 @@ func (b *planBuilder) buildSet(v *ast.SetStmt) Plan {
 	return p
 }
 
+func (b *planBuilder) buildSetNames(v *ast.SetNamesStmt) Plan {
+	p := &Set{}
+	p.tp = St
+	p.allocator = b.allocator
+	assign := &expression.VarAssignment{
+		Name:      ast.SetNames,
+		IsDefault: v.IsDefault,
+	}
+	if !v.IsDefault {
+		assign.Expr = &expression.Constant{
+			Value:   types.NewStringDatum(v.Charset),
+			RetType: types.NewFieldType(mysql.TypeVarString),
+		}
+	}
+	if v.Collate != "" {
+		assign.ExtendValue = &expression.Constant{
+			Value:   types.NewStringDatum(v.Collate),
+			RetType: types.NewFieldType(mysql.TypeVarString),
+		}
+	}
+	p.VarAssigns = append(p.VarAssigns, assign)
+	p.initIDAndContext(b.ctx)
+	p.SetSchema(expression.NewSchema())
+	return p
+}
+
 // Detect aggregate function or groupby clause.
 func (b *planBuilder) detectSelectAgg(sel *ast.SelectStmt) bool {
 	if sel.GroupBy != nil {
@@ -309,6 +401,7 @@ func (b *planBuilder) buildSelectLock(src Plan, lock ast.SelectLockType) *Select
 func (b *planBuilder) buildPrepare(x *ast.PrepareStmt) Plan {
 	p := &Prepare{
 		Name: x.Name,
+		Stmt: x,
 	}
 	if x.SQLVar != nil {
 		p.SQLText, _ = x.SQLVar.GetValue().(string)
@@ -329,6 +422,12 @@ func (b *planBuilder) buildAdmin(as *ast.AdminStmt) Plan {
 	case ast.AdminShowDDL:
 		p = &ShowDDL{}
 		p.SetSchema(buildShowDDLFields())
+	case ast.AdminShowDDLJobs:
+		b.err = ErrUnsupportedType.Gen("ADMIN SHOW DDL JOBS is not supported")
+	case ast.AdminCancelDDLJobs:
+		b.err = ErrUnsupportedType.Gen("ADMIN CANCEL DDL JOBS is not supported")
+	case ast.AdminCheckIndex:
+		b.err = ErrUnsupportedType.Gen("ADMIN CHECK INDEX is not supported")
 	default:
 		b.err = ErrUnsupportedType.Gen("Unsupported type %T", as)
 	}
@@ -383,6 +482,14 @@ func (b *planBuilder) buildAnalyze(as *ast.AnalyzeTableStmt) LogicalPlan {
 		PkOffset:        -1,
 	}
 	for _, tbl := range as.TableNames {
+		// The statistics of a table are built as a whole, so the INDEX form
+		// only checks the index names and still analyzes the entire table.
+		for _, idxName := range as.IndexNames {
+			if findIndexByName(tbl.TableInfo.Indices, idxName) == nil {
+				b.err = ErrKeyDoesNotExist.GenByArgs(idxName.O, tbl.Name.O)
+				return nil
+			}
+		}
 		idxOffsets, colOffsets, pkOffset := getColumnOffsets(tbl)
 		result := &Analyze{
 			baseLogicalPlan: newBaseLogicalPlan(Aly, b.allocator),
@@ -390,6 +497,7 @@ func (b *planBuilder) buildAnalyze(as *ast.AnalyzeTableStmt) LogicalPlan {
 			IdxOffsets:      idxOffsets,
 			ColOffsets:      colOffsets,
 			PkOffset:        pkOffset,
+			NumBuckets:      as.MaxNumBuckets,
 		}
 		result.self = result
 		result.initIDAndContext(b.ctx)
@@ -459,6 +567,18 @@ func splitWhere(where ast.ExprNode) []ast.ExprNode {
 }
 
 func (b *planBuilder) buildShow(show *ast.ShowStmt) Plan {
+	if len(show.Roles) > 0 {
+		b.err = ErrUnsupportedType.Gen("SHOW GRANTS ... USING roles is not supported")
+		return nil
+	}
+	switch show.Tp {
+	case ast.ShowStatsMeta, ast.ShowStatsHistograms, ast.ShowStatsBuckets, ast.ShowStatsHealthy:
+		b.err = ErrUnsupportedType.Gen("Showing statistics is not supported")
+		return nil
+	case ast.ShowBuiltins:
+		b.err = ErrUnsupportedType.Gen("SHOW BUILTINS is not supported")
+		return nil
+	}
 	var resultPlan Plan
 	p := &Show{
 		Tp:              show.Tp,
@@ -468,6 +588,7 @@ func (b *planBuilder) buildShow(show *ast.ShowStmt) Plan {
 		Flag:            show.Flag,
 		Full:            show.Full,
 		User:            show.User,
+		CountOnly:       show.CountOnly,
 		baseLogicalPlan: newBaseLogicalPlan("Show", b.allocator),
 	}
 	resultPlan = p
@@ -517,6 +638,15 @@ func (b *planBuilder) buildShow(show *ast.ShowStmt) Plan {
 		sel.SetSchema(p.Schema())
 		resultPlan = sel
 	}
+	if show.OrderBy != nil {
+		resultPlan = b.buildSort(resultPlan.(LogicalPlan), show.OrderBy.Items, nil)
+		if b.err != nil {
+			return nil
+		}
+	}
+	if show.Limit != nil {
+		resultPlan = b.buildLimit(resultPlan.(LogicalPlan), show.Limit)
+	}
 	return resultPlan
 }
 
@@ -526,6 +656,14 @@ func (b *planBuilder) buildSimple(node ast.StmtNode) Plan {
 	return p
 }
 
+// buildTableMaintenance builds the plan of OPTIMIZE TABLE and CHECK TABLE,
+// which return a Table, Op, Msg_type and Msg_text row for each table like MySQL.
+func (b *planBuilder) buildTableMaintenance(node ast.StmtNode) Plan {
+	p := &Simple{Statement: node}
+	p.SetSchema(buildSchema([]string{"Table", "Op", "Msg_type", "Msg_text"}, nil))
+	return p
+}
+
 func (b *planBuilder) getDefaultValue(col *table.Column) (*expression.Constant, error) {
 	if value, ok, err := table.GetColDefaultValue(b.ctx, col.ToInfo()); ok {
 		if err != nil {
@@ -668,6 +806,7 @@ func (b *planBuilder) buildLoadData(ld *ast.LoadDataStmt) Plan {
 		IsLocal:    ld.IsLocal,
 		Path:       ld.Path,
 		Table:      ld.Table,
+		Columns:    ld.Columns,
 		FieldsInfo: ld.FieldsInfo,
 		LinesInfo:  ld.LinesInfo,
 	}
@@ -719,6 +858,12 @@ func (b *planBuilder) buildDDL(node ast.DDLNode) Plan {
 				table:     table.Name.L,
 			})
 		}
+	case *ast.FlashBackTableStmt:
+		b.err = ErrUnsupportedType.Gen("FLASHBACK TABLE is not supported")
+		return nil
+	case *ast.RecoverTableStmt:
+		b.err = ErrUnsupportedType.Gen("RECOVER TABLE is not supported")
+		return nil
 	case *ast.TruncateTableStmt:
 		b.visitInfo = append(b.visitInfo, visitInfo{
 			privilege: mysql.DeletePriv,
@@ -726,16 +871,23 @@ func (b *planBuilder) buildDDL(node ast.DDLNode) Plan {
 			table:     v.Table.Name.L,
 		})
 	case *ast.RenameTableStmt:
-		b.visitInfo = append(b.visitInfo, visitInfo{
-			privilege: mysql.AlterPriv,
-			db:        v.OldTable.Schema.L,
-			table:     v.OldTable.Name.L,
-		})
-		b.visitInfo = append(b.visitInfo, visitInfo{
-			privilege: mysql.AlterPriv,
-			db:        v.NewTable.Schema.L,
-			table:     v.NewTable.Name.L,
-		})
+		if len(v.TableToTables) > 1 {
+			// The tables can't be renamed atomically yet.
+			b.err = ErrUnsupportedType.Gen("RENAME TABLE with multiple tables is not supported")
+			return nil
+		}
+		for _, t := range v.TableToTables {
+			b.visitInfo = append(b.visitInfo, visitInfo{
+				privilege: mysql.AlterPriv,
+				db:        t.OldTable.Schema.L,
+				table:     t.OldTable.Name.L,
+			})
+			b.visitInfo = append(b.visitInfo, visitInfo{
+				privilege: mysql.AlterPriv,
+				db:        t.NewTable.Schema.L,
+				table:     t.NewTable.Name.L,
+			})
+		}
 	}
 
 	p := &DDL{Statement: node}
@@ -846,6 +998,70 @@ func buildSchema(names []string, ftypes []byte) *expression.Schema {
 	return schema
 }
 
+// showGrantsUser returns the user@host name in the "Grants for user@host" column title of
+// SHOW GRANTS. It is empty if no user is given or the user is CURRENT_USER.
+func showGrantsUser(user *ast.UserIdentity) string {
+	if user == nil || user.CurrentUser {
+		return ""
+	}
+	return user.Username + "@" + user.Hostname
+}
+
+// profileColumns are the columns SHOW PROFILE shows for each profile type, in the order they are shown.
+// MEMORY is accepted but has no columns, like in MySQL.
+var profileColumns = []struct {
+	tp     ast.ProfileType
+	names  []string
+	ftypes []byte
+}{
+	{ast.ProfileTypeCPU, []string{"CPU_user", "CPU_system"}, []byte{mysql.TypeNewDecimal, mysql.TypeNewDecimal}},
+	{ast.ProfileTypeContextSwitches, []string{"Context_voluntary", "Context_involuntary"}, []byte{mysql.TypeLonglong, mysql.TypeLonglong}},
+	{ast.ProfileTypeBlockIO, []string{"Block_ops_in", "Block_ops_out"}, []byte{mysql.TypeLonglong, mysql.TypeLonglong}},
+	{ast.ProfileTypeIPC, []string{"Messages_sent", "Messages_received"}, []byte{mysql.TypeLonglong, mysql.TypeLonglong}},
+	{ast.ProfileTypePageFaults, []string{"Page_faults_major", "Page_faults_minor"}, []byte{mysql.TypeLonglong, mysql.TypeLonglong}},
+	{ast.ProfileTypeSwaps, []string{"Swaps"}, []byte{mysql.TypeLonglong}},
+	{ast.ProfileTypeSource, []string{"Source_function", "Source_file", "Source_line"}, []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong}},
+}
+
+// showProfileColumns returns the column names and types of SHOW PROFILE with the profile types.
+// The columns are in a fixed order whatever order the types are given in.
+func showProfileColumns(types []ast.ProfileType) ([]string, []byte) {
+	names := []string{"Status", "Duration"}
+	ftypes := []byte{mysql.TypeVarchar, mysql.TypeNewDecimal}
+	for _, col := range profileColumns {
+		for _, tp := range types {
+			if tp == col.tp || tp == ast.ProfileTypeAll {
+				names = append(names, col.names...)
+				ftypes = append(ftypes, col.ftypes...)
+				break
+			}
+		}
+	}
+	return names, ftypes
+}
+
+// showStatsColumns returns the column names and types of SHOW STATS_META, STATS_HISTOGRAMS,
+// STATS_BUCKETS and STATS_HEALTHY.
+func showStatsColumns(tp ast.ShowStmtType) (names []string, ftypes []byte) {
+	switch tp {
+	case ast.ShowStatsMeta:
+		names = []string{"Db_name", "Table_name", "Update_time", "Modify_count", "Row_count"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDatetime, mysql.TypeLonglong, mysql.TypeLonglong}
+	case ast.ShowStatsHistograms:
+		names = []string{"Db_name", "Table_name", "Column_name", "Is_index", "Update_time", "Distinct_count", "Null_count"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeTiny, mysql.TypeDatetime,
+			mysql.TypeLonglong, mysql.TypeLonglong}
+	case ast.ShowStatsBuckets:
+		names = []string{"Db_name", "Table_name", "Column_name", "Is_index", "Bucket_id", "Count", "Repeats", "Lower_Bound", "Upper_Bound"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeTiny, mysql.TypeLonglong,
+			mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar}
+	case ast.ShowStatsHealthy:
+		names = []string{"Db_name", "Table_name", "Healthy"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong}
+	}
+	return
+}
+
 // buildShowSchema builds column info for ShowStmt including column name and type.
 func buildShowSchema(s *ast.ShowStmt) (schema *expression.Schema) {
 	var names []string
@@ -853,6 +1069,24 @@ func buildShowSchema(s *ast.ShowStmt) (schema *expression.Schema) {
 	switch s.Tp {
 	case ast.ShowEngines:
 		names = []string{"Engine", "Support", "Comment", "Transactions", "XA", "Savepoints"}
+	case ast.ShowPlugins:
+		names = []string{"Name", "Status", "Type", "Library", "License"}
+	case ast.ShowPrivileges:
+		names = []string{"Privilege", "Context", "Comment"}
+	case ast.ShowProfiles:
+		names = []string{"Query_ID", "Duration", "Query"}
+		ftypes = []byte{mysql.TypeLonglong, mysql.TypeNewDecimal, mysql.TypeVarchar}
+	case ast.ShowProfile:
+		names, ftypes = showProfileColumns(s.ProfileTypes)
+	case ast.ShowStatsMeta, ast.ShowStatsHistograms, ast.ShowStatsBuckets, ast.ShowStatsHealthy:
+		names, ftypes = showStatsColumns(s.Tp)
+	case ast.ShowBindings:
+		names = []string{"Original_sql", "Bind_sql", "Default_db", "Status", "Create_time", "Update_time", "Charset", "Collation"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar,
+			mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeVarchar, mysql.TypeVarchar}
+	case ast.ShowOpenTables:
+		names = []string{"Database", "Table", "In_use", "Name_locked"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeLonglong}
 	case ast.ShowDatabases:
 		names = []string{"Database"}
 		schema = buildSchema(names, ftypes)
@@ -874,7 +1108,16 @@ func buildShowSchema(s *ast.ShowStmt) (schema *expression.Schema) {
 			mysql.TypeVarchar, mysql.TypeVarchar}
 	case ast.ShowColumns:
 		names = table.ColDescFieldNames(s.Full)
-	case ast.ShowWarnings:
+	case ast.ShowWarnings, ast.ShowErrors:
+		if s.CountOnly {
+			if s.Tp == ast.ShowWarnings {
+				names = []string{"@@session.warning_count"}
+			} else {
+				names = []string{"@@session.error_count"}
+			}
+			ftypes = []byte{mysql.TypeLonglong}
+			break
+		}
 		names = []string{"Level", "Code", "Message"}
 		ftypes = []byte{mysql.TypeVarchar, mysql.TypeLong, mysql.TypeVarchar}
 	case ast.ShowCharset:
@@ -891,7 +1134,7 @@ func buildShowSchema(s *ast.ShowStmt) (schema *expression.Schema) {
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
 	case ast.ShowGrants:
-		names = []string{fmt.Sprintf("Grants for %s", s.User)}
+		names = []string{fmt.Sprintf("Grants for %s", showGrantsUser(s.User))}
 	case ast.ShowIndex:
 		names = []string{"Table", "Non_unique", "Key_name", "Seq_in_index",
 			"Column_name", "Collation", "Cardinality", "Sub_part", "Packed",
@@ -16,6 +16,8 @@ package plan
 import (
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/ast"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/model"
 )
 
 var _ = Suite(&testPlanBuilderSuite{})
@@ -38,3 +40,27 @@ func (s *testPlanBuilderSuite) TestShowDatabases(c *C) {
 	c.Assert(schema.Columns, HasLen, 1)
 	c.Assert(schema.Columns[0].RetType.Flen, Equals, 256)
 }
+
+func (s *testPlanBuilderSuite) TestShowFilterNotSupported(c *C) {
+	node := &ast.ShowStmt{
+		Tp:    ast.ShowCreateTable,
+		Table: &ast.TableName{Name: model.NewCIStr("t")},
+		Where: &ast.ValueExpr{},
+	}
+	err := MockResolveName(node, infoschema.MockInfoSchema(nil), "test", mockContext())
+	c.Assert(err, ErrorMatches, ".*WHERE is not supported in this SHOW statement")
+
+	node = &ast.ShowStmt{
+		Tp:      ast.ShowEngines,
+		Pattern: &ast.PatternLikeExpr{Pattern: &ast.ValueExpr{}},
+	}
+	err = MockResolveName(node, infoschema.MockInfoSchema(nil), "test", mockContext())
+	c.Assert(err, ErrorMatches, ".*LIKE is not supported in this SHOW statement")
+
+	node = &ast.ShowStmt{
+		Tp:    ast.ShowOpenTables,
+		Where: &ast.ValueExpr{},
+	}
+	err = MockResolveName(node, infoschema.MockInfoSchema(nil), "test", mockContext())
+	c.Assert(err, ErrorMatches, ".*SHOW OPEN TABLES only supports LIKE")
+}
@@ -127,6 +127,8 @@ type Prepare struct {
 
 	Name    string
 	SQLText string
+	// Stmt is the PREPARE statement the plan is built from.
+	Stmt *ast.PrepareStmt
 }
 
 // Execute represents prepare plan.
@@ -142,7 +144,9 @@ type Execute struct {
 type Deallocate struct {
 	basePlan
 
-	Name string
+	Name    string
+	ExecID  uint32
+	UsingID bool
 }
 
 // Show represents a show plan.
@@ -155,10 +159,12 @@ type Show struct {
 	Column *ast.ColumnName // Used for `desc table column`.
 	Flag   int             // Some flag parsed from sql, such as FULL.
 	Full   bool
-	User   string // Used for show grants.
+	User   *ast.UserIdentity // Used for show grants, nil for the current user.
 
 	// Used by show variables
 	GlobalScope bool
+	// Used by show warnings/errors.
+	CountOnly bool
 }
 
 // Set represents a plan for set stmt.
@@ -199,6 +205,7 @@ type Analyze struct {
 	IdxOffsets []int
 	ColOffsets []int
 	PkOffset   int // Used only when pk is handle.
+	NumBuckets uint64
 }
 
 // LoadData represents a loaddata plan.
@@ -208,6 +215,7 @@ type LoadData struct {
 	IsLocal    bool
 	Path       string
 	Table      *ast.TableName
+	Columns    []*ast.ColumnName
 	FieldsInfo *ast.FieldsClause
 	LinesInfo  *ast.LinesClause
 }
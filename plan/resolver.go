@@ -189,6 +189,16 @@ func (nr *nameResolver) Enter(inNode ast.Node) (outNode ast.Node, skipChildren b
 	case *ast.DropTableStmt:
 		nr.pushContext()
 		nr.currentContext().inCreateOrDropTable = true
+	case *ast.FlashBackTableStmt, *ast.RecoverTableStmt:
+		// The table to restore has been dropped.
+		nr.pushContext()
+		nr.currentContext().inCreateOrDropTable = true
+	case *ast.SplitRegionStmt:
+		nr.pushContext()
+	case *ast.LockTablesStmt:
+		// Table locks are not supported, skip resolving the tables.
+		nr.pushContext()
+		nr.currentContext().inCreateOrDropTable = true
 	case *ast.DropIndexStmt:
 		nr.pushContext()
 	case *ast.FieldList:
@@ -199,17 +209,31 @@ func (nr *nameResolver) Enter(inNode ast.Node) (outNode ast.Node, skipChildren b
 		nr.currentContext().inHaving = true
 	case *ast.InsertStmt:
 		nr.pushContext()
-	case *ast.LoadDataStmt:
+	case *ast.LoadDataStmt, *ast.ImportIntoStmt:
 		nr.pushContext()
 	case *ast.Join:
 		nr.pushJoin(v)
 	case *ast.OnCondition:
 		nr.currentContext().inOnCondition = true
+	case *ast.OptimizeTableStmt, *ast.CheckTableStmt:
+		nr.pushContext()
+	case *ast.BRIEStmt:
+		// The tables to restore may not exist yet, skip resolving the tables.
+		nr.pushContext()
+		nr.currentContext().inCreateOrDropTable = true
+	case *ast.DropStatsStmt:
+		// The statement is not supported, skip resolving the tables.
+		nr.pushContext()
+		nr.currentContext().inCreateOrDropTable = true
 	case *ast.OrderByClause:
 		nr.currentContext().inOrderBy = true
 	case *ast.RenameTableStmt:
 		nr.pushContext()
 		nr.currentContext().inCreateOrDropTable = true
+	case *ast.RepairTableStmt:
+		// The metadata of the table to repair may be broken, skip resolving the tables.
+		nr.pushContext()
+		nr.currentContext().inCreateOrDropTable = true
 	case *ast.SelectStmt:
 		nr.pushContext()
 	case *ast.SetStmt:
@@ -222,6 +246,14 @@ func (nr *nameResolver) Enter(inNode ast.Node) (outNode ast.Node, skipChildren b
 		nr.pushContext()
 	case *ast.ShowStmt:
 		nr.pushContext()
+		if clause := showFilterClause(v); clause != "" && !v.Filterable() {
+			nr.Err = ErrUnsupportedType.Gen("%s is not supported in this SHOW statement", clause)
+			return inNode, true
+		}
+		if v.Tp == ast.ShowOpenTables && (v.Where != nil || v.OrderBy != nil) {
+			nr.Err = ErrUnsupportedType.Gen("SHOW OPEN TABLES only supports LIKE")
+			return inNode, true
+		}
 		nr.currentContext().inShow = true
 		nr.fillShowFields(v)
 	case *ast.TableRefsClause:
@@ -266,6 +298,8 @@ func (nr *nameResolver) Leave(inNode ast.Node) (node ast.Node, ok bool) {
 		nr.popContext()
 	case *ast.DropTableStmt:
 		nr.popContext()
+	case *ast.FlashBackTableStmt, *ast.RecoverTableStmt, *ast.LockTablesStmt, *ast.SplitRegionStmt:
+		nr.popContext()
 	case *ast.TableSource:
 		nr.handleTableSource(v)
 	case *ast.OnCondition:
@@ -295,7 +329,8 @@ func (nr *nameResolver) Leave(inNode ast.Node) (node ast.Node, ok bool) {
 		nr.currentContext().inByItemExpression = false
 	case *ast.PositionExpr:
 		nr.handlePosition(v)
-	case *ast.RenameTableStmt:
+	case *ast.OptimizeTableStmt, *ast.CheckTableStmt, *ast.RenameTableStmt, *ast.RepairTableStmt, *ast.BRIEStmt,
+		*ast.DropStatsStmt:
 		nr.popContext()
 	case *ast.SelectStmt:
 		ctx := nr.currentContext()
@@ -328,7 +363,7 @@ func (nr *nameResolver) Leave(inNode ast.Node) (node ast.Node, ok bool) {
 		nr.handleUnionSelectList(v)
 	case *ast.InsertStmt:
 		nr.popContext()
-	case *ast.LoadDataStmt:
+	case *ast.LoadDataStmt, *ast.ImportIntoStmt:
 		nr.popContext()
 	case *ast.DeleteStmt:
 		nr.popContext()
@@ -858,6 +893,19 @@ func (nr *nameResolver) handleUnionSelectList(u *ast.UnionSelectList) {
 	nr.currentContext().fieldList = unionFields
 }
 
+// showFilterClause returns the name of the first filter clause of s, or "" if s has none.
+func showFilterClause(s *ast.ShowStmt) string {
+	switch {
+	case s.Pattern != nil:
+		return "LIKE"
+	case s.Where != nil:
+		return "WHERE"
+	case s.OrderBy != nil:
+		return "ORDER BY"
+	}
+	return ""
+}
+
 func (nr *nameResolver) fillShowFields(s *ast.ShowStmt) {
 	if s.DBName == "" {
 		if s.Table != nil && s.Table.Schema.L != "" {
@@ -876,6 +924,24 @@ func (nr *nameResolver) fillShowFields(s *ast.ShowStmt) {
 	switch s.Tp {
 	case ast.ShowEngines:
 		names = []string{"Engine", "Support", "Comment", "Transactions", "XA", "Savepoints"}
+	case ast.ShowPlugins:
+		names = []string{"Name", "Status", "Type", "Library", "License"}
+	case ast.ShowPrivileges:
+		names = []string{"Privilege", "Context", "Comment"}
+	case ast.ShowProfiles:
+		names = []string{"Query_ID", "Duration", "Query"}
+		ftypes = []byte{mysql.TypeLonglong, mysql.TypeNewDecimal, mysql.TypeVarchar}
+	case ast.ShowProfile:
+		names, ftypes = showProfileColumns(s.ProfileTypes)
+	case ast.ShowStatsMeta, ast.ShowStatsHistograms, ast.ShowStatsBuckets, ast.ShowStatsHealthy:
+		names, ftypes = showStatsColumns(s.Tp)
+	case ast.ShowBindings:
+		names = []string{"Original_sql", "Bind_sql", "Default_db", "Status", "Create_time", "Update_time", "Charset", "Collation"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar,
+			mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeVarchar, mysql.TypeVarchar}
+	case ast.ShowOpenTables:
+		names = []string{"Database", "Table", "In_use", "Name_locked"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeLonglong}
 	case ast.ShowDatabases:
 		names = []string{"Database"}
 	case ast.ShowTables:
@@ -894,7 +960,16 @@ func (nr *nameResolver) fillShowFields(s *ast.ShowStmt) {
 			mysql.TypeVarchar, mysql.TypeVarchar}
 	case ast.ShowColumns:
 		names = table.ColDescFieldNames(s.Full)
-	case ast.ShowWarnings:
+	case ast.ShowWarnings, ast.ShowErrors:
+		if s.CountOnly {
+			if s.Tp == ast.ShowWarnings {
+				names = []string{"@@session.warning_count"}
+			} else {
+				names = []string{"@@session.error_count"}
+			}
+			ftypes = []byte{mysql.TypeLonglong}
+			break
+		}
 		names = []string{"Level", "Code", "Message"}
 		ftypes = []byte{mysql.TypeVarchar, mysql.TypeLong, mysql.TypeVarchar}
 	case ast.ShowCharset:
@@ -913,15 +988,23 @@ func (nr *nameResolver) fillShowFields(s *ast.ShowStmt) {
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
 	case ast.ShowGrants:
-		names = []string{fmt.Sprintf("Grants for %s", s.User)}
+		names = []string{fmt.Sprintf("Grants for %s", showGrantsUser(s.User))}
 	case ast.ShowTriggers:
 		names = []string{"Trigger", "Event", "Table", "Statement", "Timing", "Created",
 			"sql_mode", "Definer", "character_set_client", "collation_connection", "Database Collation"}
 		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar,
 			mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar}
-	case ast.ShowProcedureStatus, ast.ShowEvents:
-		names = []string{}
-		ftypes = []byte{}
+	case ast.ShowProcedureStatus:
+		names = []string{"Db", "Name", "Type", "Definer", "Modified", "Created", "Security_type", "Comment",
+			"character_set_client", "collation_connection", "Database Collation"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDatetime,
+			mysql.TypeDatetime, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar}
+	case ast.ShowEvents:
+		names = []string{"Db", "Name", "Time zone", "Definer", "Type", "Execute At", "Interval Value", "Interval Field",
+			"Starts", "Ends", "Status", "Originator", "character_set_client", "collation_connection", "Database Collation"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar,
+			mysql.TypeDatetime, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeVarchar,
+			mysql.TypeInt24, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar}
 	case ast.ShowIndex:
 		names = []string{"Table", "Non_unique", "Key_name", "Seq_in_index",
 			"Column_name", "Collation", "Cardinality", "Sub_part", "Packed",
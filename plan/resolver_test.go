@@ -99,4 +99,12 @@ func (ts *testNameResolverSuite) TestNameResolver(c *C) {
 			c.Assert(resolveErr, NotNil, Commentf("%s", tc.src))
 		}
 	}
+	// The subqueries referring to the outer query are marked as correlated.
+	node, err := ts.ParseOneStmt("select c1 from t1 where c2 in (select c2 from t2 where t2.c1 = t1.c1) and exists (select c1 from t3)", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(plan.ResolveName(node, domain.InfoSchema(), ctx), IsNil)
+	subqueries := ast.ExtractSubqueries(node)
+	c.Assert(subqueries, HasLen, 2)
+	c.Assert(subqueries[0].Correlated, IsTrue)
+	c.Assert(subqueries[1].Correlated, IsFalse)
 }
@@ -65,6 +65,12 @@ func (v *validator) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		if v.err != nil {
 			return in, true
 		}
+	case interface{ Validate() error }:
+		// Statements which check their own restrictions that the grammar can't express.
+		if err := node.Validate(); err != nil {
+			v.err = errors.Trace(err)
+			return in, true
+		}
 	}
 	return in, false
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/errors"
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb"
+	"github.com/pingcap/tidb/ast"
 	"github.com/pingcap/tidb/context"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/plan"
@@ -71,6 +72,21 @@ func (s *testValidatorSuite) TestValidator(c *C) {
 			errors.New("[schema:1068]Multiple primary key defined")},
 		{"create table t(c1 int not null, c2 int not null, primary key(c1), primary key(c2))", true,
 			errors.New("[schema:1068]Multiple primary key defined")},
+		{"execute stmt using @a, @b", false, nil},
+		{"import into t from '/tmp/t.csv' with thread = 8", false, nil},
+		{"analyze table t with 1024 buckets", false, nil},
+		{"analyze table t with 1025 buckets", false, errors.New("The number of buckets 1025 is larger than the maximum 1024")},
+		{"analyze table t index with 9223372036854775808 buckets", false, errors.New("The number of buckets 9223372036854775808 is larger than the maximum 1024")},
+		{"calibrate resource workload tpcc", false, nil},
+		{"query watch add action kill sql digest 'd1'", false, nil},
+		{"query watch add action kill action dryrun sql digest 'd1'", false, errors.New("QUERY WATCH option ACTION is given more than once")},
+		{"query watch add resource group rg1", false, errors.New("QUERY WATCH needs one of SQL TEXT, SQL DIGEST and PLAN DIGEST")},
+		{"calibrate resource duration '20m'", false, errors.New("CALIBRATE RESOURCE needs START_TIME for the time window")},
+		{"import into t from '/tmp/t.csv' with thread = 8, fast", false, errors.New("Unknown IMPORT INTO option 'fast'")},
+		{"backup database * to 'local:///tmp/backup' with rate = 120", false, errors.New("Unknown BACKUP option 'rate'")},
+		{"batch on a limit 100 delete from t", false, nil},
+		{"batch limit 0 delete from t", false, errors.New("BATCH LIMIT must be greater than 0")},
+		{"use `test `", false, ast.ErrWrongDBName.GenByArgs("test ")},
 	}
 
 	store, err := tidb.NewStore(tidb.EngineGoLevelDBMemory)
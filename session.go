@@ -353,6 +353,7 @@ func (s *session) ExecRestrictedSQL(ctx context.Context, sql string) (ast.Record
 		log.Errorf("ExecRestrictedSQL only executes one statement. Too many/few statement in %s", sql)
 		return nil, errors.New("wrong number of statement")
 	}
+	rawStmts[0].SetInternal()
 	// Some execution is done in compile stage, so we reset it before compile.
 	st, err := Compile(s, rawStmts[0])
 	if err != nil {
@@ -443,6 +444,9 @@ func (s *session) Execute(sql string) ([]ast.RecordSet, error) {
 	var rs []ast.RecordSet
 	ph := sessionctx.GetDomain(s).PerfSchema()
 	for i, rst := range rawStmts {
+		if _, ok := rst.(*ast.EmptyStmt); ok {
+			continue
+		}
 		s.prepareTxnCtx()
 		startTS := time.Now()
 		// Some execution is done in compile stage, so we reset it before compile.
@@ -1274,6 +1274,10 @@ func (s *testSessionSuite) TestShow(c *C) {
 	row, err = r.Next()
 	c.Assert(err, IsNil)
 	c.Assert(row.Data, HasLen, 1)
+	r = mustExecSQL(c, se, "show grants for current_user()")
+	row, err = r.Next()
+	c.Assert(err, IsNil)
+	c.Assert(row.Data, HasLen, 1)
 
 	mustExecSQL(c, se, dropDBSQL)
 }
@@ -1477,6 +1481,22 @@ func (s *testSessionSuite) TestExecRestrictedSQL(c *C) {
 	mustExecSQL(c, se, dropDBSQL)
 }
 
+func (s *testSessionSuite) TestExecuteEmptyStmt(c *C) {
+	defer testleak.AfterTest(c)()
+	dbName := "test_execute_empty_stmt"
+	dropDBSQL := fmt.Sprintf("drop database %s;", dbName)
+	se := newSession(c, s.store, dbName)
+	// Empty statements are skipped.
+	rs, err := se.Execute(";")
+	c.Assert(err, IsNil)
+	c.Assert(rs, HasLen, 0)
+	rs, err = se.Execute("select 1;;select 2;")
+	c.Assert(err, IsNil)
+	c.Assert(rs, HasLen, 1)
+
+	mustExecSQL(c, se, dropDBSQL)
+}
+
 func (s *testSessionSuite) TestGroupBy(c *C) {
 	defer testleak.AfterTest(c)()
 	dbName := "test_groupby"